@@ -0,0 +1,84 @@
+package boardingpass
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// prefix marks a GoBus boarding pass and the version of its layout.
+const prefix = "GOBUS1"
+
+// signatureBytes is how much of the HMAC-SHA256 is kept, it keeps the QR code small.
+const signatureBytes = 16
+
+// ErrInvalid is returned for a payload that was not signed with the key or has been changed.
+var ErrInvalid = errors.New("ticket signature is not valid")
+
+// Pass struct is what the QR code of a ticket carries, the booking, its seats and the day of travel.
+type Pass struct {
+	BookingID uint
+	Day       time.Time
+	Seats     []string
+}
+
+// Sign function is used to encode the pass as a signed payload of the form GOBUS1|booking|day|seats|signature.
+func Sign(pass *Pass, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", errors.New("no signing key set")
+	}
+	if pass.BookingID == 0 || len(pass.Seats) == 0 {
+		return "", errors.New("a pass needs a booking and its seats")
+	}
+	for _, seat := range pass.Seats {
+		if seat == "" || strings.ContainsAny(seat, "|,") {
+			return "", errors.New("seat " + seat + " cannot be put on a pass")
+		}
+	}
+	body := strings.Join([]string{
+		prefix,
+		strconv.FormatUint(uint64(pass.BookingID), 10),
+		pass.Day.Format("20060102"),
+		strings.Join(pass.Seats, ","),
+	}, "|")
+	return body + "|" + signature(body, key), nil
+}
+
+// Verify function is used to check the signature of the payload and read back its pass.
+func Verify(payload string, key []byte) (*Pass, error) {
+	if len(key) == 0 {
+		return nil, errors.New("no signing key set")
+	}
+	payload = strings.TrimSpace(payload)
+	cut := strings.LastIndex(payload, "|")
+	if cut < 0 {
+		return nil, ErrInvalid
+	}
+	body, sig := payload[:cut], payload[cut+1:]
+	if !hmac.Equal([]byte(sig), []byte(signature(body, key))) {
+		return nil, ErrInvalid
+	}
+	fields := strings.Split(body, "|")
+	if len(fields) != 4 || fields[0] != prefix {
+		return nil, ErrInvalid
+	}
+	bookingID, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	day, err := time.Parse("20060102", fields[2])
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	return &Pass{BookingID: uint(bookingID), Day: day, Seats: strings.Split(fields[3], ",")}, nil
+}
+
+func signature(body string, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureBytes])
+}
@@ -0,0 +1,66 @@
+package boardingpass
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_SignAndVerify(t *testing.T) {
+	key := []byte("secret")
+	pass := &Pass{BookingID: 42, Day: time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC), Seats: []string{"01A", "01B"}}
+	payload, err := Sign(pass, key)
+	if err != nil {
+		t.Fatalf("boardingpass.Sign() error = %v", err)
+	}
+	if !strings.HasPrefix(payload, "GOBUS1|42|20300102|01A,01B|") {
+		t.Fatalf("boardingpass.Sign() = %s", payload)
+	}
+	got, err := Verify(payload, key)
+	if err != nil {
+		t.Fatalf("boardingpass.Verify() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, pass) {
+		t.Errorf("boardingpass.Verify() = %+v, want %+v", got, pass)
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		key     []byte
+	}{
+		{name: "other key", payload: payload, key: []byte("other")},
+		{name: "seat added", payload: strings.Replace(payload, "01A,01B", "01A,01B,02A", 1), key: key},
+		{name: "other booking", payload: strings.Replace(payload, "|42|", "|43|", 1), key: key},
+		{name: "no signature", payload: "GOBUS1|42|20300102|01A", key: key},
+		{name: "garbage", payload: "hello", key: key},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Verify(tt.payload, tt.key); err != ErrInvalid {
+				t.Errorf("boardingpass.Verify() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func Test_SignRejects(t *testing.T) {
+	day := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		pass *Pass
+		key  []byte
+	}{
+		{name: "no key", pass: &Pass{BookingID: 1, Day: day, Seats: []string{"01A"}}},
+		{name: "no seats", pass: &Pass{BookingID: 1, Day: day}, key: []byte("secret")},
+		{name: "separator in seat", pass: &Pass{BookingID: 1, Day: day, Seats: []string{"01|A"}}, key: []byte("secret")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Sign(tt.pass, tt.key); err == nil {
+				t.Errorf("boardingpass.Sign() expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package cancellation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxTiers is the number of tiers a policy can have.
+const MaxTiers = 10
+
+// Tier struct is a step of a policy, RefundPercent of the paid fare is refunded when cancelling at least HoursBefore hours before departure.
+type Tier struct {
+	HoursBefore   int `json:"hours_before"`
+	RefundPercent int `json:"refund_percent"`
+}
+
+// String function describes the tier the way it is shown to the user.
+func (t Tier) String() string {
+	if t.HoursBefore == 0 {
+		return fmt.Sprintf("%d%% refund until departure", t.RefundPercent)
+	}
+	return fmt.Sprintf("%d%% refund from %dh before departure", t.RefundPercent, t.HoursBefore)
+}
+
+// Policy struct holds the tiers of a cancellation policy, ordered from the earliest cancellation to the latest.
+type Policy struct {
+	Tiers []Tier
+}
+
+// Quote struct is the outcome of a policy for a cancellation at a given time.
+type Quote struct {
+	RefundPercent int
+	HoursLeft     float64
+	Departed      bool
+	Rule          string
+}
+
+// Default function returns the policy used when neither the bus nor its provider has one, 90% is refunded until departure.
+func Default() *Policy {
+	return &Policy{Tiers: []Tier{{HoursBefore: 0, RefundPercent: 90}}}
+}
+
+// New function is used to build a policy from its tiers, a tier closer to departure cannot refund more than an earlier one.
+func New(tiers []Tier) (*Policy, error) {
+	if len(tiers) == 0 {
+		return nil, errors.New("a cancellation policy needs at least one tier")
+	}
+	if len(tiers) > MaxTiers {
+		return nil, fmt.Errorf("a cancellation policy can have at most %d tiers", MaxTiers)
+	}
+	sorted := append([]Tier{}, tiers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].HoursBefore > sorted[j].HoursBefore })
+	for i, tier := range sorted {
+		if tier.HoursBefore < 0 {
+			return nil, errors.New("hours before departure cannot be negative")
+		}
+		if tier.RefundPercent < 0 || tier.RefundPercent > 100 {
+			return nil, errors.New("refund percent must be between 0 and 100")
+		}
+		if i > 0 && tier.HoursBefore == sorted[i-1].HoursBefore {
+			return nil, fmt.Errorf("more than one tier for %dh before departure", tier.HoursBefore)
+		}
+		if i > 0 && tier.RefundPercent > sorted[i-1].RefundPercent {
+			return nil, errors.New("a later cancellation cannot be refunded more than an earlier one")
+		}
+	}
+	return &Policy{Tiers: sorted}, nil
+}
+
+// Decode function is used to build a policy from the tiers stored as JSON.
+func Decode(data []byte) (*Policy, error) {
+	var tiers []Tier
+	if err := json.Unmarshal(data, &tiers); err != nil {
+		return nil, err
+	}
+	return New(tiers)
+}
+
+// Encode function is used to encode the tiers for storing them.
+func (p *Policy) Encode() ([]byte, error) {
+	return json.Marshal(p.Tiers)
+}
+
+// Evaluate function returns the refund the policy gives for cancelling at the given time, nothing is refunded once the bus has left.
+func (p *Policy) Evaluate(departure time.Time, at time.Time) Quote {
+	left := departure.Sub(at)
+	quote := Quote{HoursLeft: left.Hours()}
+	if left < 0 {
+		quote.Departed = true
+		quote.Rule = "no refund after departure"
+		return quote
+	}
+	for _, tier := range p.Tiers {
+		if left >= time.Duration(tier.HoursBefore)*time.Hour {
+			quote.RefundPercent = tier.RefundPercent
+			quote.Rule = tier.String()
+			return quote
+		}
+	}
+	quote.Rule = fmt.Sprintf("no refund within %dh of departure", p.Tiers[len(p.Tiers)-1].HoursBefore)
+	return quote
+}
+
+// Refund function returns the part of the amount the quote refunds.
+func (q Quote) Refund(amount float64) float64 {
+	return amount * float64(q.RefundPercent) / 100
+}
@@ -0,0 +1,72 @@
+package cancellation
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		name    string
+		tiers   []Tier
+		wantErr bool
+	}{
+		{name: "tiers", tiers: []Tier{{HoursBefore: 24, RefundPercent: 75}, {HoursBefore: 48, RefundPercent: 100}}},
+		{name: "no tiers", wantErr: true},
+		{name: "negative hours", tiers: []Tier{{HoursBefore: -1, RefundPercent: 50}}, wantErr: true},
+		{name: "percent above 100", tiers: []Tier{{HoursBefore: 0, RefundPercent: 120}}, wantErr: true},
+		{name: "duplicate hours", tiers: []Tier{{HoursBefore: 24, RefundPercent: 75}, {HoursBefore: 24, RefundPercent: 50}}, wantErr: true},
+		{name: "later refunds more", tiers: []Tier{{HoursBefore: 48, RefundPercent: 50}, {HoursBefore: 24, RefundPercent: 75}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.tiers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("cancellation.New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Policy_Evaluate(t *testing.T) {
+	policy, err := New([]Tier{{HoursBefore: 24, RefundPercent: 75}, {HoursBefore: 48, RefundPercent: 100}})
+	if err != nil {
+		t.Fatalf("cancellation.New() error = %v", err)
+	}
+	departure := time.Date(2030, 1, 10, 22, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		before      time.Duration
+		wantPercent int
+		wantRule    string
+	}{
+		{name: "early", before: 72 * time.Hour, wantPercent: 100, wantRule: "100% refund from 48h before departure"},
+		{name: "on the tier", before: 48 * time.Hour, wantPercent: 100, wantRule: "100% refund from 48h before departure"},
+		{name: "a day before", before: 30 * time.Hour, wantPercent: 75, wantRule: "75% refund from 24h before departure"},
+		{name: "last minute", before: time.Hour, wantPercent: 0, wantRule: "no refund within 24h of departure"},
+		{name: "departed", before: -time.Hour, wantPercent: 0, wantRule: "no refund after departure"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := policy.Evaluate(departure, departure.Add(-tt.before))
+			if quote.RefundPercent != tt.wantPercent || quote.Rule != tt.wantRule {
+				t.Errorf("Policy.Evaluate() = %+v, want %d%% by %q", quote, tt.wantPercent, tt.wantRule)
+			}
+		})
+	}
+	if refund := Default().Evaluate(departure, departure.Add(-time.Minute)).Refund(200); refund != 180 {
+		t.Errorf("Default().Evaluate().Refund() = %v, want 180", refund)
+	}
+}
+
+func Test_Decode(t *testing.T) {
+	policy, _ := New([]Tier{{HoursBefore: 0, RefundPercent: 50}, {HoursBefore: 12, RefundPercent: 80}})
+	data, err := policy.Encode()
+	if err != nil {
+		t.Fatalf("Policy.Encode() error = %v", err)
+	}
+	decoded, err := Decode(data)
+	if err != nil || len(decoded.Tiers) != 2 || decoded.Tiers[0].HoursBefore != 12 {
+		t.Errorf("cancellation.Decode() = %+v, error = %v", decoded, err)
+	}
+}
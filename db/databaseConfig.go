@@ -15,6 +15,18 @@ func ConnectDB() *gorm.DB {
 	if err != nil {
 		panic("Unable to connect to DB")
 	}
+	// bookings stored without a PNR must hold NULL rather than '' or the unique index on the PNR cannot be built
+	if db.Migrator().HasColumn(&entities.Booking{}, "PNR") {
+		db.Model(&entities.Booking{}).Where("pnr=?", "").Update("pnr", nil)
+	}
+	// a booking is settled once per trip it was on, no longer once in all
+	if db.Migrator().HasIndex(&entities.Settlement{}, "idx_settlements_booking_id") {
+		db.Migrator().DropIndex(&entities.Settlement{}, "idx_settlements_booking_id")
+	}
+	// an issue is told apart by its booking and ledger entry too, not only by its order and payment
+	if db.Migrator().HasIndex(&entities.ReconciliationIssue{}, "idx_reconciliation_issue") {
+		db.Migrator().DropIndex(&entities.ReconciliationIssue{}, "idx_reconciliation_issue")
+	}
 	db.AutoMigrate(&entities.User{},
 		&entities.ServiceProvider{},
 		&entities.Buses{},
@@ -29,6 +41,23 @@ func ConnectDB() *gorm.DB {
 		&entities.BaseFare{},
 		&entities.RazorPay{},
 		&entities.SubStation{},
+		&entities.ScheduleStop{},
+		&entities.BoardingPoint{},
+		&entities.ScheduleRecurrence{},
+		&entities.BookingItem{},
+		&entities.CancellationPolicy{},
+		&entities.LedgerAccount{},
+		&entities.LedgerEntry{},
+		&entities.LedgerLine{},
+		&entities.WalletTopup{},
+		&entities.ProviderCommission{},
+		&entities.Settlement{},
+		&entities.Payout{},
+		&entities.SuspiciousPayment{},
+		&entities.PaymentEvent{},
+		&entities.Refund{},
+		&entities.PaymentOrder{},
+		&entities.ReconciliationIssue{},
 	)
 	return db
 }
@@ -53,7 +82,24 @@ func DropTables() {
 		&entities.Stations{},
 		&entities.BaseFare{},
 		&entities.RazorPay{},
-		&entities.SubStation{}); err != nil {
+		&entities.SubStation{},
+		&entities.ScheduleStop{},
+		&entities.BoardingPoint{},
+		&entities.ScheduleRecurrence{},
+		&entities.BookingItem{},
+		&entities.CancellationPolicy{},
+		&entities.LedgerAccount{},
+		&entities.LedgerEntry{},
+		&entities.LedgerLine{},
+		&entities.WalletTopup{},
+		&entities.ProviderCommission{},
+		&entities.Settlement{},
+		&entities.Payout{},
+		&entities.SuspiciousPayment{},
+		&entities.PaymentEvent{},
+		&entities.Refund{},
+		&entities.PaymentOrder{},
+		&entities.ReconciliationIssue{}); err != nil {
 		fmt.Println("Error dropping the table:", err)
 		return
 	}
@@ -1,6 +1,8 @@
 package di
 
 import (
+	"fmt"
+	"gobus/recurrence"
 	"gobus/services/interfaces"
 	"time"
 )
@@ -18,3 +20,101 @@ func CouponValidator(ps interfaces.ProviderService) {
 		}
 	}
 }
+
+// PaymentReconciler is used to check the orders of the last days against the payment gateway and report what does not agree.
+func PaymentReconciler(as interfaces.AdminService) {
+	report, err := as.ReconcilePayments()
+	if err != nil {
+		fmt.Println("Error reconciling the payments:", err)
+		return
+	}
+	if len(report.Issues) > 0 || report.Unreachable > 0 {
+		fmt.Printf("Payment reconciliation checked %d orders: %d new issues, %d orders unreachable\n", report.Checked, len(report.Issues), report.Unreachable)
+	}
+}
+
+// ChartGenerator is used to create the charts of the coming days for every bus with a recurrence.
+func ChartGenerator(as interfaces.AdminService) {
+	today := time.Now()
+	from := today.Format("02 01 2006")
+	to := today.AddDate(0, 0, recurrence.DaysAhead()).Format("02 01 2006")
+	result, err := as.GenerateCharts(0, from, to)
+	if err != nil {
+		fmt.Println("Error generating the charts:", err)
+		return
+	}
+	if result.Created > 0 || len(result.Failed) > 0 {
+		fmt.Printf("Generated %d charts, %d buses failed\n", result.Created, len(result.Failed))
+	}
+}
+
+// SeatHoldSweeper is used to release the seats of the bookings whose payment hold has expired.
+func SeatHoldSweeper(us interfaces.UserService) {
+	released, err := us.ReleaseExpiredHolds()
+	if released > 0 {
+		fmt.Printf("Released the seats of %d expired bookings\n", released)
+	}
+	if err != nil {
+		fmt.Println("Error releasing the expired seat holds:", err)
+	}
+}
+
+// PNRBackfill is used to give a PNR to the bookings made before bookings had one.
+func PNRBackfill(us interfaces.UserService) {
+	assigned, err := us.AssignMissingPNRs()
+	if err != nil {
+		fmt.Println("Error assigning the missing PNRs:", err)
+		return
+	}
+	if assigned > 0 {
+		fmt.Printf("Assigned a PNR to %d bookings\n", assigned)
+	}
+}
+
+// LedgerCheck is used to report when the wallet ledger does not balance.
+func LedgerCheck(as interfaces.AdminService) {
+	report, err := as.ReconcileLedger()
+	if err != nil {
+		fmt.Println("Error reconciling the ledger:", err)
+		return
+	}
+	if !report.Balanced {
+		fmt.Printf("Ledger does not balance: journal total %d, %d unbalanced entries, %d mismatched accounts\n", report.JournalTotal, len(report.UnbalancedEntries), len(report.Mismatches))
+	}
+}
+
+// TripSettlement is used to release the fares of the completed trips to the providers, less the commission.
+func TripSettlement(as interfaces.AdminService) {
+	settled, err := as.SettleTrips()
+	if err != nil {
+		fmt.Println("Error settling the completed trips:", err)
+		return
+	}
+	if settled > 0 {
+		fmt.Printf("Settled the fares of %d bookings\n", settled)
+	}
+}
+
+// PayoutBatcher is used to gather the settled fares of every provider into a payout waiting for approval.
+func PayoutBatcher(as interfaces.AdminService) {
+	batched, err := as.BatchPayouts()
+	if err != nil {
+		fmt.Println("Error batching the payouts:", err)
+		return
+	}
+	if batched > 0 {
+		fmt.Printf("Raised %d payouts\n", batched)
+	}
+}
+
+// RefundRetrier is used to send the refunds the payment gateway turned down or never got back to it.
+func RefundRetrier(as interfaces.AdminService) {
+	issued, err := as.RetryRefunds()
+	if err != nil {
+		fmt.Println("Error retrying the refunds:", err)
+		return
+	}
+	if issued > 0 {
+		fmt.Printf("Issued %d refunds\n", issued)
+	}
+}
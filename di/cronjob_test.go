@@ -0,0 +1,113 @@
+package di
+
+import (
+	"errors"
+	"gobus/dto"
+	"gobus/recurrence"
+	"gobus/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_SeatHoldSweeper(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		beforeTest func(userService *services.MockUserService)
+	}{
+		{
+			name: "success holds released",
+			beforeTest: func(userService *services.MockUserService) {
+				userService.EXPECT().ReleaseExpiredHolds().Return(2, nil)
+			},
+		},
+		{
+			name: "success nothing expired",
+			beforeTest: func(userService *services.MockUserService) {
+				userService.EXPECT().ReleaseExpiredHolds().Return(0, nil)
+			},
+		},
+		{
+			name: "expired holds not found",
+			beforeTest: func(userService *services.MockUserService) {
+				userService.EXPECT().ReleaseExpiredHolds().Return(0, errors.New("Oops"))
+			},
+		},
+		{
+			name: "some holds not released",
+			beforeTest: func(userService *services.MockUserService) {
+				userService.EXPECT().ReleaseExpiredHolds().Return(1, errors.New("booking 3: Oops"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := services.NewMockUserService(ctrl)
+			tt.beforeTest(mockService)
+			SeatHoldSweeper(mockService)
+		})
+	}
+}
+
+func Test_PNRBackfill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		beforeTest func(userService *services.MockUserService)
+	}{
+		{
+			name: "success PNRs assigned",
+			beforeTest: func(userService *services.MockUserService) {
+				userService.EXPECT().AssignMissingPNRs().Return(3, nil)
+			},
+		},
+		{
+			name: "bookings not found",
+			beforeTest: func(userService *services.MockUserService) {
+				userService.EXPECT().AssignMissingPNRs().Return(0, errors.New("Oops"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := services.NewMockUserService(ctrl)
+			tt.beforeTest(mockService)
+			PNRBackfill(mockService)
+		})
+	}
+}
+
+func Test_ChartGenerator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	from := time.Now().Format("02 01 2006")
+	to := time.Now().AddDate(0, 0, recurrence.DaysAhead()).Format("02 01 2006")
+	tests := []struct {
+		name       string
+		beforeTest func(adminService *services.MockAdminService)
+	}{
+		{
+			name: "success charts generated for every bus",
+			beforeTest: func(adminService *services.MockAdminService) {
+				adminService.EXPECT().GenerateCharts(0, from, to).Return(&dto.ChartGenerationResult{Created: 30, Failed: []string{"bus 2: no seat layout assigned to the bus type SE"}}, nil)
+			},
+		},
+		{
+			name: "charts not generated",
+			beforeTest: func(adminService *services.MockAdminService) {
+				adminService.EXPECT().GenerateCharts(0, from, to).Return(nil, errors.New("Oops"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := services.NewMockAdminService(ctrl)
+			tt.beforeTest(mockService)
+			ChartGenerator(mockService)
+		})
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"gobus/middleware"
 	"gobus/otphandler"
 	otphandlerprovider "gobus/otphandler_provider"
+	"gobus/payment"
 	"gobus/repository"
 	"gobus/routes"
+	"gobus/seathold"
 	"gobus/server"
 	"gobus/services"
 
@@ -22,12 +24,21 @@ func Init() *server.Serverstruct {
 	// entities.SeatLayoutStr(db)
 	otphandler.InitRedis()
 	otphandlerprovider.InitRedis()
+	var seatHold seathold.SeatHold
+	seatHold, err := seathold.NewRedisSeatHold()
+	if err != nil {
+		fmt.Println("Error connecting the seat holds, holds expire from the database only:", err)
+		seatHold = seathold.DatabaseHold{}
+	}
+	gateway := payment.NewGateway()
 	userRepository := repository.NewUserRepository(db)
 	adminRepository := repository.NewAdminRepository(db)
 	providerRepository := repository.NewProviderRepository(db)
-	userService := services.NewUserService(userRepository, jwt)
-	adminService := services.NewAdminService(adminRepository, jwt)
+	userService := services.NewUserService(userRepository, jwt, seatHold, gateway)
+	adminService := services.NewAdminService(adminRepository, jwt, gateway)
 	providerService := services.NewProviderService(providerRepository, jwt)
+	// the bookings made before the PNR get theirs before any request can load and save them without one
+	PNRBackfill(userService)
 	userHandler := handlers.NewUserHandler(userService)
 	adminHandler := handlers.NewAdminHandler(adminService)
 	providerHandler := handlers.NewProviderHandler(providerService)
@@ -40,13 +51,60 @@ func Init() *server.Serverstruct {
 	adminRoutes.Routes()
 	userRoutes.URoutes()
 	providerRoutes.ProRoutes()
+	if fake, ok := gateway.(*payment.FakeGateway); ok {
+		// the fake gateway has no checkout page, its orders are paid through this route instead
+		server.R.GET("/user/payment/fake/:order", handlers.NewFakeCheckoutHandler(fake).Pay)
+	}
 	c := cron.New()
-	err := c.AddFunc("0 0 * * *", func() {
+	err = c.AddFunc("0 0 * * *", func() {
 		CouponValidator(providerService)
 	})
 	if err != nil {
 		fmt.Println("Error adding cron job:", err)
 	}
+	err = c.AddFunc("0 30 1 * * *", func() {
+		PaymentReconciler(adminService)
+	})
+	if err != nil {
+		fmt.Println("Error adding cron job:", err)
+	}
+	err = c.AddFunc("@every 1m", func() {
+		SeatHoldSweeper(userService)
+	})
+	if err != nil {
+		fmt.Println("Error adding cron job:", err)
+	}
+	err = c.AddFunc("@daily", func() {
+		ChartGenerator(adminService)
+	})
+	if err != nil {
+		fmt.Println("Error adding cron job:", err)
+	}
+	err = c.AddFunc("@daily", func() {
+		LedgerCheck(adminService)
+	})
+	if err != nil {
+		fmt.Println("Error adding cron job:", err)
+	}
+	err = c.AddFunc("@hourly", func() {
+		TripSettlement(adminService)
+	})
+	if err != nil {
+		fmt.Println("Error adding cron job:", err)
+	}
+	err = c.AddFunc("@weekly", func() {
+		PayoutBatcher(adminService)
+	})
+	if err != nil {
+		fmt.Println("Error adding cron job:", err)
+	}
+	err = c.AddFunc("@every 15m", func() {
+		RefundRetrier(adminService)
+	})
+	if err != nil {
+		fmt.Println("Error adding cron job:", err)
+	}
 	c.Start()
+	go ChartGenerator(adminService)
 	return server
 }
@@ -0,0 +1,28 @@
+package dto
+
+// BoardingScanRequest struct is used by the crew to scan the QR code of a ticket on a trip of their bus.
+type BoardingScanRequest struct {
+	Payload string `json:"payload" validate:"required"`
+	BusID   uint   `json:"bus_id" validate:"required"`
+	Date    string `json:"date" validate:"required"`
+}
+
+// BoardingScanResponse struct is used to tell the crew which seats of the ticket were boarded and which were not.
+type BoardingScanResponse struct {
+	BookingID      uint          `json:"booking_id"`
+	PNR            string        `json:"pnr"`
+	Boarded        []string      `json:"boarded"`
+	AlreadyBoarded []string      `json:"already_boarded,omitempty"`
+	Cancelled      []string      `json:"cancelled,omitempty"`
+	Trip           *TripBoarding `json:"trip"`
+}
+
+// TripBoarding struct is the live boarding count of a trip, a seat not boarded once the bus left its stop is a no-show.
+type TripBoarding struct {
+	BusID      uint   `json:"bus_id"`
+	Date       string `json:"date"`
+	Booked     int    `json:"booked"`
+	Boarded    int    `json:"boarded"`
+	YetToBoard int    `json:"yet_to_board"`
+	NoShow     int    `json:"no_show"`
+}
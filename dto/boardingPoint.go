@@ -0,0 +1,22 @@
+package dto
+
+// BoardingPointRequest struct is used to attach a sub station to a bus as a boarding or dropping point.
+type BoardingPointRequest struct {
+	BusID        uint   `json:"bus_id" validate:"required"`
+	SubStationID uint   `json:"sub_station_id" validate:"required"`
+	PointType    string `json:"type" validate:"required,oneof=boarding dropping"`
+	Address      string `json:"address" validate:"required"`
+	TimeOffset   int    `json:"time_offset"`
+}
+
+// BoardingPointResponse struct is used to return a boarding or dropping point of a bus with the time the bus reaches it.
+type BoardingPointResponse struct {
+	ID        uint   `json:"id"`
+	BusID     uint   `json:"bus_id"`
+	BusNumber string `json:"bus_number"`
+	PointType string `json:"type"`
+	Name      string `json:"name"`
+	Address   string `json:"address"`
+	Stop      string `json:"stop"`
+	Time      string `json:"time"`
+}
@@ -7,7 +7,13 @@ type BookingRequest struct {
 	UsedCouponID         uint          `json:"coupon_id"`
 	BusID                uint          `json:"bus_id" gorm:"not null" validate:"required"`
 	PassengerID          pq.Int64Array `json:"passenger_id" gorm:"not null" validate:"required"`
-	SeatsReserved        []string      `json:"seat_reserved" gorm:"not null" validate:"required"`
+	SeatsReserved        []string      `json:"seat_reserved" gorm:"not null" validate:"required_unless=Waitlist true"`
 	BookingDate          string        `json:"booking_date" gorm:"not null" validate:"required"`
 	PreferredPaymentType string        `json:"payment_type" gorm:"default: Wallet"`
+	FromStation          string        `json:"from_station"`
+	ToStation            string        `json:"to_station"`
+	BoardingPointID      uint          `json:"boarding_point_id"`
+	DroppingPointID      uint          `json:"dropping_point_id"`
+	SeatClass            string        `json:"seat_class"`
+	Waitlist             bool          `json:"waitlist"`
 }
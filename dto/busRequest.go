@@ -4,6 +4,30 @@ package dto
 type BusRequest struct {
 	DepartureStation string `json:"depart" gorm:"not null" validate:"required"`
 	ArrivalStation   string `json:"arrival" gorm:"not null" validate:"required"`
-	Price            int
-	Duration         int `json:"duration"`
+	Date             string `json:"date"`
+	Price            int    `json:"max_price"`
+	Duration         int    `json:"duration"`
+	BusType          string `json:"bus_type"`
+	AC               *bool  `json:"ac"`
+	SortBy           string `json:"sort_by"`
+}
+
+// BusSearchResult struct is used to return one bus running on the requested route and day along with its availability and fares.
+type BusSearchResult struct {
+	BusID            uint    `json:"bus_id"`
+	BusNumber        string  `json:"bus_number"`
+	BusTypeCode      string  `json:"bus_type"`
+	Date             string  `json:"date"`
+	BookingDate      string  `json:"booking_date"`
+	FromStation      string  `json:"from_station"`
+	ToStation        string  `json:"to_station"`
+	DepartureTime    string  `json:"departure_time"`
+	ArrivalTime      string  `json:"arrival_time"`
+	DurationMinutes  int     `json:"duration_minutes"`
+	SleeperSeatsLeft int     `json:"sleeper_seats_left"`
+	SeaterSeatsLeft  int     `json:"seater_seats_left"`
+	SleeperFare      float64 `json:"sleeper_fare,omitempty"`
+	SeaterFare       float64 `json:"seater_fare,omitempty"`
+	Fare             float64 `json:"fare"`
+	ChartStatus      string  `json:"chart_status"`
 }
@@ -5,3 +5,27 @@ type BusSchedule struct {
 	BusID uint   `json:"bus_id" gorm:"not_null" validate:"required"`
 	Day   string `json:"day" gorm:"not_null" validate:"required"`
 }
+
+// RecurrenceRequest struct is used to set the days a bus runs on, the dates are in "02 01 2006" format.
+type RecurrenceRequest struct {
+	BusID         uint     `json:"bus_id" validate:"required"`
+	Frequency     string   `json:"frequency" validate:"required,oneof=daily weekdays days"`
+	Days          []string `json:"days"`
+	ExcludedDates []string `json:"excluded_dates"`
+	StartDate     string   `json:"start_date"`
+	EndDate       string   `json:"end_date"`
+}
+
+// ChartBackfillRequest struct is used to generate the charts of a date range, a missing bus id covers every bus with a recurrence.
+type ChartBackfillRequest struct {
+	BusID uint   `json:"bus_id"`
+	From  string `json:"from" validate:"required"`
+	To    string `json:"to" validate:"required"`
+}
+
+// ChartGenerationResult struct is used to report how many charts a generation run created.
+type ChartGenerationResult struct {
+	Created int      `json:"created"`
+	Skipped int      `json:"skipped"`
+	Failed  []string `json:"failed,omitempty"`
+}
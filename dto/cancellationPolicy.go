@@ -0,0 +1,35 @@
+package dto
+
+// RefundTier struct is one step of a cancellation policy.
+type RefundTier struct {
+	HoursBefore   int `json:"hours_before" validate:"min=0"`
+	RefundPercent int `json:"refund_percent" validate:"min=0,max=100"`
+}
+
+// CancellationPolicyRequest struct is used to set the cancellation policy of a provider, or of one of their buses when a bus is given.
+type CancellationPolicyRequest struct {
+	BusID uint         `json:"bus_id"`
+	Tiers []RefundTier `json:"tiers" validate:"required,min=1,dive"`
+}
+
+// CancellationPolicyResponse struct is used to return a cancellation policy with its tiers from the earliest cancellation to the latest.
+type CancellationPolicyResponse struct {
+	ID         uint         `json:"id"`
+	ProviderID uint         `json:"provider_id"`
+	BusID      uint         `json:"bus_id"`
+	Tiers      []RefundTier `json:"tiers"`
+	Rules      []string     `json:"rules"`
+}
+
+// RefundQuote struct is used to show the refund of a cancellation before it is confirmed.
+type RefundQuote struct {
+	BookingID          uint     `json:"booking_id"`
+	Seats              []string `json:"seats"`
+	Departure          string   `json:"departure"`
+	HoursToDeparture   float64  `json:"hours_to_departure"`
+	Rule               string   `json:"rule"`
+	RefundPercent      int      `json:"refund_percent"`
+	PaidAmount         float64  `json:"paid_amount"`
+	CancellationCharge float64  `json:"cancellation_charge"`
+	RefundAmount       float64  `json:"refund_amount"`
+}
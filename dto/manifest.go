@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// ManifestEntry struct is a booked seat of a trip with the passenger travelling on it.
+type ManifestEntry struct {
+	Seat          string     `json:"seat"`
+	BookingID     uint       `json:"booking_id"`
+	PNR           string     `json:"pnr"`
+	PassengerID   uint       `json:"passenger_id"`
+	Name          string     `json:"passenger_name"`
+	Age           uint       `json:"age"`
+	Gender        string     `json:"gender"`
+	From          string     `json:"from"`
+	To            string     `json:"to"`
+	BoardingPoint string     `json:"boarding_point,omitempty"`
+	BoardingTime  string     `json:"boarding_time,omitempty"`
+	DroppingPoint string     `json:"dropping_point,omitempty"`
+	BoardedAt     *time.Time `json:"boarded_at,omitempty"`
+}
+
+// TripManifest struct is the passenger list of a trip of a bus of the provider.
+type TripManifest struct {
+	BusID      uint             `json:"bus_id"`
+	BusNumber  string           `json:"bus_number"`
+	Date       string           `json:"date"`
+	Route      string           `json:"route"`
+	Departure  string           `json:"departure"`
+	Passengers []*ManifestEntry `json:"passengers"`
+}
@@ -3,7 +3,9 @@ package dto
 // MakePaymentResp is used for responding to the RazorPay API.
 type MakePaymentResp struct {
 	BookingID      int
-	AmountInRupees int
+	PNR            string
+	AmountInRupees float64
+	AmountInPaisa  int64
 	OrderID        string
 	Email          string
 	PhoneNumber    string
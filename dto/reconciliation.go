@@ -0,0 +1,15 @@
+package dto
+
+import "gobus/entities"
+
+// PaymentReconciliation struct is the report of a run checking the orders against the gateway and the confirmed bookings and gateway ledger entries against the payments, listing the issues found for the first time.
+type PaymentReconciliation struct {
+	Checked     int                             `json:"checked"`
+	Unreachable int                             `json:"unreachable"`
+	Issues      []*entities.ReconciliationIssue `json:"issues"`
+}
+
+// IssueResolution struct is used by the admin to resolve a reconciliation issue.
+type IssueResolution struct {
+	Remarks string `json:"remarks" validate:"required"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// RescheduleRequest struct is used to move a booking to another date or bus on the same route, the bus stays the same when none is given. A lower fare is refunded to the wallet or back to the payment.
+type RescheduleRequest struct {
+	BusID                uint     `json:"bus_id"`
+	BookingDate          string   `json:"booking_date" validate:"required"`
+	SeatsReserved        []string `json:"seat_reserved" validate:"required"`
+	BoardingPointID      uint     `json:"boarding_point_id"`
+	DroppingPointID      uint     `json:"dropping_point_id"`
+	PreferredPaymentType string   `json:"payment_type"`
+	RefundTo             string   `json:"refund_to" validate:"omitempty,oneof=wallet source"`
+}
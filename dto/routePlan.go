@@ -0,0 +1,39 @@
+package dto
+
+// RoutePlanRequest struct is used to fetch the information for planning a journey that may need more than one bus.
+type RoutePlanRequest struct {
+	DepartureStation string `json:"depart" validate:"required"`
+	ArrivalStation   string `json:"arrival" validate:"required"`
+	Date             string `json:"date"`
+	MaxLegs          int    `json:"max_legs"`
+	MinLayover       int    `json:"min_layover_minutes"`
+	Passengers       int    `json:"passengers"`
+}
+
+// ItineraryLeg struct is one bus ride of an itinerary, the booking date is the day the bus leaves on.
+type ItineraryLeg struct {
+	BusID            uint    `json:"bus_id"`
+	BusNumber        string  `json:"bus_number"`
+	BusTypeCode      string  `json:"bus_type"`
+	DepartureStation string  `json:"depart"`
+	ArrivalStation   string  `json:"arrival"`
+	BookingDate      string  `json:"booking_date"`
+	DepartureTime    string  `json:"departure_time"`
+	ArrivalTime      string  `json:"arrival_time"`
+	Fare             float64 `json:"fare"`
+	SeatsLeft        int     `json:"seats_left"`
+}
+
+// Itinerary struct is used to return one way of reaching the destination.
+type Itinerary struct {
+	Legs                 []*ItineraryLeg `json:"legs"`
+	TotalDurationMinutes int             `json:"total_duration_minutes"`
+	LayoverMinutes       int             `json:"layover_minutes"`
+	TotalFare            float64         `json:"total_fare"`
+}
+
+// ItineraryBookingRequest struct is used to book every leg of an itinerary together, either all legs are booked or none.
+type ItineraryBookingRequest struct {
+	Legs                 []*BookingRequest `json:"legs" validate:"required,min=1,dive"`
+	PreferredPaymentType string            `json:"payment_type"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// ScheduleStop struct is one stop of a schedule as shared by the admin, the times are in "15:04:05" format and the fare is for the hop reaching this stop.
+type ScheduleStop struct {
+	StationName   string `json:"station" validate:"required"`
+	ArrivalTime   string `json:"arrival_time"`
+	DepartureTime string `json:"departure_time"`
+	DayOffset     int    `json:"day_offset" validate:"min=0"`
+	SegmentFare   uint   `json:"segment_fare"`
+}
+
+// ScheduleStopsRequest struct is used to replace the ordered stops of a schedule, from its departure to its arrival station.
+type ScheduleStopsRequest struct {
+	Stops []*ScheduleStop `json:"stops" validate:"required,min=2,dive"`
+}
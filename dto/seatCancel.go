@@ -0,0 +1,8 @@
+package dto
+
+// SeatCancelRequest struct is used to cancel some of the seats of a booking, picked by seat or by passenger, the refund goes to the wallet or back to the payment.
+type SeatCancelRequest struct {
+	Seats        []string `json:"seats" validate:"required_without=PassengerIDs"`
+	PassengerIDs []uint   `json:"passenger_ids" validate:"required_without=Seats"`
+	RefundTo     string   `json:"refund_to" validate:"omitempty,oneof=wallet source"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "encoding/json"
+
+// SeatLayoutRequest is used to accept a seat layout designed by the provider, the seat map can be left out to generate a fully seated layout from the deck sizes.
+type SeatLayoutRequest struct {
+	Name           string          `json:"name" validate:"required"`
+	BusTypeCode    string          `json:"bus_type_code" validate:"required"`
+	BusID          uint            `json:"bus_id"`
+	ParentID       uint            `json:"parent_id"`
+	DeckOneRows    int             `json:"deck_one_rows"`
+	DeckOneColumns int             `json:"deck_one_columns"`
+	DeckTwoRows    int             `json:"deck_two_rows"`
+	DeckTwoColumns int             `json:"deck_two_columns"`
+	SeatMap        json.RawMessage `json:"seat_map"`
+}
+
+// SeatLayoutPreview is used to show the provider how the designed layout will look, every cell holds the seat ID, "|" for an aisle or "X" for a blocked cell.
+type SeatLayoutPreview struct {
+	Decks        [][][]string `json:"decks"`
+	SleeperSeats int          `json:"sleeper_seats"`
+	SeaterSeats  int          `json:"seater_seats"`
+}
+
+// AssignSeatLayoutRequest is used to assign a seat layout to one of the bus types of the provider.
+type AssignSeatLayoutRequest struct {
+	BusTypeCode string `json:"bus_type_code" validate:"required"`
+}
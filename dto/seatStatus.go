@@ -6,6 +6,8 @@ type SeatAvailabilityResponse struct {
 	Date                  string
 	BusType               string
 	BusStatus             string
+	FromStation           string
+	ToStation             string
 	SleeperSlotsLeft      int
 	SeaterSlotsLeft       int
 	AvailableSleeperSlots []string
@@ -16,4 +18,7 @@ type SeatAvailabilityResponse struct {
 type SeatAvailabilityRequest struct {
 	BusID int    `json:"bus_id" gorm:"not null" validate:"required"`
 	Date  string `json:"date" gorm:"not null" validate:"required"`
+	// FromStation and ToStation narrow the check to a part of the route, the whole route is checked when they are empty.
+	FromStation string `json:"from_station"`
+	ToStation   string `json:"to_station"`
 }
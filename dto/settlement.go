@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+// CommissionRequest struct is used to set the share of the fares the platform keeps from a provider, in percent.
+type CommissionRequest struct {
+	Percent *float64 `json:"percent" validate:"required,gte=0,lte=100"`
+}
+
+// PayoutDecision struct is used by the admin to approve or reject a payout.
+type PayoutDecision struct {
+	Reference string `json:"reference"`
+	Remarks   string `json:"remarks"`
+}
+
+// Payout struct is a batch of settled fares paid out to a provider, the amount is in rupees.
+type Payout struct {
+	ID          uint       `json:"id"`
+	ProviderID  uint       `json:"provider_id"`
+	Amount      float64    `json:"amount"`
+	Settlements int        `json:"settlements"`
+	Status      string     `json:"status"`
+	Requested   bool       `json:"requested"`
+	Reference   string     `json:"reference,omitempty"`
+	Remarks     string     `json:"remarks,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+}
+
+// SettlementLine struct is a booking included in a payout, the amounts are in rupees.
+type SettlementLine struct {
+	BookingID  uint      `json:"booking_id"`
+	PNR        string    `json:"pnr"`
+	BusID      uint      `json:"bus_id"`
+	TripDate   string    `json:"trip_date"`
+	Gross      float64   `json:"gross"`
+	Percent    float64   `json:"percent"`
+	Commission float64   `json:"commission"`
+	Net        float64   `json:"net"`
+	SettledAt  time.Time `json:"settled_at"`
+}
+
+// SettlementStatement struct lists the bookings included in a payout with their totals.
+type SettlementStatement struct {
+	Payout     *Payout           `json:"payout"`
+	Provider   string            `json:"provider"`
+	Gross      float64           `json:"gross"`
+	Commission float64           `json:"commission"`
+	Net        float64           `json:"net"`
+	Bookings   []*SettlementLine `json:"bookings"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// WalletTransaction struct is one movement of a wallet, the amounts are in rupees and Balance is what the wallet held after it.
+type WalletTransaction struct {
+	ID        uint      `json:"id"`
+	Kind      string    `json:"kind"`
+	BookingID uint      `json:"booking_id,omitempty"`
+	Memo      string    `json:"memo"`
+	Amount    float64   `json:"amount"`
+	Balance   float64   `json:"balance"`
+	At        time.Time `json:"at"`
+}
+
+// WalletStatement struct is a page of the movements of a wallet, newest first.
+type WalletStatement struct {
+	Balance      float64              `json:"balance"`
+	Page         int                  `json:"page"`
+	Limit        int                  `json:"limit"`
+	Total        int64                `json:"total"`
+	Transactions []*WalletTransaction `json:"transactions"`
+}
+
+// TopupRequest struct is used to add money to the wallet through Razorpay.
+type TopupRequest struct {
+	Amount float64 `json:"amount" validate:"required,gt=0,lte=50000"`
+}
+
+// RefundPreference struct is used to pick where the refunds of the user go, the wallet or back to the payment.
+type RefundPreference struct {
+	RefundTo string `json:"refund_to" validate:"required,oneof=wallet source"`
+}
+
+// TopupResponse struct is the Razorpay order to pay for the top-up.
+type TopupResponse struct {
+	TopupID     uint    `json:"topup_id"`
+	OrderID     string  `json:"order_id"`
+	Amount      float64 `json:"amount"`
+	Email       string  `json:"email"`
+	PhoneNumber string  `json:"phone_number"`
+}
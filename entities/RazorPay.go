@@ -1,10 +1,12 @@
 package entities
 
-// RazorPay Model
+// RazorPay Model, Status moves through the payment states and AmountRefunded is what was refunded back to the way it was paid.
 type RazorPay struct {
 	BookID          uint    `JSON:"bookID"`
 	RazorPaymentID  string  `JSON:"razorpaymentid" gorm:"primaryKey"`
 	RazorPayOrderID string  `JSON:"razorpayorderid"`
 	Signature       string  `JSON:"signature"`
 	AmountPaid      float64 `JSON:"amountpaid"`
+	Status          string  `JSON:"status"`
+	AmountRefunded  float64 `JSON:"amountrefunded"`
 }
@@ -0,0 +1,15 @@
+package entities
+
+import "gorm.io/gorm"
+
+// BoardingPoint struct is used to attach a sub station to a bus as a place to get on or off, the time offset is in minutes from the time the bus is at the stop.
+type BoardingPoint struct {
+	gorm.Model
+	BusID        uint   `json:"bus_id" gorm:"not null;index"`
+	SubStationID uint   `json:"sub_station_id" gorm:"not null"`
+	StopName     string `json:"stop" gorm:"not null"`
+	Name         string `json:"name" gorm:"not null"`
+	Address      string `json:"address"`
+	PointType    string `json:"type" gorm:"not null"`
+	TimeOffset   int    `json:"time_offset"`
+}
@@ -1,10 +1,15 @@
 package entities
 
-import "github.com/lib/pq"
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
 
 // Booking struct is used to make a booking table to store the booking related information.
 type Booking struct {
-	BookingID        uint `json:"booking_id" gorm:"primaryKey; autoIncrement"`
+	BookingID        uint   `json:"booking_id" gorm:"primaryKey; autoIncrement"`
+	PNR              string `json:"pnr" gorm:"uniqueIndex"`
 	UserID           uint
 	UsedCouponID     uint
 	ActualFare       float64
@@ -14,4 +19,29 @@ type Booking struct {
 	PassengerID      pq.Int64Array  `gorm:"type:integer[]"  validate:"required"`
 	SeatReserved     pq.StringArray `json:"seat_reserved" gorm:"type:text[]"  validate:"required"`
 	Status           string
+	HoldExpiresAt    *time.Time `json:"hold_expires_at,omitempty"`
+	ItineraryRef     string     `json:"itinerary_ref,omitempty"`
+	FromStation      string     `json:"from_station,omitempty"`
+	ToStation        string     `json:"to_station,omitempty"`
+	BoardingPointID  uint       `json:"boarding_point_id,omitempty"`
+	BoardingPoint    string     `json:"boarding_point,omitempty"`
+	BoardingTime     string     `json:"boarding_time,omitempty"`
+	DroppingPointID  uint       `json:"dropping_point_id,omitempty"`
+	DroppingPoint    string     `json:"dropping_point,omitempty"`
+	DroppingTime     string     `json:"dropping_time,omitempty"`
+	PaymentType      string     `json:"payment_type,omitempty"`
+	SeatClass        string     `json:"seat_class,omitempty"`
+	WaitlistPosition int        `json:"waitlist_position,omitempty" gorm:"-"`
+	// the refund breakdown of the cancellations made on the booking, added up over partial cancellations
+	RefundRule         string  `json:"refund_rule,omitempty"`
+	RefundPercent      int     `json:"refund_percent,omitempty"`
+	CancellationCharge float64 `json:"cancellation_charge,omitempty"`
+	RefundAmount       float64 `json:"refund_amount,omitempty"`
+	// a rescheduled booking links to the booking that replaced it and the new booking back to the original
+	RescheduledFrom uint `json:"rescheduled_from,omitempty"`
+	RescheduledTo   uint `json:"rescheduled_to,omitempty"`
+	// RescheduleCredit is the fare already paid on the original booking, only the rest is charged through Razorpay
+	RescheduleCredit float64        `json:"reschedule_credit,omitempty"`
+	Items            []*BookingItem `json:"items,omitempty" gorm:"-"`
+	CreatedAt        time.Time      `json:"created_at"`
 }
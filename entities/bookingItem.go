@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// BookingItem struct is used to store one passenger and seat of a booking, cancelled items are kept as the history of the booking.
+type BookingItem struct {
+	ID               uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	BookingID        uint       `json:"booking_id" gorm:"not null;index"`
+	PassengerID      uint       `json:"passenger_id"`
+	SeatID           string     `json:"seat"`
+	Fare             float64    `json:"fare"`
+	FarePostDiscount float64    `json:"fare_post_discount"`
+	Status           string     `json:"status"`
+	RefundAmount     float64    `json:"refund_amount"`
+	CancelledAt      *time.Time `json:"cancelled_at,omitempty"`
+	// BoardedAt is set when the crew scans the ticket of the seat at boarding
+	BoardedAt *time.Time `json:"boarded_at,omitempty"`
+}
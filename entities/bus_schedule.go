@@ -13,5 +13,6 @@ type BusSchedule struct {
 	Day               time.Time `json:"day" gorm:"not_null" validate:"required"`
 	DeckOneSeatLayout []byte
 	DeckTwoSeatLayout []byte
+	SegmentOccupancy  []byte
 	Status            string `json:"status" gorm:"default: Active" validate:"required"`
 }
@@ -7,4 +7,5 @@ type BusType struct {
 	BusTypeName  string
 	Manufacturer string
 	SeatLayoutID uint
+	ProviderID   uint
 }
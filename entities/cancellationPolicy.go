@@ -0,0 +1,11 @@
+package entities
+
+import "gorm.io/gorm"
+
+// CancellationPolicy struct is used to store the refund tiers of a provider, a policy with a bus applies to that bus only and overrides the provider wide one.
+type CancellationPolicy struct {
+	gorm.Model
+	ProviderID uint   `json:"provider_id" gorm:"not null;uniqueIndex:idx_cancellation_policy_owner"`
+	BusID      uint   `json:"bus_id" gorm:"uniqueIndex:idx_cancellation_policy_owner"`
+	Tiers      []byte `json:"tiers" gorm:"type:jsonb"`
+}
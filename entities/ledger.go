@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// LedgerAccount struct is a wallet in the double-entry ledger, Balance is the sum of its journal lines in paisa and is kept with every posting.
+type LedgerAccount struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code      string    `json:"code" gorm:"uniqueIndex;not null"`
+	OwnerType string    `json:"owner_type" gorm:"not null"`
+	OwnerID   uint      `json:"owner_id"`
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// LedgerEntry struct is an immutable journal entry, its lines add up to zero and its key makes posting it again a no-op.
+type LedgerEntry struct {
+	ID        uint          `json:"id" gorm:"primaryKey;autoIncrement"`
+	Key       string        `json:"key" gorm:"uniqueIndex;not null"`
+	Kind      string        `json:"kind" gorm:"not null"`
+	BookingID uint          `json:"booking_id,omitempty" gorm:"index"`
+	Memo      string        `json:"memo"`
+	CreatedAt time.Time     `json:"created_at"`
+	Lines     []*LedgerLine `json:"lines" gorm:"foreignKey:EntryID"`
+}
+
+// LedgerLine struct is the amount in paisa an entry moves in or out of one account.
+type LedgerLine struct {
+	ID        uint  `json:"id" gorm:"primaryKey;autoIncrement"`
+	EntryID   uint  `json:"entry_id" gorm:"not null;index"`
+	AccountID uint  `json:"account_id" gorm:"not null;index"`
+	Amount    int64 `json:"amount"`
+}
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// PaymentEvent struct is a webhook the payment gateway sent, stored once by its event id along with what came of it.
+type PaymentEvent struct {
+	ID         string    `json:"id" gorm:"primaryKey"`
+	Type       string    `json:"type" gorm:"not null"`
+	OrderID    string    `json:"order_id"`
+	PaymentID  string    `json:"payment_id" gorm:"index"`
+	RefundID   string    `json:"refund_id,omitempty"`
+	Amount     int64     `json:"amount"`
+	Outcome    string    `json:"outcome"`
+	Detail     string    `json:"detail,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
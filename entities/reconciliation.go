@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// PaymentOrder struct is an order raised with the payment gateway for a booking, kept to check it against the gateway, the amount is in paisa.
+type PaymentOrder struct {
+	OrderID   string    `json:"order_id" gorm:"primaryKey"`
+	BookingID uint      `json:"booking_id" gorm:"index"`
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// ReconciliationIssue struct is a disagreement found between the gateway and the bookings, payments and ledger kept here, open until an admin resolves it, the amounts are in paisa. Reference is the key of the ledger entry the issue is about, if any.
+type ReconciliationIssue struct {
+	ID         uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Kind       string     `json:"kind" gorm:"uniqueIndex:idx_reconciliation_issues"`
+	OrderID    string     `json:"order_id" gorm:"uniqueIndex:idx_reconciliation_issues"`
+	PaymentID  string     `json:"payment_id" gorm:"uniqueIndex:idx_reconciliation_issues"`
+	BookingID  uint       `json:"booking_id" gorm:"index;uniqueIndex:idx_reconciliation_issues"`
+	Reference  string     `json:"reference,omitempty" gorm:"uniqueIndex:idx_reconciliation_issues"`
+	Expected   int64      `json:"expected"`
+	Actual     int64      `json:"actual"`
+	Detail     string     `json:"detail"`
+	Status     string     `json:"status" gorm:"index"`
+	Remarks    string     `json:"remarks,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// Refund struct is a refund of a booking sent back through the payment gateway to the payment it was paid with, the amount is in paisa.
+type Refund struct {
+	ID          uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	BookingID   uint       `json:"booking_id" gorm:"index"`
+	UserID      uint       `json:"user_id" gorm:"not null;index"`
+	PaymentID   string     `json:"payment_id" gorm:"index"`
+	Key         string     `json:"-" gorm:"uniqueIndex"`
+	Amount      int64      `json:"amount"`
+	Status      string     `json:"status" gorm:"index"`
+	GatewayID   string     `json:"gateway_id,omitempty" gorm:"index"`
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+}
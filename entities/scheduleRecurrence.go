@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// ScheduleRecurrence struct is used to store the days a bus runs on, charts are generated ahead from it.
+type ScheduleRecurrence struct {
+	gorm.Model
+	BusID         uint           `json:"bus_id" gorm:"not null;uniqueIndex"`
+	Frequency     string         `json:"frequency" gorm:"not null"`
+	Days          pq.StringArray `json:"days" gorm:"type:text[]"`
+	ExcludedDates pq.StringArray `json:"excluded_dates" gorm:"type:text[]"`
+	StartDate     string         `json:"start_date"`
+	EndDate       string         `json:"end_date"`
+}
@@ -0,0 +1,13 @@
+package entities
+
+// ScheduleStop struct is used to store the ordered stops of a schedule, the first stop is the origin and the last one the destination.
+type ScheduleStop struct {
+	ID            uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	ScheduleID    uint   `json:"schedule_id" gorm:"not null;index"`
+	Sequence      int    `json:"sequence" gorm:"not null"`
+	StationName   string `json:"station" gorm:"not null" validate:"required"`
+	ArrivalTime   string `json:"arrival_time"`
+	DepartureTime string `json:"departure_time"`
+	DayOffset     int    `json:"day_offset"`
+	SegmentFare   uint   `json:"segment_fare"`
+}
@@ -22,12 +22,17 @@ func NewSeatLayout(db *gorm.DB) *SeatLayoutStr {
 type BusSeatLayout struct {
 	gorm.Model
 	// SeatLayoutId   int    `json:"seat_id"`
+	ProviderID     uint   `json:"provider_id"`
+	Name           string `json:"name"`
+	Version        int    `json:"version" gorm:"default:1"`
+	ParentID       uint   `json:"parent_id"`
 	DeckOneColumns int    `json:"deck_one_columns"  validate:"required"`
 	DeckTwoColumns int    `json:"deck_two_columns" validate:"required"`
 	DeckOneRows    int    `json:"deck_one_rows" validate:"required"`
 	DeckTwoRows    int    `json:"deck_two_rows" validate:"required"`
 	DeckOneLayout  []byte `json:"deckone_seat_layout"`
 	DeckTwoLayout  []byte `json:"decktwo_seat_layout"`
+	SeatMap        []byte `json:"seat_map"`
 }
 
 // DeckOneLayoutstr struct is used to store the unmarshalled data of Deck One layout
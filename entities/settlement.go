@@ -0,0 +1,40 @@
+package entities
+
+import "time"
+
+// ProviderCommission struct is the share of the fares the platform keeps from the trips of a provider, in percent.
+type ProviderCommission struct {
+	ProviderID uint      `json:"provider_id" gorm:"primaryKey"`
+	Percent    float64   `json:"percent"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Settlement struct is the fare of a booking released from escrow once its trip completed, split between the commission and the provider, the amounts are in paisa.
+type Settlement struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProviderID uint      `json:"provider_id" gorm:"not null;index"`
+	BookingID  uint      `json:"booking_id" gorm:"uniqueIndex:idx_settlement_trip"`
+	PNR        string    `json:"pnr"`
+	BusID      uint      `json:"bus_id" gorm:"uniqueIndex:idx_settlement_trip"`
+	TripDate   string    `json:"trip_date" gorm:"uniqueIndex:idx_settlement_trip"`
+	Gross      int64     `json:"gross"`
+	Percent    float64   `json:"percent"`
+	Commission int64     `json:"commission"`
+	Net        int64     `json:"net"`
+	SettledAt  time.Time `json:"settled_at"`
+	PayoutID   *uint     `json:"payout_id,omitempty" gorm:"index"`
+}
+
+// Payout struct is a batch of settlements paid out of the wallet of the provider once an admin approves it, the amount is in paisa.
+type Payout struct {
+	ID          uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProviderID  uint       `json:"provider_id" gorm:"not null;index"`
+	Amount      int64      `json:"amount"`
+	Settlements int        `json:"settlements"`
+	Status      string     `json:"status" gorm:"index"`
+	Requested   bool       `json:"requested"`
+	Reference   string     `json:"reference,omitempty"`
+	Remarks     string     `json:"remarks,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+}
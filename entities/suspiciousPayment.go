@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+// SuspiciousPayment struct is a payment callback that was turned down, kept for the admins to look into.
+type SuspiciousPayment struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	BookingID uint      `json:"booking_id,omitempty" gorm:"index"`
+	TopupID   uint      `json:"topup_id,omitempty"`
+	OrderID   string    `json:"order_id"`
+	PaymentID string    `json:"payment_id" gorm:"index"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -13,4 +13,5 @@ type User struct {
 	DOB         string `json:"dob" gorm:"not null" validate:"required"`
 	IsLocked    bool   `json:"is_account_locked" gorm:"default: false"`
 	UserWallet  int    `json:"user_wallet"`
+	RefundTo    string `json:"refund_to" gorm:"default:'wallet'"`
 }
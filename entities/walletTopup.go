@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// WalletTopup struct is a Razorpay order raised to add money to the wallet of a user, the wallet is credited once the payment succeeds.
+type WalletTopup struct {
+	ID         uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	Amount     float64    `json:"amount"`
+	OrderID    string     `json:"order_id" gorm:"uniqueIndex"`
+	PaymentID  string     `json:"payment_id"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	CreditedAt *time.Time `json:"credited_at,omitempty"`
+}
@@ -658,6 +658,149 @@ func (ah *AdminHandler) AddBusSchedule(c *gin.Context) {
 	})
 }
 
+// SetScheduleStops function is used to replace the ordered stops of a schedule along with their times and hop fares.
+func (ah *AdminHandler) SetScheduleStops(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid Schedule ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	request := &dto.ScheduleStopsRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to bind the stops",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	stops, err := ah.admin.SetScheduleStops(idInt, request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to update the stops",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "Success",
+		"message": "Successfully updated the stops of the schedule",
+		"data":    stops,
+	})
+}
+
+// SetRecurrence function is used to set the days a bus runs on.
+func (ah *AdminHandler) SetRecurrence(c *gin.Context) {
+	request := &dto.RecurrenceRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to bind the recurrence",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	rule, err := ah.admin.SetRecurrence(request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to set the recurrence",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "Success",
+		"message": "Successfully set the recurrence",
+		"data":    rule,
+	})
+}
+
+// FindRecurrence function is used to view the days a bus runs on.
+func (ah *AdminHandler) FindRecurrence(c *gin.Context) {
+	id := c.Param("id")
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid Bus ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	rule, err := ah.admin.FindRecurrence(idInt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the recurrence",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully found the recurrence",
+		"data":    rule,
+	})
+}
+
+// BackfillCharts function is used to generate the charts of a date range from the recurrences.
+func (ah *AdminHandler) BackfillCharts(c *gin.Context) {
+	request := &dto.ChartBackfillRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to bind the date range",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	result, err := ah.admin.GenerateCharts(int(request.BusID), request.From, request.To)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to generate the charts",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "Success",
+		"message": "Successfully generated the charts",
+		"data":    result,
+	})
+}
+
 // ViewAllBookings function is used to list all the bookings
 func (ah *AdminHandler) ViewAllBookings(c *gin.Context) {
 	bookings, err := ah.admin.ViewAllBookings()
@@ -677,6 +820,236 @@ func (ah *AdminHandler) ViewAllBookings(c *gin.Context) {
 	})
 }
 
+// ReconcileLedger function is used to check that the wallet ledger balances.
+func (ah *AdminHandler) ReconcileLedger(c *gin.Context) {
+	report, err := ah.admin.ReconcileLedger()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to reconcile the ledger",
+			"data":    err.Error(),
+		})
+		return
+	}
+	message := "The ledger balances"
+	if !report.Balanced {
+		message = "The ledger does not balance"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": message,
+		"data":    report,
+	})
+}
+
+// ViewSuspiciousPayments function is used to list the payment callbacks that were turned down, newest first.
+func (ah *AdminHandler) ViewSuspiciousPayments(c *gin.Context) {
+	attempts, err := ah.admin.ViewSuspiciousPayments()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to fetch the suspicious payments",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully fetched the suspicious payments",
+		"data":    attempts,
+	})
+}
+
+// ViewReconciliationIssues function is used to list the disagreements the payment reconciliation found, open or resolved ones when asked.
+func (ah *AdminHandler) ViewReconciliationIssues(c *gin.Context) {
+	issues, err := ah.admin.ViewReconciliationIssues(c.Query("status"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to fetch the reconciliation issues",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully fetched the reconciliation issues",
+		"data":    issues,
+	})
+}
+
+// ReconcilePayments function is used to check the recent orders against the payment gateway without waiting for the nightly run.
+func (ah *AdminHandler) ReconcilePayments(c *gin.Context) {
+	report, err := ah.admin.ReconcilePayments()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to reconcile the payments",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully reconciled the payments",
+		"data":    report,
+	})
+}
+
+// ResolveReconciliationIssue function is used to close a reconciliation issue once it has been looked into.
+func (ah *AdminHandler) ResolveReconciliationIssue(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid Issue ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	resolution := &dto.IssueResolution{}
+	if err := c.BindJSON(resolution); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to bind the resolution",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(resolution); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields",
+			"data":    err.Error(),
+		})
+		return
+	}
+	issue, err := ah.admin.ResolveReconciliationIssue(id, resolution)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to resolve the reconciliation issue",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully resolved the reconciliation issue",
+		"data":    issue,
+	})
+}
+
+// SetCommission function is used to set the share of the fares the platform keeps from the provider.
+func (ah *AdminHandler) SetCommission(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid Provider ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	request := &dto.CommissionRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to bind the commission",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	commission, err := ah.admin.SetCommission(id, request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to set the commission",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully set the commission",
+		"data":    commission,
+	})
+}
+
+// ViewPayouts function is used to list the payouts of the providers, filtered by status when one is passed.
+func (ah *AdminHandler) ViewPayouts(c *gin.Context) {
+	payouts, err := ah.admin.ViewPayouts(c.Query("status"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to fetch the payouts",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully fetched the payouts",
+		"data":    payouts,
+	})
+}
+
+// ApprovePayout function is used to approve a pending payout, the amount is taken from the wallet of the provider.
+func (ah *AdminHandler) ApprovePayout(c *gin.Context) {
+	ah.decidePayout(c, ah.admin.ApprovePayout, "approve")
+}
+
+// RejectPayout function is used to reject a pending payout, its bookings go into the next one.
+func (ah *AdminHandler) RejectPayout(c *gin.Context) {
+	ah.decidePayout(c, ah.admin.RejectPayout, "reject")
+}
+
+// decidePayout function is used to read the payout and the decision from the request and pass them on.
+func (ah *AdminHandler) decidePayout(c *gin.Context, decide func(int, *dto.PayoutDecision) (*dto.Payout, error), action string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid Payout ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	decision := &dto.PayoutDecision{}
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(decision); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"status":  "Failed",
+				"message": "Unable to bind the decision",
+				"data":    err.Error(),
+			})
+			return
+		}
+	}
+	payout, err := decide(id, decision)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to " + action + " the payout",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully decided the payout",
+		"data":    payout,
+	})
+}
+
 // ViewBookingsPerBus function is used to list all bookings based on the bus id passed.
 func (ah *AdminHandler) ViewBookingsPerBus(c *gin.Context) {
 	schedule := &dto.BusSchedule{}
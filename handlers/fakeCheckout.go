@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"gobus/payment"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FakeCheckoutHandler struct is used to pay the orders of the fake payment gateway, it stands in for the Razorpay checkout in local development.
+type FakeCheckoutHandler struct {
+	gateway *payment.FakeGateway
+}
+
+// Pay function is used to pay the order and send the browser to the payment success callback the way the checkout page would.
+func (fh *FakeCheckoutHandler) Pay(c *gin.Context) {
+	order, err := fh.gateway.FetchStatus(c.Param("order"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the order",
+			"data":    err.Error(),
+		})
+		return
+	}
+	paid, signature, err := fh.gateway.Pay(order.ID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusPaymentRequired, gin.H{
+			"status":  "Failed",
+			"message": "Payment failed",
+			"data":    err.Error(),
+		})
+		return
+	}
+	query := url.Values{}
+	query.Set("order_id", order.ID)
+	query.Set("payment_id", paid.ID)
+	query.Set("signature", signature)
+	query.Set("total", fmt.Sprint(order.Amount/100))
+	if !strings.HasPrefix(order.Receipt, "topup-") {
+		query.Set("pnr", order.Receipt)
+	}
+	c.Redirect(http.StatusFound, "/user/payment/success?"+query.Encode())
+}
+
+// NewFakeCheckoutHandler is used to initialize the FakeCheckoutHandler
+func NewFakeCheckoutHandler(gateway *payment.FakeGateway) *FakeCheckoutHandler {
+	return &FakeCheckoutHandler{
+		gateway: gateway,
+	}
+}
@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"fmt"
 	"gobus/dto"
 	"gobus/entities"
 	"gobus/services/interfaces"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -512,6 +514,180 @@ func (ph *ProviderHandler) AddSubStations(c *gin.Context) {
 	})
 }
 
+// PreviewSeatLayout function is used to validate a designed seat layout and show how it will look without saving it.
+func (ph *ProviderHandler) PreviewSeatLayout(c *gin.Context) {
+	request := &dto.SeatLayoutRequest{}
+	c.BindJSON(request)
+	if err := validate.Struct(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	preview, err := ph.provider.PreviewSeatLayout(request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid seat layout",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Seat layout is valid",
+		"data":    preview,
+	})
+}
+
+// AddSeatLayout function is used to save a designed seat layout, passing a parent_id saves it as a new version of that layout.
+func (ph *ProviderHandler) AddSeatLayout(c *gin.Context) {
+	request := &dto.SeatLayoutRequest{}
+	c.BindJSON(request)
+	if err := validate.Struct(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	layout, err := ph.provider.AddSeatLayout(request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to add the seat layout",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "Success",
+		"message": "Successfully added the seat layout",
+		"data":    layout,
+	})
+}
+
+// FindSeatLayouts function is used to list the seat layouts of the provider.
+func (ph *ProviderHandler) FindSeatLayouts(c *gin.Context) {
+	email := c.MustGet("email").(string)
+	layouts, err := ph.provider.FindSeatLayouts(email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the seat layouts",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully found the seat layouts",
+		"data":    layouts,
+	})
+}
+
+// FindSeatLayoutByID function is used to find one seat layout of the provider.
+func (ph *ProviderHandler) FindSeatLayoutByID(c *gin.Context) {
+	id := c.Param("id")
+	layoutID, err := strconv.Atoi(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid seat layout ID",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	layout, err := ph.provider.FindSeatLayoutByID(layoutID, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the seat layout",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully found the seat layout",
+		"data":    layout,
+	})
+}
+
+// FindSeatLayoutVersions function is used to list every version of a seat layout.
+func (ph *ProviderHandler) FindSeatLayoutVersions(c *gin.Context) {
+	id := c.Param("id")
+	layoutID, err := strconv.Atoi(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid seat layout ID",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	versions, err := ph.provider.FindSeatLayoutVersions(layoutID, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the seat layout versions",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully found the seat layout versions",
+		"data":    versions,
+	})
+}
+
+// AssignSeatLayout function is used to assign a seat layout to a bus type of the provider.
+func (ph *ProviderHandler) AssignSeatLayout(c *gin.Context) {
+	id := c.Param("id")
+	layoutID, err := strconv.Atoi(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid seat layout ID",
+			"data":    err.Error(),
+		})
+		return
+	}
+	request := &dto.AssignSeatLayoutRequest{}
+	c.BindJSON(request)
+	if err := validate.Struct(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	busType, err := ph.provider.AssignSeatLayout(layoutID, request.BusTypeCode, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to assign the seat layout",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "Success",
+		"message": "Successfully assigned the seat layout",
+		"data":    busType,
+	})
+}
+
 // NewProviderHandler is used to initialize the ProviderHandler
 func NewProviderHandler(providerService interfaces.ProviderService) *ProviderHandler {
 	return &ProviderHandler{
@@ -519,3 +695,375 @@ func NewProviderHandler(providerService interfaces.ProviderService) *ProviderHan
 	}
 
 }
+
+// AddBoardingPoint function is used to attach a sub station to a bus of the provider as a boarding or dropping point.
+func (ph *ProviderHandler) AddBoardingPoint(c *gin.Context) {
+	request := &dto.BoardingPointRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to bind the boarding point",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	point, err := ph.provider.AddBoardingPoint(request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to add the boarding point",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "Success",
+		"message": "Successfully added the boarding point",
+		"data":    point,
+	})
+}
+
+// FindBoardingPoints function is used to list the boarding and dropping points of a bus of the provider.
+func (ph *ProviderHandler) FindBoardingPoints(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid Bus ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	points, err := ph.provider.FindBoardingPoints(id, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the boarding points",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully found the boarding points",
+		"data":    points,
+	})
+}
+
+// DeleteBoardingPoint function is used to remove a boarding or dropping point of a bus of the provider.
+func (ph *ProviderHandler) DeleteBoardingPoint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid boarding point ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	if err := ph.provider.DeleteBoardingPoint(id, email); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to remove the boarding point",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully removed the boarding point",
+		"data":    id,
+	})
+}
+
+// SetCancellationPolicy function is used to set the refund tiers of the provider or of one of their buses.
+func (ph *ProviderHandler) SetCancellationPolicy(c *gin.Context) {
+	request := &dto.CancellationPolicyRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to bind the cancellation policy",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	policy, err := ph.provider.SetCancellationPolicy(request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to set the cancellation policy",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully set the cancellation policy",
+		"data":    policy,
+	})
+}
+
+// FindCancellationPolicies function is used to list the cancellation policies of the provider.
+func (ph *ProviderHandler) FindCancellationPolicies(c *gin.Context) {
+	email := c.MustGet("email").(string)
+	policies, err := ph.provider.FindCancellationPolicies(email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the cancellation policies",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully found the cancellation policies",
+		"data":    policies,
+	})
+}
+
+// ScanTicket function is used by the crew to verify the QR code of a ticket and board its passengers.
+func (ph *ProviderHandler) ScanTicket(c *gin.Context) {
+	request := &dto.BoardingScanRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to bind the scanned ticket",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	scan, err := ph.provider.ScanTicket(request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Ticket rejected",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully boarded the passengers",
+		"data":    scan,
+	})
+}
+
+// TripBoarding function is used to get the live boarded and no-show count of a trip of a bus of the provider.
+func (ph *ProviderHandler) TripBoarding(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid Bus ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	trip, err := ph.provider.TripBoarding(id, c.Query("date"), email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the boarding status of the trip",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully found the boarding status of the trip",
+		"data":    trip,
+	})
+}
+
+// TripManifest function is used to list the passengers of a trip of a bus of the provider, as JSON or exported as CSV or PDF for the crew.
+func (ph *ProviderHandler) TripManifest(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid Bus ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	date := c.Query("date")
+	format := strings.ToLower(c.Query("format"))
+	if format == "" || format == "json" {
+		manifest, err := ph.provider.TripManifest(id, date, email)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"status":  "Failed",
+				"message": "Unable to find the manifest of the trip",
+				"data":    err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "Success",
+			"message": "Successfully found the manifest of the trip",
+			"data":    manifest,
+		})
+		return
+	}
+	file, err := ph.provider.ExportManifest(id, date, format, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to export the manifest of the trip",
+			"data":    err.Error(),
+		})
+		return
+	}
+	contentType := "text/csv"
+	if format == "pdf" {
+		contentType = "application/pdf"
+	}
+	filename := fmt.Sprintf("manifest-%d-%s.%s", id, strings.ReplaceAll(date, " ", "-"), format)
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, contentType, file)
+}
+
+// WalletStatement function is used to list the movements of the wallet of the provider, a page at a time.
+func (ph *ProviderHandler) WalletStatement(c *gin.Context) {
+	page, limit, err := pageQuery(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid page or limit provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	statement, err := ph.provider.WalletStatement(email, page, limit)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to fetch the wallet statement",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully fetched the wallet statement",
+		"data":    statement,
+	})
+}
+
+// ViewPayouts function is used to list the payouts of the provider.
+func (ph *ProviderHandler) ViewPayouts(c *gin.Context) {
+	email := c.MustGet("email").(string)
+	payouts, err := ph.provider.ViewPayouts(email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to fetch the payouts",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully fetched the payouts",
+		"data":    payouts,
+	})
+}
+
+// RequestPayout function is used to ask for the settled fares not paid out yet, the payout waits for an admin to approve it.
+func (ph *ProviderHandler) RequestPayout(c *gin.Context) {
+	email := c.MustGet("email").(string)
+	payout, err := ph.provider.RequestPayout(email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to request the payout",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "Success",
+		"message": "Successfully requested the payout",
+		"data":    payout,
+	})
+}
+
+// SettlementStatement function is used to list the bookings paid out in the payout, as JSON or as a CSV or PDF download.
+func (ph *ProviderHandler) SettlementStatement(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid Payout ID provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	format := strings.ToLower(c.Query("format"))
+	if format == "" || format == "json" {
+		statement, err := ph.provider.SettlementStatement(id, email)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"status":  "Failed",
+				"message": "Unable to find the settlement statement",
+				"data":    err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "Success",
+			"message": "Successfully found the settlement statement",
+			"data":    statement,
+		})
+		return
+	}
+	file, err := ph.provider.ExportSettlement(id, format, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to export the settlement statement",
+			"data":    err.Error(),
+		})
+		return
+	}
+	contentType := "text/csv"
+	if format == "pdf" {
+		contentType = "application/pdf"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=settlement-%d.%s", id, format))
+	c.Data(http.StatusOK, contentType, file)
+}
@@ -226,6 +226,71 @@ func (uh *UserHandler) BookSeat(c *gin.Context) {
 	})
 }
 
+// PlanRoutes function is used to find journeys to the destination that may change buses on the way.
+func (uh *UserHandler) PlanRoutes(c *gin.Context) {
+	planRequest := &dto.RoutePlanRequest{}
+	c.BindJSON(planRequest)
+	if err := validate.Struct(planRequest); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	itineraries, err := uh.user.PlanRoutes(planRequest)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to plan the route",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if len(itineraries) == 0 {
+		c.JSON(http.StatusAccepted, gin.H{
+			"status":  "Success",
+			"message": "No route has been found",
+			"data":    itineraries,
+		})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "Success",
+		"message": "Routes found",
+		"data":    itineraries,
+	})
+}
+
+// BookItinerary function is used to book every leg of a planned journey together.
+func (uh *UserHandler) BookItinerary(c *gin.Context) {
+	bookreq := &dto.ItineraryBookingRequest{}
+	c.BindJSON(bookreq)
+	if err := validate.Struct(bookreq); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	bookings, err := uh.user.BookItinerary(bookreq, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to book the itinerary",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "Success",
+		"message": "Itinerary has been booked.",
+		"data":    bookings,
+	})
+}
+
 // FindCoupon function is used to find the coupons.
 func (uh *UserHandler) FindCoupon(c *gin.Context) {
 	coupons, err := uh.user.FindCoupon()
@@ -265,11 +330,28 @@ func (uh *UserHandler) ViewBookings(c *gin.Context) {
 	})
 }
 
+// bookingID function is used to find the booking of the PNR in the path, the services still check that it belongs to the user.
+func (uh *UserHandler) bookingID(c *gin.Context) (int, bool) {
+	booking, err := uh.user.FindBookingByPNR(c.Param("pnr"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the booking",
+			"data":    err.Error(),
+		})
+		return 0, false
+	}
+	return int(booking.BookingID), true
+}
+
 // CancelBooking function is used to cancel the booking
 func (uh *UserHandler) CancelBooking(c *gin.Context) {
-	id := c.Param("id")
-	intID, _ := strconv.Atoi(id)
-	bookings, err := uh.user.CancelBooking(intID)
+	intID, ok := uh.bookingID(c)
+	if !ok {
+		return
+	}
+	email := c.MustGet("email").(string)
+	bookings, err := uh.user.CancelBooking(intID, c.Query("refund_to"), email)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
 			"status":  "Failed",
@@ -286,6 +368,140 @@ func (uh *UserHandler) CancelBooking(c *gin.Context) {
 	})
 }
 
+// BookingTicket function is used to download the PDF e-ticket of a booking.
+func (uh *UserHandler) BookingTicket(c *gin.Context) {
+	intID, ok := uh.bookingID(c)
+	if !ok {
+		return
+	}
+	email := c.MustGet("email").(string)
+	pnr, pdf, err := uh.user.BookingTicket(intID, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to get the ticket",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=ticket-"+pnr+".pdf")
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// RescheduleBooking function is used to move a booking to another date or bus on the same route.
+func (uh *UserHandler) RescheduleBooking(c *gin.Context) {
+	intID, ok := uh.bookingID(c)
+	if !ok {
+		return
+	}
+	request := &dto.RescheduleRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Missing mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	booking, err := uh.user.RescheduleBooking(intID, request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to reschedule the booking",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  "Success",
+		"message": "Successfully rescheduled the booking",
+		"data":    booking,
+	})
+}
+
+// RefundQuote function is used to show the refund of cancelling a booking, or some of its seats, before confirming it.
+func (uh *UserHandler) RefundQuote(c *gin.Context) {
+	intID, ok := uh.bookingID(c)
+	if !ok {
+		return
+	}
+	request := &dto.SeatCancelRequest{}
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Failed",
+				"message": "Unable to read the seats to cancel.",
+				"data":    err.Error(),
+			})
+			return
+		}
+	}
+	email := c.MustGet("email").(string)
+	quote, err := uh.user.RefundQuote(intID, request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to quote the refund",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully quoted the refund",
+		"data":    quote,
+	})
+}
+
+// CancelSeats function is used to cancel some of the seats or passengers of a booking.
+func (uh *UserHandler) CancelSeats(c *gin.Context) {
+	intID, ok := uh.bookingID(c)
+	if !ok {
+		return
+	}
+	request := &dto.SeatCancelRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Missing mandatory fields.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please pick the seats or passengers to cancel.",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	booking, err := uh.user.CancelSeats(intID, request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to cancel the seats",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully cancelled the seats",
+		"data":    booking,
+	})
+}
+
 // SeatStatus is used to get the seat availability details.
 func (uh *UserHandler) SeatStatus(c *gin.Context) {
 	seatReq := &dto.SeatAvailabilityRequest{}
@@ -316,9 +532,8 @@ func (uh *UserHandler) SeatStatus(c *gin.Context) {
 
 // MakePayment function is used to make the payment
 func (uh *UserHandler) MakePayment(c *gin.Context) {
-	ID := c.Param("bookid")
-	bookID, _ := strconv.Atoi(ID)
-	book, err := uh.user.FindBookingByID(bookID)
+	pnr := c.Param("pnr")
+	book, err := uh.user.FindBookingByPNR(pnr)
 	if err != nil {
 		log.Println("Error fetching the booking")
 		c.JSON(http.StatusConflict, gin.H{
@@ -336,46 +551,80 @@ func (uh *UserHandler) MakePayment(c *gin.Context) {
 		})
 		return
 	}
-	// fmt.Print(bookID)
-	paymentResp, err := uh.user.MakePayment(bookID)
+	paymentResp, err := uh.user.MakePayment(book.PNR)
 	if err != nil {
 		fmt.Printf("Problem getting repositorys information: %v\n", err)
-
+		c.JSON(http.StatusConflict, gin.H{
+			"status":  "Failed",
+			"message": "Unable to start the payment",
+			"data":    err.Error(),
+		})
+		return
 	}
 	c.HTML(http.StatusOK, "app.html", gin.H{
-		"bookID":      paymentResp.BookingID,
-		"totalPrice":  paymentResp.AmountInRupees,
-		"total":       paymentResp.AmountInRupees * 100,
+		"pnr":         paymentResp.PNR,
+		"totalPrice":  fmt.Sprintf("%.2f", paymentResp.AmountInRupees),
+		"total":       paymentResp.AmountInPaisa,
 		"orderID":     paymentResp.OrderID,
 		"email":       paymentResp.Email,
 		"phoneNumber": paymentResp.PhoneNumber,
 	})
 }
 
-// PaymentSuccess function is used to implement the logic once the payment is successful.
+// PaymentSuccess function is used to implement the logic once the payment is successful, a payment without a PNR is a wallet top-up.
 func (uh *UserHandler) PaymentSuccess(c *gin.Context) {
-	bookIDstr := c.Query("bookID")
-	// fmt.Println(bookIDstr,"****************************************************************")
-	BookID, _ := strconv.Atoi(bookIDstr)
+	if c.Query("pnr") == "" {
+		uh.topupSuccess(c)
+		return
+	}
+	book, err := uh.user.FindBookingByPNR(c.Query("pnr"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Error fetching the booking Info",
+			"data":    err.Error(),
+		})
+		return
+	}
 	orderID := c.Query("order_id")
 	paymentID := c.Query("payment_id")
 	signature := c.Query("signature")
+	// the checkout page sends the amount in paisa
 	paymentAmount := c.Query("total")
 	amount, _ := strconv.Atoi(paymentAmount)
 
 	rPay := &entities.RazorPay{
-		BookID:          uint(BookID),
+		BookID:          book.BookingID,
 		RazorPaymentID:  paymentID,
 		Signature:       signature,
 		RazorPayOrderID: orderID,
-		AmountPaid:      float64(amount),
+		AmountPaid:      float64(amount) / 100,
 	}
-	err := uh.user.PaymentSuccess(rPay)
+	err = uh.user.PaymentSuccess(rPay)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "Failed",
-			"message": "Error while updating data into DB",
-			"data":    err,
+			"message": "Unable to confirm the payment",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status": true})
+}
+
+// topupSuccess function is used to credit the wallet once the payment of a top-up is successful.
+func (uh *UserHandler) topupSuccess(c *gin.Context) {
+	rPay := &entities.RazorPay{
+		RazorPaymentID:  c.Query("payment_id"),
+		Signature:       c.Query("signature"),
+		RazorPayOrderID: c.Query("order_id"),
+	}
+	if err := uh.user.TopupSuccess(rPay); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to credit the wallet",
+			"data":    err.Error(),
 		})
 		return
 	}
@@ -383,23 +632,220 @@ func (uh *UserHandler) PaymentSuccess(c *gin.Context) {
 		"status": true})
 }
 
+// PaymentWebhook function is used to take the events the payment gateway sends about payments and refunds, an event is applied once however often it is sent.
+func (uh *UserHandler) PaymentWebhook(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to read the webhook",
+			"data":    err.Error(),
+		})
+		return
+	}
+	err = uh.user.PaymentWebhook(c.GetHeader("X-Razorpay-Event-Id"), body, c.GetHeader("X-Razorpay-Signature"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to process the webhook",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status": true})
+}
+
+// WalletStatement function is used to list the movements of the wallet of the user, a page at a time.
+func (uh *UserHandler) WalletStatement(c *gin.Context) {
+	page, limit, err := pageQuery(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Invalid page or limit provided",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	statement, err := uh.user.WalletStatement(email, page, limit)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to fetch the wallet statement",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully fetched the wallet statement",
+		"data":    statement,
+	})
+}
+
+// TopupWallet function is used to raise the Razorpay order to add money to the wallet of the user.
+func (uh *UserHandler) TopupWallet(c *gin.Context) {
+	request := &dto.TopupRequest{}
+	if err := c.BindJSON(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Binding the data from the body failed",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	topup, err := uh.user.TopupWallet(request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to start the top-up",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "Success",
+		"message": "Complete the payment to add the money to the wallet",
+		"data":    topup,
+	})
+}
+
+// SetRefundPreference function is used to pick where the refunds of the user go, the wallet or back to the payment.
+func (uh *UserHandler) SetRefundPreference(c *gin.Context) {
+	request := &dto.RefundPreference{}
+	if err := c.BindJSON(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Binding the data from the body failed",
+			"data":    err.Error(),
+		})
+		return
+	}
+	if err := validate.Struct(request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Please fill all the mandatory fields",
+			"data":    err.Error(),
+		})
+		return
+	}
+	email := c.MustGet("email").(string)
+	user, err := uh.user.SetRefundPreference(request, email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to save the refund preference",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Refunds will go to the " + user.RefundTo,
+		"data":    request,
+	})
+}
+
+// ViewRefunds function is used to list the refunds sent back to the payments of the user, with their status.
+func (uh *UserHandler) ViewRefunds(c *gin.Context) {
+	email := c.MustGet("email").(string)
+	refunds, err := uh.user.ViewRefunds(email)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "Unable to fetch the refunds",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully fetched the refunds",
+		"data":    refunds,
+	})
+}
+
+// pageQuery function is used to read the page and limit query parameters, both are optional.
+func pageQuery(c *gin.Context) (int, int, error) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		return 0, 0, err
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return page, limit, nil
+}
+
+// LookupBooking function is used to find a booking by its PNR along with the phone number or email of the user who made it.
+func (uh *UserHandler) LookupBooking(c *gin.Context) {
+	pnr := c.Query("pnr")
+	contact := c.Query("contact")
+	if pnr == "" || contact == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Failed",
+			"message": "PNR and the phone number or email are mandatory",
+			"data":    nil,
+		})
+		return
+	}
+	booking, err := uh.user.LookupBooking(pnr, contact)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "Failed",
+			"message": "Unable to find the booking",
+			"data":    err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "Success",
+		"message": "Successfully found the booking",
+		"data":    booking,
+	})
+}
+
 // SuccessPage function is used to display the success page
 func (uh *UserHandler) SuccessPage(c *gin.Context) {
 	pID := c.Query("id")
-	userID := c.Query("bookID")
+	pnr := c.Query("pnr")
 	// fmt.Println(pID)
 	// fmt.Println("Fully successful")
 
 	c.HTML(http.StatusOK, "success.html", gin.H{
 		"paymentID": pID,
-		"userID":    userID,
+		"pnr":       pnr,
 	})
 }
 
-// SubStationsDetails function is used to fetch the details of the sub station.
+// SubStationsDetails function is used to fetch the boarding and dropping points at a location, optionally only those of one bus.
 func (uh *UserHandler) SubStationsDetails(c *gin.Context) {
 	parent := c.Query("location")
-	substations, err := uh.user.SubStationDetails(parent)
+	busID := 0
+	if bus := c.Query("bus_id"); bus != "" {
+		id, err := strconv.Atoi(bus)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Failed",
+				"message": "Invalid Bus ID provided",
+				"data":    err.Error(),
+			})
+			return
+		}
+		busID = id
+	}
+	substations, err := uh.user.SubStationDetails(parent, busID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "Failed",
@@ -115,7 +115,7 @@ func TestUserHandler_FindBus(t *testing.T) {
 				userService.EXPECT().FindBus(&dto.BusRequest{
 					DepartureStation: "Kannur",
 					ArrivalStation:   "Bangalore",
-				}).Return([]*entities.BusesResp{}, nil)
+				}).Return([]*dto.BusSearchResult{}, nil)
 			},
 			route:       "/user/findbus",
 			errorResult: nil,
@@ -131,7 +131,7 @@ func TestUserHandler_FindBus(t *testing.T) {
 				userService.EXPECT().FindBus(&dto.BusRequest{
 					DepartureStation: "Kannur",
 					ArrivalStation:   "",
-				}).Return([]*entities.BusesResp{}, nil)
+				}).Return([]*dto.BusSearchResult{}, nil)
 			},
 			route:       "/user/findbus",
 			errorResult: map[string]interface{}{"data": interface{}(nil), "message": "Stations cannot be empty.", "status": "Failed"},
@@ -0,0 +1,247 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"gobus/entities"
+	"gobus/repository/interfaces"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Owner types of the ledger accounts, escrow accounts hold the fares of a trip until it completes and system accounts stand for money outside of the wallets.
+const (
+	OwnerUser     = "user"
+	OwnerProvider = "provider"
+	OwnerEscrow   = "escrow"
+	OwnerSystem   = "system"
+)
+
+// System accounts, Razorpay holds what came in through the gateway, Opening the wallet balances from before the ledger, Commission what the platform kept and Payouts what was paid out to the providers.
+const (
+	Razorpay   = "system:razorpay"
+	Opening    = "system:opening"
+	Commission = "system:commission"
+	Payouts    = "system:payouts"
+)
+
+// Kinds of the journal entries.
+const (
+	KindOpening    = "opening"
+	KindPayment    = "payment"
+	KindRefund     = "refund"
+	KindReschedule = "reschedule"
+	KindTopup      = "topup"
+	KindSettlement = "settlement"
+	KindPayout     = "payout"
+)
+
+// ErrUnbalanced is returned for an entry whose lines do not add up to zero.
+var ErrUnbalanced = errors.New("ledger entry does not balance")
+
+// UserAccount function returns the code of the wallet account of the user.
+func UserAccount(userID uint) string {
+	return fmt.Sprintf("%s:%d", OwnerUser, userID)
+}
+
+// ProviderAccount function returns the code of the wallet account of the provider.
+func ProviderAccount(providerID uint) string {
+	return fmt.Sprintf("%s:%d", OwnerProvider, providerID)
+}
+
+// EscrowAccount function returns the code of the account holding the fares of the trip of the bus on the day.
+func EscrowAccount(busID uint, day time.Time) string {
+	return fmt.Sprintf("%s:%d:%s", OwnerEscrow, busID, day.Format("20060102"))
+}
+
+// ParseEscrow function returns the bus and the day of the trip of an escrow account, the day starts at local midnight like the trips themselves.
+func ParseEscrow(code string) (uint, time.Time, error) {
+	ownerType, owner, _ := strings.Cut(code, ":")
+	bus, date, ok := strings.Cut(owner, ":")
+	if ownerType != OwnerEscrow || !ok {
+		return 0, time.Time{}, errors.New("invalid escrow account " + code)
+	}
+	busID, err := strconv.ParseUint(bus, 10, 64)
+	if err != nil || busID == 0 {
+		return 0, time.Time{}, errors.New("invalid escrow account " + code)
+	}
+	day, err := time.ParseInLocation("20060102", date, time.Local)
+	if err != nil {
+		return 0, time.Time{}, errors.New("invalid escrow account " + code)
+	}
+	return uint(busID), day, nil
+}
+
+// Paisa function converts an amount in rupees to paisa, rounded to the nearest paisa.
+func Paisa(rupees float64) int64 {
+	return int64(math.Round(rupees * 100))
+}
+
+// Line struct is the amount an entry moves into an account, negative amounts move money out of it.
+type Line struct {
+	Account string
+	Amount  int64
+}
+
+// Entry struct is a journal entry to post, the key identifies the booking or refund it is for so it is posted once.
+type Entry struct {
+	Key       string
+	Kind      string
+	BookingID uint
+	Memo      string
+	Lines     []Line
+}
+
+// Transfer function is used to build the entry moving the amount from one account to another.
+func Transfer(key string, kind string, bookingID uint, memo string, from string, to string, amount int64) *Entry {
+	return &Entry{Key: key, Kind: kind, BookingID: bookingID, Memo: memo, Lines: []Line{{Account: from, Amount: -amount}, {Account: to, Amount: amount}}}
+}
+
+// Post function is used to write the entry to the journal and move the balances of its accounts, an entry whose key was posted before is skipped and reported as not posted.
+func Post(repo interfaces.LedgerRepository, entry *Entry) (bool, error) {
+	if entry.Key == "" {
+		return false, errors.New("ledger entry has no key")
+	}
+	amounts := map[string]int64{}
+	var total int64
+	for _, line := range entry.Lines {
+		amounts[line.Account] += line.Amount
+		total += line.Amount
+	}
+	if total != 0 {
+		return false, ErrUnbalanced
+	}
+	var codes []string
+	for code, amount := range amounts {
+		if amount != 0 {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return false, nil
+	}
+	// accounts are locked users first, then providers, then escrow and system accounts, the order the wallets were locked in before
+	sort.Slice(codes, func(i, j int) bool { return lockOrder(codes[i]) < lockOrder(codes[j]) })
+	posted := false
+	err := repo.WithTx(func(tx interfaces.LedgerRepository) error {
+		existing, err := tx.FindEntryByKey(entry.Key)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return nil
+		}
+		accounts := make([]*entities.LedgerAccount, 0, len(codes))
+		lines := make([]*entities.LedgerLine, 0, len(codes))
+		for _, code := range codes {
+			account, err := open(tx, code)
+			if err != nil {
+				return err
+			}
+			accounts = append(accounts, account)
+			lines = append(lines, &entities.LedgerLine{AccountID: account.ID, Amount: amounts[code]})
+		}
+		if err := tx.AddEntry(&entities.LedgerEntry{Key: entry.Key, Kind: entry.Kind, BookingID: entry.BookingID, Memo: entry.Memo, Lines: lines}); err != nil {
+			return err
+		}
+		for i, account := range accounts {
+			account.Balance += lines[i].Amount
+			if err := tx.SaveAccountBalance(account); err != nil {
+				return err
+			}
+		}
+		posted = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return posted, nil
+}
+
+// Balance function returns the balance of the account in paisa, the account is locked until the transaction of the repository ends.
+func Balance(repo interfaces.LedgerRepository, code string) (int64, error) {
+	var balance int64
+	err := repo.WithTx(func(tx interfaces.LedgerRepository) error {
+		account, err := open(tx, code)
+		if err != nil {
+			return err
+		}
+		balance = account.Balance
+		return nil
+	})
+	return balance, err
+}
+
+// open function is used to lock the account, a wallet seen for the first time is opened with the balance it had before the ledger.
+func open(tx interfaces.LedgerRepository, code string) (*entities.LedgerAccount, error) {
+	ownerType, ownerID, err := parseCode(code)
+	if err != nil {
+		return nil, err
+	}
+	account, created, err := tx.OpenAccount(&entities.LedgerAccount{Code: code, OwnerType: ownerType, OwnerID: ownerID})
+	if err != nil || !created || !isWallet(ownerType) {
+		return account, err
+	}
+	wallet, err := tx.WalletBalance(ownerType, ownerID)
+	if err != nil || wallet == 0 {
+		return account, err
+	}
+	opening, _, err := tx.OpenAccount(&entities.LedgerAccount{Code: Opening, OwnerType: OwnerSystem})
+	if err != nil {
+		return nil, err
+	}
+	amount := int64(wallet) * 100
+	lines := []*entities.LedgerLine{{AccountID: account.ID, Amount: amount}, {AccountID: opening.ID, Amount: -amount}}
+	if err := tx.AddEntry(&entities.LedgerEntry{Key: "opening:" + code, Kind: KindOpening, Memo: "Wallet balance before the ledger", Lines: lines}); err != nil {
+		return nil, err
+	}
+	account.Balance += amount
+	opening.Balance -= amount
+	if err := tx.SaveAccountBalance(opening); err != nil {
+		return nil, err
+	}
+	if err := tx.SaveAccountBalance(account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func parseCode(code string) (string, uint, error) {
+	ownerType, owner, ok := strings.Cut(code, ":")
+	if !ok || owner == "" {
+		return "", 0, errors.New("invalid ledger account " + code)
+	}
+	switch ownerType {
+	case OwnerSystem:
+		return ownerType, 0, nil
+	case OwnerEscrow:
+		busID, _, err := ParseEscrow(code)
+		return ownerType, busID, err
+	case OwnerUser, OwnerProvider:
+		id, err := strconv.ParseUint(owner, 10, 64)
+		if err != nil || id == 0 {
+			return "", 0, errors.New("invalid ledger account " + code)
+		}
+		return ownerType, uint(id), nil
+	}
+	return "", 0, errors.New("invalid ledger account " + code)
+}
+
+// isWallet function reports whether the accounts of the owner type had a wallet column before the ledger.
+func isWallet(ownerType string) bool {
+	return ownerType == OwnerUser || ownerType == OwnerProvider
+}
+
+func lockOrder(code string) string {
+	ownerType, owner, _ := strings.Cut(code, ":")
+	rank := map[string]string{OwnerUser: "0", OwnerProvider: "1", OwnerEscrow: "2"}[ownerType]
+	if rank == "" {
+		rank = "3"
+	}
+	// ids are padded so that they sort as numbers
+	return fmt.Sprintf("%s%20s", rank, owner)
+}
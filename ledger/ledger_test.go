@@ -0,0 +1,350 @@
+package ledger
+
+import (
+	"errors"
+	"gobus/entities"
+	"gobus/repository/interfaces"
+	"testing"
+	"time"
+)
+
+// memoryRepo is an in memory ledger repository, wallets holds what the wallets had in rupees before the ledger by account code.
+type memoryRepo struct {
+	accounts []*entities.LedgerAccount
+	entries  []*entities.LedgerEntry
+	wallets  map[string]int
+	failSave bool
+}
+
+func (r *memoryRepo) WithTx(fn func(tx interfaces.LedgerRepository) error) error {
+	accounts := make([]entities.LedgerAccount, len(r.accounts))
+	for i, account := range r.accounts {
+		accounts[i] = *account
+	}
+	count, entries := len(r.accounts), len(r.entries)
+	if err := fn(r); err != nil {
+		r.accounts, r.entries = r.accounts[:count], r.entries[:entries]
+		for i := range accounts {
+			*r.accounts[i] = accounts[i]
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *memoryRepo) OpenAccount(account *entities.LedgerAccount) (*entities.LedgerAccount, bool, error) {
+	for _, existing := range r.accounts {
+		if existing.Code == account.Code {
+			copied := *existing
+			return &copied, false, nil
+		}
+	}
+	account.ID = uint(len(r.accounts) + 1)
+	r.accounts = append(r.accounts, account)
+	copied := *account
+	return &copied, true, nil
+}
+
+func (r *memoryRepo) SaveAccountBalance(account *entities.LedgerAccount) error {
+	if r.failSave {
+		return errSave
+	}
+	*r.accounts[account.ID-1] = *account
+	return nil
+}
+
+func (r *memoryRepo) WalletBalance(ownerType string, ownerID uint) (int, error) {
+	if ownerType == OwnerUser {
+		return r.wallets[UserAccount(ownerID)], nil
+	}
+	return r.wallets[ProviderAccount(ownerID)], nil
+}
+
+func (r *memoryRepo) FindEntryByKey(key string) (*entities.LedgerEntry, error) {
+	for _, entry := range r.entries {
+		if entry.Key == key {
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepo) AddEntry(entry *entities.LedgerEntry) error {
+	entry.ID = uint(len(r.entries) + 1)
+	for _, line := range entry.Lines {
+		line.EntryID = entry.ID
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *memoryRepo) FindAccounts() ([]*entities.LedgerAccount, error) {
+	return r.accounts, nil
+}
+
+func (r *memoryRepo) AccountTotals() (map[uint]int64, error) {
+	totals := map[uint]int64{}
+	for _, entry := range r.entries {
+		for _, line := range entry.Lines {
+			totals[line.AccountID] += line.Amount
+		}
+	}
+	return totals, nil
+}
+
+func (r *memoryRepo) UnbalancedEntries() ([]uint, error) {
+	var ids []uint
+	for _, entry := range r.entries {
+		var total int64
+		for _, line := range entry.Lines {
+			total += line.Amount
+		}
+		if total != 0 {
+			ids = append(ids, entry.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *memoryRepo) FindStatement(accountID uint, offset int, limit int) ([]*entities.LedgerEntry, int64, error) {
+	var touching []*entities.LedgerEntry
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		entry := &entities.LedgerEntry{ID: r.entries[i].ID, Kind: r.entries[i].Kind, Memo: r.entries[i].Memo}
+		for _, line := range r.entries[i].Lines {
+			if line.AccountID == accountID {
+				entry.Lines = append(entry.Lines, line)
+			}
+		}
+		if len(entry.Lines) > 0 {
+			touching = append(touching, entry)
+		}
+	}
+	total := int64(len(touching))
+	if offset >= len(touching) {
+		return nil, total, nil
+	}
+	touching = touching[offset:]
+	if len(touching) > limit {
+		touching = touching[:limit]
+	}
+	return touching, total, nil
+}
+
+func (r *memoryRepo) BalanceThrough(accountID uint, entryID uint) (int64, error) {
+	var balance int64
+	for _, entry := range r.entries {
+		for _, line := range entry.Lines {
+			if entry.ID <= entryID && line.AccountID == accountID {
+				balance += line.Amount
+			}
+		}
+	}
+	return balance, nil
+}
+
+func (r *memoryRepo) FindOpenAccounts(ownerType string) ([]*entities.LedgerAccount, error) {
+	var accounts []*entities.LedgerAccount
+	for _, account := range r.accounts {
+		if account.OwnerType == ownerType && account.Balance != 0 {
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts, nil
+}
+
+func (r *memoryRepo) BookingTotals(accountID uint) (map[uint]int64, error) {
+	totals := map[uint]int64{}
+	for _, entry := range r.entries {
+		for _, line := range entry.Lines {
+			if line.AccountID == accountID {
+				totals[entry.BookingID] += line.Amount
+			}
+		}
+	}
+	return totals, nil
+}
+
+func (r *memoryRepo) HeldFor(code string, bookingID uint) (int64, error) {
+	for _, account := range r.accounts {
+		if account.Code == code {
+			totals, _ := r.BookingTotals(account.ID)
+			return totals[bookingID], nil
+		}
+	}
+	return 0, nil
+}
+
+var errSave = errors.New("save failed")
+
+func balance(t *testing.T, repo *memoryRepo, code string) int64 {
+	t.Helper()
+	got, err := Balance(repo, code)
+	if err != nil {
+		t.Fatalf("ledger.Balance(%s) error = %v", code, err)
+	}
+	return got
+}
+
+func Test_Post(t *testing.T) {
+	repo := &memoryRepo{wallets: map[string]int{UserAccount(1): 1000}}
+	// the first posting opens the user wallet with what it held before the ledger
+	posted, err := Post(repo, Transfer("booking:1:payment", KindPayment, 1, "Fare", UserAccount(1), ProviderAccount(1), Paisa(418.5)))
+	if err != nil || !posted {
+		t.Fatalf("ledger.Post() = %v, %v", posted, err)
+	}
+	if got := balance(t, repo, UserAccount(1)); got != 58150 {
+		t.Errorf("user balance = %d, want 58150", got)
+	}
+	if got := balance(t, repo, ProviderAccount(1)); got != 41850 {
+		t.Errorf("provider balance = %d, want 41850", got)
+	}
+	if got := balance(t, repo, Opening); got != -100000 {
+		t.Errorf("opening balance = %d, want -100000", got)
+	}
+	if repo.wallets[UserAccount(1)] != 1000 || repo.wallets[ProviderAccount(1)] != 0 {
+		t.Errorf("wallets = %v, want the balances before the ledger left as they were", repo.wallets)
+	}
+
+	// the same key is posted once
+	posted, err = Post(repo, Transfer("booking:1:payment", KindPayment, 1, "Fare", UserAccount(1), ProviderAccount(1), Paisa(418.5)))
+	if err != nil || posted {
+		t.Errorf("ledger.Post() again = %v, %v, want it skipped", posted, err)
+	}
+	if got := balance(t, repo, UserAccount(1)); got != 58150 {
+		t.Errorf("user balance after the repeat = %d, want 58150", got)
+	}
+
+	// lines of the same account are netted and an entry has to balance
+	posted, err = Post(repo, &Entry{Key: "booking:1:reschedule", Kind: KindReschedule, Lines: []Line{
+		{Account: ProviderAccount(1), Amount: -41850},
+		{Account: ProviderAccount(1), Amount: 50000},
+		{Account: UserAccount(1), Amount: -8150},
+	}})
+	if err != nil || !posted || len(repo.entries[len(repo.entries)-1].Lines) != 2 {
+		t.Errorf("ledger.Post() netted = %v, %v", posted, err)
+	}
+	if _, err := Post(repo, &Entry{Key: "broken", Lines: []Line{{Account: UserAccount(1), Amount: 10}}}); err != ErrUnbalanced {
+		t.Errorf("ledger.Post() unbalanced error = %v, want %v", err, ErrUnbalanced)
+	}
+	if _, err := Post(repo, Transfer("bad", KindPayment, 0, "", "wallet", UserAccount(1), 10)); err == nil {
+		t.Errorf("ledger.Post() accepted an unknown account")
+	}
+
+	// a failed posting leaves the books untouched
+	entries := len(repo.entries)
+	repo.failSave = true
+	if _, err := Post(repo, Transfer("booking:2:payment", KindPayment, 2, "", UserAccount(1), ProviderAccount(2), 100)); err == nil {
+		t.Fatalf("ledger.Post() error = nil, want the save error")
+	}
+	repo.failSave = false
+	if len(repo.entries) != entries {
+		t.Errorf("ledger.Post() kept the entry of a failed posting")
+	}
+
+	report, err := Reconcile(repo)
+	if err != nil {
+		t.Fatalf("ledger.Reconcile() error = %v", err)
+	}
+	if !report.Balanced || report.Accounts != 3 {
+		t.Errorf("ledger.Reconcile() = %+v, want balanced books", report)
+	}
+}
+
+func Test_Reconcile(t *testing.T) {
+	repo := &memoryRepo{wallets: map[string]int{}}
+	if _, err := Post(repo, Transfer("topup:1", KindPayment, 0, "", Razorpay, UserAccount(1), 50000)); err != nil {
+		t.Fatalf("ledger.Post() error = %v", err)
+	}
+	// a balance drifting from the journal is reported
+	repo.accounts[1].Balance += 5
+	report, err := Reconcile(repo)
+	if err != nil {
+		t.Fatalf("ledger.Reconcile() error = %v", err)
+	}
+	if report.Balanced || len(report.Mismatches) != 1 || report.Mismatches[0].Account != repo.accounts[1].Code {
+		t.Errorf("ledger.Reconcile() = %+v, want the drifting balance", report)
+	}
+	repo.entries[0].Lines[0].Amount++
+	report, _ = Reconcile(repo)
+	if report.Balanced || len(report.UnbalancedEntries) != 1 || report.JournalTotal != 1 {
+		t.Errorf("ledger.Reconcile() = %+v, want the unbalanced entry", report)
+	}
+}
+
+func Test_Paisa(t *testing.T) {
+	for rupees, want := range map[float64]int64{418.5: 41850, 0.1 + 0.2: 30, 333.333: 33333, -12.5: -1250} {
+		if got := Paisa(rupees); got != want {
+			t.Errorf("ledger.Paisa(%v) = %d, want %d", rupees, got, want)
+		}
+	}
+}
+
+func Test_AccountStatement(t *testing.T) {
+	repo := &memoryRepo{wallets: map[string]int{UserAccount(1): 100}}
+	for _, entry := range []*Entry{
+		Transfer("topup:1", KindTopup, 0, "Wallet top-up", Razorpay, UserAccount(1), 50000),
+		Transfer("booking:1:payment", KindPayment, 1, "Fare", UserAccount(1), ProviderAccount(1), 41850),
+		Transfer("booking:1:cancel:1", KindRefund, 1, "Refund", ProviderAccount(1), UserAccount(1), 20000),
+	} {
+		if _, err := Post(repo, entry); err != nil {
+			t.Fatalf("ledger.Post() error = %v", err)
+		}
+	}
+	statement, err := AccountStatement(repo, UserAccount(1), 0, 2)
+	if err != nil {
+		t.Fatalf("ledger.AccountStatement() error = %v", err)
+	}
+	if statement.Balance != 38150 || statement.Total != 4 || len(statement.Lines) != 2 {
+		t.Fatalf("ledger.AccountStatement() = %+v, want 2 of 4 entries", statement)
+	}
+	if got := statement.Lines[0]; got.Kind != KindRefund || got.Amount != 20000 || got.Balance != 38150 {
+		t.Errorf("newest line = %+v", got)
+	}
+	if got := statement.Lines[1]; got.Kind != KindPayment || got.Amount != -41850 || got.Balance != 18150 {
+		t.Errorf("second line = %+v", got)
+	}
+	// the running balance carries over to the next page
+	statement, _ = AccountStatement(repo, UserAccount(1), 2, 2)
+	if len(statement.Lines) != 2 || statement.Lines[0].Balance != 60000 || statement.Lines[1].Kind != KindOpening || statement.Lines[1].Balance != 10000 {
+		t.Errorf("ledger.AccountStatement() second page = %+v", statement.Lines)
+	}
+}
+
+func Test_Escrow(t *testing.T) {
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local)
+	code := EscrowAccount(12, day)
+	if code != "escrow:12:20261018" {
+		t.Fatalf("ledger.EscrowAccount() = %s", code)
+	}
+	busID, parsed, err := ParseEscrow(code)
+	if err != nil || busID != 12 || !parsed.Equal(day) {
+		t.Errorf("ledger.ParseEscrow() = %d, %v, %v", busID, parsed, err)
+	}
+	for _, bad := range []string{"escrow:12", "escrow:0:20261018", "user:12:20261018", "escrow:12:2026"} {
+		if _, _, err := ParseEscrow(bad); err == nil {
+			t.Errorf("ledger.ParseEscrow(%s) accepted an invalid account", bad)
+		}
+	}
+
+	repo := &memoryRepo{wallets: map[string]int{}}
+	for _, entry := range []*Entry{
+		Transfer("booking:1:payment", KindPayment, 1, "", Razorpay, code, 50000),
+		Transfer("booking:2:payment", KindPayment, 2, "", Razorpay, code, 30000),
+		Transfer("booking:2:cancel:3", KindRefund, 2, "", code, UserAccount(1), 27000),
+	} {
+		if _, err := Post(repo, entry); err != nil {
+			t.Fatalf("ledger.Post() error = %v", err)
+		}
+	}
+	if held, _ := repo.HeldFor(code, 2); held != 3000 {
+		t.Errorf("held for booking 2 = %d, want 3000", held)
+	}
+	open, _ := repo.FindOpenAccounts(OwnerEscrow)
+	if len(open) != 1 || open[0].Balance != 53000 {
+		t.Errorf("open escrow accounts = %+v", open)
+	}
+	// escrow accounts are not wallets, the books still balance
+	if report, _ := Reconcile(repo); !report.Balanced {
+		t.Errorf("ledger.Reconcile() = %+v, want balanced books", report)
+	}
+}
@@ -0,0 +1,46 @@
+package ledger
+
+import "gobus/repository/interfaces"
+
+// Mismatch struct is an account whose cached balance does not agree with its journal.
+type Mismatch struct {
+	Account string `json:"account"`
+	Balance int64  `json:"balance"`
+	Journal int64  `json:"journal"`
+}
+
+// Report struct is the result of checking the books, they balance when every entry adds up to zero and every balance matches the journal.
+type Report struct {
+	Balanced          bool       `json:"balanced"`
+	Accounts          int        `json:"accounts"`
+	JournalTotal      int64      `json:"journal_total"`
+	UnbalancedEntries []uint     `json:"unbalanced_entries,omitempty"`
+	Mismatches        []Mismatch `json:"mismatches,omitempty"`
+}
+
+// Reconcile function is used to check that the books balance and that the cached balances match the journal.
+func Reconcile(repo interfaces.LedgerRepository) (*Report, error) {
+	unbalanced, err := repo.UnbalancedEntries()
+	if err != nil {
+		return nil, err
+	}
+	totals, err := repo.AccountTotals()
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := repo.FindAccounts()
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Accounts: len(accounts), UnbalancedEntries: unbalanced}
+	for _, total := range totals {
+		report.JournalTotal += total
+	}
+	for _, account := range accounts {
+		if account.Balance != totals[account.ID] {
+			report.Mismatches = append(report.Mismatches, Mismatch{Account: account.Code, Balance: account.Balance, Journal: totals[account.ID]})
+		}
+	}
+	report.Balanced = report.JournalTotal == 0 && len(report.UnbalancedEntries) == 0 && len(report.Mismatches) == 0
+	return report, nil
+}
@@ -0,0 +1,63 @@
+package ledger
+
+import (
+	"gobus/repository/interfaces"
+	"time"
+)
+
+// StatementLine struct is one entry of an account as its owner sees it, Balance is what the account held after it.
+type StatementLine struct {
+	EntryID   uint      `json:"entry_id"`
+	Kind      string    `json:"kind"`
+	BookingID uint      `json:"booking_id,omitempty"`
+	Memo      string    `json:"memo"`
+	Amount    int64     `json:"amount"`
+	Balance   int64     `json:"balance"`
+	At        time.Time `json:"at"`
+}
+
+// Statement struct is a page of the entries of an account, newest first, with the amounts in paisa.
+type Statement struct {
+	Account string          `json:"account"`
+	Balance int64           `json:"balance"`
+	Total   int64           `json:"total"`
+	Lines   []StatementLine `json:"lines"`
+}
+
+// AccountStatement function is used to list a page of the entries of the account, newest first.
+func AccountStatement(repo interfaces.LedgerRepository, code string, offset int, limit int) (*Statement, error) {
+	statement := &Statement{Account: code, Lines: []StatementLine{}}
+	err := repo.WithTx(func(tx interfaces.LedgerRepository) error {
+		account, err := open(tx, code)
+		if err != nil {
+			return err
+		}
+		statement.Balance = account.Balance
+		entries, total, err := tx.FindStatement(account.ID, offset, limit)
+		if err != nil {
+			return err
+		}
+		statement.Total = total
+		if len(entries) == 0 {
+			return nil
+		}
+		// the balance after the newest entry of the page, the older ones are walked back from it
+		balance, err := tx.BalanceThrough(account.ID, entries[0].ID)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			var amount int64
+			for _, line := range entry.Lines {
+				amount += line.Amount
+			}
+			statement.Lines = append(statement.Lines, StatementLine{EntryID: entry.ID, Kind: entry.Kind, BookingID: entry.BookingID, Memo: entry.Memo, Amount: amount, Balance: balance, At: entry.CreatedAt})
+			balance -= amount
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
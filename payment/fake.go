@@ -0,0 +1,186 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrDeclined is returned by the fake gateway for a payment it was told to decline.
+var ErrDeclined = errors.New("payment declined")
+
+// ErrRefundFailed is returned by the fake gateway for a refund it was told to fail.
+var ErrRefundFailed = errors.New("refund failed")
+
+// FakeGateway struct is an in-process PaymentGateway for the tests and local development, it declines every payment when Decline is set, fails every refund when FailRefunds is set and waits Delay before every call.
+type FakeGateway struct {
+	Decline     bool
+	FailRefunds bool
+	Delay       time.Duration
+	secret      string
+	mu          sync.Mutex
+	seq         int
+	orders      map[string]*Order
+	refunds     map[string][]*Refund
+}
+
+func (fg *FakeGateway) wait() {
+	if fg.Delay > 0 {
+		time.Sleep(fg.Delay)
+	}
+}
+
+func (fg *FakeGateway) nextID(prefix string) string {
+	fg.seq++
+	return fmt.Sprintf("%s_fake%d", prefix, fg.seq)
+}
+
+// CreateOrder implements PaymentGateway.
+func (fg *FakeGateway) CreateOrder(amount int64, receipt string) (*Order, error) {
+	fg.wait()
+	if amount <= 0 {
+		return nil, errors.New("order amount should be more than zero")
+	}
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+	order := &Order{ID: fg.nextID("order"), Receipt: receipt, Amount: amount, Currency: "INR", Status: OrderCreated}
+	fg.orders[order.ID] = order
+	copied := *order
+	return &copied, nil
+}
+
+// Pay function is used to pay the order the way the customer would at the checkout, it returns the payment with the signature the gateway sends back.
+func (fg *FakeGateway) Pay(orderID string) (*Payment, string, error) {
+	fg.wait()
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+	order, ok := fg.orders[orderID]
+	if !ok {
+		return nil, "", errors.New("no order found with this id")
+	}
+	if order.Status == OrderPaid {
+		return nil, "", errors.New("order is already paid")
+	}
+	payment := Payment{ID: fg.nextID("pay"), OrderID: orderID, Amount: order.Amount, Currency: order.Currency, Status: PaymentCaptured}
+	if fg.Decline {
+		payment.Status = PaymentFailed
+		order.Status = OrderAttempted
+		order.Payments = append(order.Payments, payment)
+		return &payment, "", ErrDeclined
+	}
+	order.Status = OrderPaid
+	order.Payments = append(order.Payments, payment)
+	return &payment, Sign(fg.secret, orderID, payment.ID), nil
+}
+
+// VerifyPayment implements PaymentGateway.
+func (fg *FakeGateway) VerifyPayment(orderID string, paymentID string, signature string) error {
+	return verify(fg.secret, orderID, paymentID, signature)
+}
+
+// Refund implements PaymentGateway, a payment is refunded at most what was captured.
+func (fg *FakeGateway) Refund(paymentID string, amount int64, receipt string) (*Refund, error) {
+	fg.wait()
+	if fg.FailRefunds {
+		return nil, ErrRefundFailed
+	}
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+	var captured int64
+	for _, order := range fg.orders {
+		for _, payment := range order.Payments {
+			if payment.ID == paymentID && payment.Status == PaymentCaptured {
+				captured = payment.Amount
+			}
+		}
+	}
+	if captured == 0 {
+		return nil, errors.New("no captured payment found with this id")
+	}
+	for _, refund := range fg.refunds[paymentID] {
+		captured -= refund.Amount
+	}
+	if amount <= 0 || amount > captured {
+		return nil, errors.New("refund amount exceeds the captured amount")
+	}
+	refund := &Refund{ID: fg.nextID("rfnd"), PaymentID: paymentID, Receipt: receipt, Amount: amount, Status: RefundProcessed}
+	fg.refunds[paymentID] = append(fg.refunds[paymentID], refund)
+	copied := *refund
+	return &copied, nil
+}
+
+// FindRefund implements PaymentGateway.
+func (fg *FakeGateway) FindRefund(paymentID string, receipt string) (*Refund, error) {
+	fg.wait()
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+	for _, refund := range fg.refunds[paymentID] {
+		if refund.Receipt == receipt {
+			copied := *refund
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+// FetchStatus implements PaymentGateway.
+func (fg *FakeGateway) FetchStatus(orderID string) (*Order, error) {
+	fg.wait()
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+	order, ok := fg.orders[orderID]
+	if !ok {
+		return nil, errors.New("no order found with this id")
+	}
+	copied := *order
+	copied.Payments = append([]Payment(nil), order.Payments...)
+	return &copied, nil
+}
+
+// ParseWebhook implements PaymentGateway, the fake signs its webhooks with the key secret.
+func (fg *FakeGateway) ParseWebhook(body []byte, signature string) (*Event, error) {
+	return parseWebhook(fg.secret, body, signature)
+}
+
+// Webhook function is used to build the signed webhook the gateway would send for the payment, a refund event is about the latest refund of the payment.
+func (fg *FakeGateway) Webhook(eventType string, paymentID string) ([]byte, string, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+	var event *Event
+	for _, order := range fg.orders {
+		for _, paid := range order.Payments {
+			if paid.ID == paymentID {
+				event = &Event{Type: eventType, OrderID: order.ID, PaymentID: paid.ID, Amount: paid.Amount, Currency: paid.Currency, Status: paid.Status}
+			}
+		}
+	}
+	if event == nil {
+		return nil, "", errors.New("no payment found with this id")
+	}
+	if eventType == EventRefundProcessed {
+		refunds := fg.refunds[paymentID]
+		if len(refunds) == 0 {
+			return nil, "", errors.New("payment has no refund")
+		}
+		refund := refunds[len(refunds)-1]
+		event.RefundID, event.Amount, event.Status = refund.ID, refund.Amount, refund.Status
+	}
+	body, err := webhookOf(event)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, SignWebhook(fg.secret, body), nil
+}
+
+// NewFakeGateway function is used to instantiate the fake gateway, the payments are signed with the secret.
+func NewFakeGateway(secret string) *FakeGateway {
+	if secret == "" {
+		secret = "fake_secret"
+	}
+	return &FakeGateway{
+		secret:  secret,
+		orders:  map[string]*Order{},
+		refunds: map[string][]*Refund{},
+	}
+}
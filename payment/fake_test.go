@@ -0,0 +1,88 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_FakeGateway(t *testing.T) {
+	gateway := NewFakeGateway("secret")
+	order, err := gateway.CreateOrder(45000, "GB123")
+	if err != nil || order.Status != OrderCreated || order.Amount != 45000 {
+		t.Fatalf("CreateOrder() = %+v, %v", order, err)
+	}
+	paid, signature, err := gateway.Pay(order.ID)
+	if err != nil || paid.Status != PaymentCaptured {
+		t.Fatalf("Pay() = %+v, %v", paid, err)
+	}
+	if err := gateway.VerifyPayment(order.ID, paid.ID, signature); err != nil {
+		t.Errorf("VerifyPayment() error = %v", err)
+	}
+	if err := gateway.VerifyPayment(order.ID, "pay_other", signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyPayment() accepted the signature of another payment")
+	}
+	if signature != Sign("secret", order.ID, paid.ID) || NewFakeGateway("other").VerifyPayment(order.ID, paid.ID, signature) == nil {
+		t.Errorf("Pay() signature was not made with the key secret")
+	}
+	if _, _, err := gateway.Pay(order.ID); err == nil {
+		t.Errorf("Pay() paid the order twice")
+	}
+
+	refund, err := gateway.Refund(paid.ID, 30000, "cancel")
+	if err != nil || refund.Status != RefundProcessed {
+		t.Fatalf("Refund() = %+v, %v", refund, err)
+	}
+	if _, err := gateway.Refund(paid.ID, 20000, "cancel"); err == nil {
+		t.Errorf("Refund() refunded more than was captured")
+	}
+	if found, err := gateway.FindRefund(paid.ID, "cancel"); err != nil || found == nil || found.ID != refund.ID {
+		t.Errorf("FindRefund() = %+v, %v, want %s", found, err, refund.ID)
+	}
+	if found, err := gateway.FindRefund(paid.ID, "other"); err != nil || found != nil {
+		t.Errorf("FindRefund() = %+v, %v, want none", found, err)
+	}
+	status, err := gateway.FetchStatus(order.ID)
+	if err != nil || status.Status != OrderPaid || len(status.Payments) != 1 {
+		t.Errorf("FetchStatus() = %+v, %v", status, err)
+	}
+
+	gateway.Decline = true
+	gateway.Delay = 20 * time.Millisecond
+	order, _ = gateway.CreateOrder(1000, "GB124")
+	start := time.Now()
+	declined, _, err := gateway.Pay(order.ID)
+	if !errors.Is(err, ErrDeclined) || declined.Status != PaymentFailed {
+		t.Errorf("Pay() = %+v, %v, want declined", declined, err)
+	}
+	if time.Since(start) < gateway.Delay {
+		t.Errorf("Pay() did not wait for the delay")
+	}
+	if status, _ := gateway.FetchStatus(order.ID); status.Status != OrderAttempted {
+		t.Errorf("FetchStatus() = %s, want attempted", status.Status)
+	}
+}
+
+func TestFakeGatewayWebhook(t *testing.T) {
+	gateway := NewFakeGateway("")
+	order, _ := gateway.CreateOrder(50000, "GB125")
+	paid, _, _ := gateway.Pay(order.ID)
+	body, signature, err := gateway.Webhook(EventPaymentCaptured, paid.ID)
+	if err != nil {
+		t.Fatalf("Webhook() error = %v", err)
+	}
+	event, err := gateway.ParseWebhook(body, signature)
+	if err != nil || event.OrderID != order.ID || event.PaymentID != paid.ID || event.Amount != 50000 {
+		t.Errorf("ParseWebhook() = %+v, %v", event, err)
+	}
+	if _, err := gateway.ParseWebhook(body, SignWebhook("guessed", body)); !errors.Is(err, ErrInvalidWebhook) {
+		t.Errorf("ParseWebhook() forged error = %v", err)
+	}
+
+	gateway.Refund(paid.ID, 20000, "cancel")
+	body, signature, _ = gateway.Webhook(EventRefundProcessed, paid.ID)
+	event, err = gateway.ParseWebhook(body, signature)
+	if err != nil || event.RefundID == "" || event.PaymentID != paid.ID || event.Amount != 20000 {
+		t.Errorf("ParseWebhook() refund = %+v, %v", event, err)
+	}
+}
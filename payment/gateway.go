@@ -0,0 +1,95 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Statuses the gateway reports for orders, payments and refunds.
+const (
+	OrderCreated      = "created"
+	OrderAttempted    = "attempted"
+	OrderPaid         = "paid"
+	PaymentAuthorized = "authorized"
+	PaymentCaptured   = "captured"
+	PaymentFailed     = "failed"
+	PaymentRefunded   = "refunded"
+	RefundPending     = "pending"
+	RefundProcessed   = "processed"
+	RefundFailed      = "failed"
+)
+
+// ErrInvalidSignature is returned for a payment whose signature was not made with the key secret.
+var ErrInvalidSignature = errors.New("payment signature does not match")
+
+// Order struct is an order raised with the gateway, the amounts are in paisa.
+type Order struct {
+	ID       string
+	Receipt  string
+	Amount   int64
+	Currency string
+	Status   string
+	Payments []Payment
+}
+
+// Payment struct is an attempt to pay an order, the amount is in paisa.
+type Payment struct {
+	ID       string
+	OrderID  string
+	Amount   int64
+	Currency string
+	Status   string
+}
+
+// Refund struct is a refund of a payment back to the way it was paid, the amount is in paisa.
+type Refund struct {
+	ID        string
+	PaymentID string
+	Receipt   string
+	Amount    int64
+	Status    string
+}
+
+// PaymentGateway interface is used to raise orders, check and refund payments with the payment gateway. FindRefund returns nil when the payment has no refund with the receipt.
+type PaymentGateway interface {
+	CreateOrder(amount int64, receipt string) (*Order, error)
+	VerifyPayment(orderID string, paymentID string, signature string) error
+	Refund(paymentID string, amount int64, receipt string) (*Refund, error)
+	FindRefund(paymentID string, receipt string) (*Refund, error)
+	FetchStatus(orderID string) (*Order, error)
+	ParseWebhook(body []byte, signature string) (*Event, error)
+}
+
+// Sign function returns the signature the gateway sends along with a payment of the order, an HMAC-SHA256 of order_id|payment_id with the key secret.
+func Sign(secret string, orderID string, paymentID string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(orderID + "|" + paymentID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify function is used to check the signature of the payment against the key secret.
+func verify(secret string, orderID string, paymentID string, signature string) error {
+	if secret == "" || !hmac.Equal([]byte(Sign(secret, orderID, paymentID)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// NewGateway function is used to instantiate the gateway set through PAYMENT_GATEWAY, the fake one for "fake" and Razorpay otherwise.
+func NewGateway() PaymentGateway {
+	if os.Getenv("PAYMENT_GATEWAY") != "fake" {
+		return NewRazorpayGateway(os.Getenv("RAZOR_KEY_ID"), os.Getenv("RAZOR_SECRET"), os.Getenv("RAZOR_WEBHOOK_SECRET"))
+	}
+	fake := NewFakeGateway(os.Getenv("RAZOR_SECRET"))
+	fake.Decline = os.Getenv("FAKE_PAYMENT_DECLINE") == "true"
+	fake.FailRefunds = os.Getenv("FAKE_REFUND_FAIL") == "true"
+	if ms, err := strconv.Atoi(os.Getenv("FAKE_PAYMENT_DELAY_MS")); err == nil && ms > 0 {
+		fake.Delay = time.Duration(ms) * time.Millisecond
+	}
+	return fake
+}
@@ -0,0 +1,141 @@
+package payment
+
+import (
+	"errors"
+	"log"
+
+	"github.com/razorpay/razorpay-go"
+)
+
+// RazorpayGateway struct is the PaymentGateway backed by the Razorpay API.
+type RazorpayGateway struct {
+	client        *razorpay.Client
+	secret        string
+	webhookSecret string
+}
+
+// CreateOrder implements PaymentGateway.
+func (rg *RazorpayGateway) CreateOrder(amount int64, receipt string) (*Order, error) {
+	data := map[string]interface{}{
+		"amount":   amount,
+		"currency": "INR",
+		"receipt":  receipt,
+	}
+	body, err := rg.client.Order.Create(data, nil)
+	if err != nil {
+		log.Println("Unable to create the Razorpay order, in razorpay file")
+		return nil, err
+	}
+	order := orderOf(body)
+	if order.ID == "" {
+		log.Println("Razorpay order without an id, in razorpay file")
+		return nil, errors.New("razorpay order has no id")
+	}
+	return order, nil
+}
+
+// VerifyPayment implements PaymentGateway.
+func (rg *RazorpayGateway) VerifyPayment(orderID string, paymentID string, signature string) error {
+	return verify(rg.secret, orderID, paymentID, signature)
+}
+
+// Refund implements PaymentGateway.
+func (rg *RazorpayGateway) Refund(paymentID string, amount int64, receipt string) (*Refund, error) {
+	body, err := rg.client.Payment.Refund(paymentID, int(amount), map[string]interface{}{"receipt": receipt}, nil)
+	if err != nil {
+		log.Println("Unable to refund the Razorpay payment, in razorpay file")
+		return nil, err
+	}
+	return &Refund{ID: text(body["id"]), PaymentID: paymentID, Receipt: receipt, Amount: paisa(body["amount"]), Status: text(body["status"])}, nil
+}
+
+// FindRefund implements PaymentGateway.
+func (rg *RazorpayGateway) FindRefund(paymentID string, receipt string) (*Refund, error) {
+	body, err := rg.client.Payment.FetchMultipleRefund(paymentID, nil, nil)
+	if err != nil {
+		log.Println("Unable to fetch the refunds of the Razorpay payment, in razorpay file")
+		return nil, err
+	}
+	items, _ := body["items"].([]interface{})
+	for _, item := range items {
+		refund, ok := item.(map[string]interface{})
+		if !ok || text(refund["receipt"]) != receipt {
+			continue
+		}
+		return &Refund{ID: text(refund["id"]), PaymentID: paymentID, Receipt: receipt, Amount: paisa(refund["amount"]), Status: text(refund["status"])}, nil
+	}
+	return nil, nil
+}
+
+// FetchStatus implements PaymentGateway.
+func (rg *RazorpayGateway) FetchStatus(orderID string) (*Order, error) {
+	body, err := rg.client.Order.Fetch(orderID, nil, nil)
+	if err != nil {
+		log.Println("Unable to fetch the Razorpay order, in razorpay file")
+		return nil, err
+	}
+	order := orderOf(body)
+	payments, err := rg.client.Order.Payments(orderID, nil, nil)
+	if err != nil {
+		log.Println("Unable to fetch the payments of the Razorpay order, in razorpay file")
+		return nil, err
+	}
+	items, _ := payments["items"].([]interface{})
+	for _, item := range items {
+		payment, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		order.Payments = append(order.Payments, Payment{
+			ID:       text(payment["id"]),
+			OrderID:  orderID,
+			Amount:   paisa(payment["amount"]),
+			Currency: text(payment["currency"]),
+			Status:   text(payment["status"]),
+		})
+	}
+	return order, nil
+}
+
+// ParseWebhook implements PaymentGateway.
+func (rg *RazorpayGateway) ParseWebhook(body []byte, signature string) (*Event, error) {
+	return parseWebhook(rg.webhookSecret, body, signature)
+}
+
+// orderOf function reads the order out of the body Razorpay sent.
+func orderOf(body map[string]interface{}) *Order {
+	return &Order{
+		ID:       text(body["id"]),
+		Receipt:  text(body["receipt"]),
+		Amount:   paisa(body["amount"]),
+		Currency: text(body["currency"]),
+		Status:   text(body["status"]),
+	}
+}
+
+func text(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// paisa function reads an amount Razorpay sent, JSON numbers arrive as float64.
+func paisa(value interface{}) int64 {
+	switch v := value.(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	}
+	return 0
+}
+
+// NewRazorpayGateway function is used to instantiate the Razorpay gateway with the API key and the secret the webhooks are signed with.
+func NewRazorpayGateway(keyID string, secret string, webhookSecret string) *RazorpayGateway {
+	return &RazorpayGateway{
+		client:        razorpay.NewClient(keyID, secret),
+		secret:        secret,
+		webhookSecret: webhookSecret,
+	}
+}
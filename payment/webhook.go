@@ -0,0 +1,103 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// Webhook events the gateway sends.
+const (
+	EventPaymentCaptured = "payment.captured"
+	EventPaymentFailed   = "payment.failed"
+	EventRefundProcessed = "refund.processed"
+)
+
+// ErrInvalidWebhook is returned for a webhook whose body was not signed with the webhook secret.
+var ErrInvalidWebhook = errors.New("webhook signature does not match")
+
+// Event struct is a webhook the gateway sent about a payment or a refund, the amount is in paisa and is the one of the refund for refund events.
+type Event struct {
+	Type      string
+	OrderID   string
+	PaymentID string
+	RefundID  string
+	Amount    int64
+	Currency  string
+	Status    string
+}
+
+// webhookBody struct is the part of the webhook body read by ParseWebhook, laid out the way Razorpay sends it.
+type webhookBody struct {
+	Event   string `json:"event"`
+	Payload struct {
+		Payment *struct {
+			Entity webhookEntity `json:"entity"`
+		} `json:"payment,omitempty"`
+		Refund *struct {
+			Entity webhookEntity `json:"entity"`
+		} `json:"refund,omitempty"`
+	} `json:"payload"`
+}
+
+type webhookEntity struct {
+	ID        string `json:"id"`
+	OrderID   string `json:"order_id,omitempty"`
+	PaymentID string `json:"payment_id,omitempty"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Status    string `json:"status"`
+}
+
+// SignWebhook function returns the signature the gateway sends along with the webhook body, an HMAC-SHA256 of the body with the webhook secret.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseWebhook function is used to check the signature of the webhook body and read the event out of it.
+func parseWebhook(secret string, body []byte, signature string) (*Event, error) {
+	if secret == "" || !hmac.Equal([]byte(SignWebhook(secret, body)), []byte(signature)) {
+		return nil, ErrInvalidWebhook
+	}
+	parsed := &webhookBody{}
+	if err := json.Unmarshal(body, parsed); err != nil {
+		return nil, err
+	}
+	event := &Event{Type: parsed.Event}
+	if parsed.Payload.Payment != nil {
+		paid := parsed.Payload.Payment.Entity
+		event.OrderID, event.PaymentID, event.Amount, event.Currency, event.Status = paid.OrderID, paid.ID, paid.Amount, paid.Currency, paid.Status
+	}
+	if parsed.Payload.Refund != nil {
+		refund := parsed.Payload.Refund.Entity
+		event.RefundID, event.Amount, event.Status = refund.ID, refund.Amount, refund.Status
+		if refund.Currency != "" {
+			event.Currency = refund.Currency
+		}
+		if event.PaymentID == "" {
+			event.PaymentID = refund.PaymentID
+		}
+	}
+	if event.Type == "" || event.PaymentID == "" {
+		return nil, errors.New("webhook has no event or payment")
+	}
+	return event, nil
+}
+
+// webhookOf function is used to lay out the event the way Razorpay sends it.
+func webhookOf(event *Event) ([]byte, error) {
+	body := &webhookBody{Event: event.Type}
+	body.Payload.Payment = &struct {
+		Entity webhookEntity `json:"entity"`
+	}{webhookEntity{ID: event.PaymentID, OrderID: event.OrderID, Amount: event.Amount, Currency: event.Currency, Status: event.Status}}
+	if event.RefundID != "" {
+		body.Payload.Refund = &struct {
+			Entity webhookEntity `json:"entity"`
+		}{webhookEntity{ID: event.RefundID, PaymentID: event.PaymentID, Amount: event.Amount, Currency: event.Currency, Status: event.Status}}
+	}
+	return json.Marshal(body)
+}
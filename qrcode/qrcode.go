@@ -0,0 +1,423 @@
+package qrcode
+
+import "errors"
+
+// version struct is the block layout of a QR code version at error correction level M.
+type version struct {
+	codewords  int
+	ecPerBlock int
+	blocks     int
+	alignment  []int
+}
+
+// versions holds the versions 1 to 10 at level M, enough for a few hundred bytes.
+var versions = []version{
+	{26, 10, 1, nil},
+	{44, 16, 1, []int{6, 18}},
+	{70, 26, 1, []int{6, 22}},
+	{100, 18, 2, []int{6, 26}},
+	{134, 24, 2, []int{6, 30}},
+	{172, 16, 4, []int{6, 34}},
+	{196, 18, 4, []int{6, 22, 38}},
+	{242, 22, 4, []int{6, 24, 42}},
+	{292, 22, 5, []int{6, 26, 46}},
+	{346, 26, 5, []int{6, 28, 50}},
+}
+
+// levelM is the format value of error correction level M.
+const levelM = 0
+
+// Code struct is an encoded QR code, Dark tells the colour of a module.
+type Code struct {
+	Size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+// Dark function reports whether the module at the column x and the row y is dark, modules outside the code are light.
+func (c *Code) Dark(x int, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// Encode function is used to encode the data in byte mode in the smallest version that fits it.
+func Encode(data []byte) (*Code, error) {
+	for i := range versions {
+		ver := i + 1
+		countBits := 8
+		if ver >= 10 {
+			countBits = 16
+		}
+		capacity := versions[i].codewords - versions[i].ecPerBlock*versions[i].blocks
+		if 4+countBits+8*len(data) > capacity*8 {
+			continue
+		}
+		code := newCode(ver)
+		code.drawCodewords(interleave(versions[i], dataCodewords(data, countBits, capacity)))
+		code.applyBestMask()
+		return code, nil
+	}
+	return nil, errors.New("data is too long for a QR code")
+}
+
+// dataCodewords function is used to build the byte mode segment and pad it up to the capacity of the version.
+func dataCodewords(data []byte, countBits int, capacity int) []byte {
+	bits := &bitBuffer{}
+	bits.append(0x4, 4)
+	bits.append(len(data), countBits)
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	terminator := capacity*8 - bits.length
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-bits.length%8)%8)
+	for pad := 0xEC; bits.length < capacity*8; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	return bits.bytes
+}
+
+// interleave function is used to split the data into the blocks of the version, add their error correction and interleave them.
+func interleave(v version, data []byte) []byte {
+	dataLen := len(data)
+	short := dataLen / v.blocks
+	longBlocks := dataLen % v.blocks
+	var blocks, ecc [][]byte
+	for i, k := 0, 0; i < v.blocks; i++ {
+		length := short
+		if i >= v.blocks-longBlocks {
+			length++
+		}
+		block := data[k : k+length]
+		k += length
+		blocks = append(blocks, block)
+		ecc = append(ecc, reedSolomon(block, v.ecPerBlock))
+	}
+	var result []byte
+	for i := 0; i <= short; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for _, block := range ecc {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+func newCode(ver int) *Code {
+	size := ver*4 + 17
+	c := &Code{Size: size}
+	c.modules = make([][]bool, size)
+	c.function = make([][]bool, size)
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.function[i] = make([]bool, size)
+	}
+	for i := 0; i < size; i++ {
+		c.set(6, i, i%2 == 0)
+		c.set(i, 6, i%2 == 0)
+	}
+	c.drawFinder(3, 3)
+	c.drawFinder(size-4, 3)
+	c.drawFinder(3, size-4)
+	positions := versions[ver-1].alignment
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(x, y)
+		}
+	}
+	// the format bits are reserved here and written once the mask is chosen
+	c.drawFormat(0)
+	if ver >= 7 {
+		c.drawVersion(ver)
+	}
+	return c
+}
+
+// set function is used to draw a function module, data is never written over it.
+func (c *Code) set(x int, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+func (c *Code) drawFinder(cx int, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.set(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(cx int, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.set(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// formatBits function returns the 15 bit format information of level M and the mask.
+func formatBits(mask int) int {
+	data := levelM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (c *Code) drawFormat(mask int) {
+	bits := formatBits(mask)
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+	for i := 0; i <= 5; i++ {
+		c.set(8, i, bit(i))
+	}
+	c.set(8, 7, bit(6))
+	c.set(8, 8, bit(7))
+	c.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.set(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		c.set(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.set(8, c.Size-15+i, bit(i))
+	}
+	c.set(8, c.Size-8, true)
+}
+
+// versionBits function returns the 18 bit version information, only versions 7 and up carry it.
+func versionBits(ver int) int {
+	rem := ver
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return ver<<12 | rem
+}
+
+func (c *Code) drawVersion(ver int) {
+	bits := versionBits(ver)
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 != 0
+		a, b := c.Size-11+i%3, i/3
+		c.set(a, b, dark)
+		c.set(b, a, dark)
+	}
+}
+
+// drawCodewords function is used to place the codewords in the zigzag order, two columns at a time from the bottom right corner.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if c.function[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				c.modules[y][x] = (codewords[i>>3]>>(7-uint(i&7)))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// masked function reports whether the mask pattern flips the module at the column x and the row y.
+func masked(mask int, x int, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.function[y][x] && masked(mask, x, y) {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask function is used to try every mask and keep the one with the lowest penalty.
+func (c *Code) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask)
+	}
+	c.applyMask(best)
+	c.drawFormat(best)
+}
+
+// penalty function scores the code by the four rules of the standard, runs, blocks, finder like patterns and the dark balance.
+func (c *Code) penalty() int {
+	penalty := 0
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+	line := make([]bool, c.Size)
+	for _, vertical := range []bool{false, true} {
+		for i := 0; i < c.Size; i++ {
+			for j := 0; j < c.Size; j++ {
+				if vertical {
+					line[j] = c.modules[j][i]
+				} else {
+					line[j] = c.modules[i][j]
+				}
+			}
+			run := 1
+			for j := 1; j <= c.Size; j++ {
+				if j < c.Size && line[j] == line[j-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += run - 2
+				}
+				run = 1
+			}
+			for j := 0; j+11 <= c.Size; j++ {
+				for _, pattern := range finderLike {
+					if matches(line[j:j+11], pattern) {
+						penalty += 40
+					}
+				}
+			}
+		}
+	}
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < c.Size && y+1 < c.Size {
+				colour := c.modules[y][x]
+				if c.modules[y][x+1] == colour && c.modules[y+1][x] == colour && c.modules[y+1][x+1] == colour {
+					penalty += 3
+				}
+			}
+		}
+	}
+	total := c.Size * c.Size
+	penalty += abs(dark*20-total*10) / total * 10
+	return penalty
+}
+
+func matches(line []bool, pattern []bool) bool {
+	for i := range pattern {
+		if line[i] != pattern[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// reedSolomon function returns the error correction codewords of the block over GF(256).
+func reedSolomon(data []byte, degree int) []byte {
+	divisor := make([]byte, degree)
+	divisor[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range divisor {
+			divisor[j] = gfMultiply(divisor[j], root)
+			if j+1 < len(divisor) {
+				divisor[j] ^= divisor[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	result := make([]byte, degree)
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[degree-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply function multiplies two elements of GF(256) modulo the QR polynomial x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x byte, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+// bitBuffer struct is used to collect the bits of the data codewords.
+type bitBuffer struct {
+	bytes  []byte
+	length int
+}
+
+func (b *bitBuffer) append(value int, count int) {
+	for i := count - 1; i >= 0; i-- {
+		if b.length%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if (value>>uint(i))&1 != 0 {
+			b.bytes[b.length/8] |= 0x80 >> uint(b.length%8)
+		}
+		b.length++
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,181 @@
+package qrcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_reedSolomon(t *testing.T) {
+	// the version 1-M codewords of HELLO WORLD from the worked example of the standard
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := reedSolomon(data, 10); !bytes.Equal(got, want) {
+		t.Errorf("reedSolomon() = %v, want %v", got, want)
+	}
+}
+
+func Test_formatAndVersionBits(t *testing.T) {
+	if got := formatBits(0); got != 0x5412 {
+		t.Errorf("formatBits(0) = %015b, want %015b", got, 0x5412)
+	}
+	if got := formatBits(4); got != 0b100010111111001 {
+		t.Errorf("formatBits(4) = %015b, want 100010111111001", got)
+	}
+	if got := versionBits(7); got != 0b000111110010010100 {
+		t.Errorf("versionBits(7) = %018b, want 000111110010010100", got)
+	}
+}
+
+func Test_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		size    int
+		wantErr bool
+	}{
+		{name: "version 1", data: "GOBUS", size: 21},
+		{name: "version 4", data: strings.Repeat("x", 60), size: 33},
+		{name: "version 7 carries its version", data: strings.Repeat("y", 120), size: 45},
+		{name: "version 10 has a 16 bit count", data: strings.Repeat("z", 200), size: 57},
+		{name: "too long", data: strings.Repeat("z", 214), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Encode([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("qrcode.Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if code.Size != tt.size {
+				t.Fatalf("qrcode.Encode() size = %d, want %d", code.Size, tt.size)
+			}
+			if got := decode(t, code); got != tt.data {
+				t.Errorf("qrcode.Encode() decodes to %q, want %q", got, tt.data)
+			}
+		})
+	}
+}
+
+// decode function reads the code back the way a scanner would, from the format bits to the byte mode segment.
+func decode(t *testing.T, code *Code) string {
+	t.Helper()
+	for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+		for i := 0; i < 7; i++ {
+			if !code.Dark(corner[0]+i, corner[1]) || !code.Dark(corner[0]+3, corner[1]+3) {
+				t.Fatalf("finder pattern missing at %v", corner)
+			}
+		}
+	}
+	format := 0
+	for i := 14; i >= 9; i-- {
+		format = format<<1 | bit(code.Dark(14-i, 8))
+	}
+	format = format<<1 | bit(code.Dark(7, 8))
+	format = format<<1 | bit(code.Dark(8, 8))
+	format = format<<1 | bit(code.Dark(8, 7))
+	for i := 5; i >= 0; i-- {
+		format = format<<1 | bit(code.Dark(8, i))
+	}
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if formatBits(m) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("format bits %015b are not level M", format)
+	}
+
+	ver := (code.Size - 17) / 4
+	v := versions[ver-1]
+	reader := newCode(ver)
+	var codewords []byte
+	current, count := byte(0), 0
+	for right := code.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < code.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = code.Size - 1 - vert
+				}
+				if reader.function[y][x] {
+					continue
+				}
+				dark := code.Dark(x, y) != masked(mask, x, y)
+				current = current<<1 | byte(bit(dark))
+				if count++; count%8 == 0 {
+					codewords = append(codewords, current)
+					current = 0
+				}
+			}
+		}
+	}
+	codewords = codewords[:v.codewords]
+
+	// undo the interleaving and check every block against its error correction
+	dataLen := v.codewords - v.ecPerBlock*v.blocks
+	short, long := dataLen/v.blocks, dataLen%v.blocks
+	blocks := make([][]byte, v.blocks)
+	k := 0
+	for i := 0; i <= short; i++ {
+		for b := range blocks {
+			if i < short || b >= v.blocks-long {
+				blocks[b] = append(blocks[b], codewords[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for b := range blocks {
+		ecc := make([]byte, v.ecPerBlock)
+		for i := range ecc {
+			ecc[i] = codewords[dataLen+i*v.blocks+b]
+		}
+		if !bytes.Equal(reedSolomon(blocks[b], v.ecPerBlock), ecc) {
+			t.Fatalf("block %d does not match its error correction", b)
+		}
+		data = append(data, blocks[b]...)
+	}
+
+	if data[0]>>4 != 0x4 {
+		t.Fatalf("mode %x is not byte mode", data[0]>>4)
+	}
+	bits := &bitReader{data: data, pos: 4}
+	countBits := 8
+	if ver >= 10 {
+		countBits = 16
+	}
+	length := bits.read(countBits)
+	out := make([]byte, length)
+	for i := range out {
+		out[i] = byte(bits.read(8))
+	}
+	return string(out)
+}
+
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) read(count int) int {
+	value := 0
+	for i := 0; i < count; i++ {
+		value = value<<1 | int(r.data[r.pos/8]>>(7-uint(r.pos%8))&1)
+		r.pos++
+	}
+	return value
+}
+
+func bit(dark bool) int {
+	if dark {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,131 @@
+package recurrence
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequencies a bus can run at.
+const (
+	Daily    = "daily"
+	Weekdays = "weekdays"
+	Days     = "days"
+)
+
+// DateFormat is the format of the start, end and excluded dates of a rule.
+const DateFormat = "02 01 2006"
+
+// DefaultDaysAhead is how far ahead charts are generated when CHART_DAYS_AHEAD is not set.
+const DefaultDaysAhead = 30
+
+// MaxRange is the longest date range charts can be generated for in one go.
+const MaxRange = 366 * 24 * time.Hour
+
+// DaysAhead function returns the configured number of days charts are generated ahead.
+func DaysAhead() int {
+	days, err := strconv.Atoi(os.Getenv("CHART_DAYS_AHEAD"))
+	if err != nil || days <= 0 {
+		days = DefaultDaysAhead
+	}
+	return days
+}
+
+// Rule struct describes the days a bus runs on, a zero Start or End leaves that side open.
+type Rule struct {
+	Frequency string
+	Days      []time.Weekday
+	Excluded  map[string]bool
+	Start     time.Time
+	End       time.Time
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// New function is used to build a rule from its stored form, the days are weekday names such as "mon" or "monday".
+func New(frequency string, days []string, excluded []string, start string, end string) (*Rule, error) {
+	rule := &Rule{Frequency: strings.ToLower(frequency), Excluded: map[string]bool{}}
+	switch rule.Frequency {
+	case Daily, Weekdays:
+	case Days:
+		if len(days) == 0 {
+			return nil, errors.New("pick the days the bus runs on")
+		}
+		for _, name := range days {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if len(name) > 3 {
+				name = name[:3]
+			}
+			day, ok := weekdayNames[name]
+			if !ok {
+				return nil, errors.New("invalid day " + name)
+			}
+			rule.Days = append(rule.Days, day)
+		}
+	default:
+		return nil, errors.New("frequency must be daily, weekdays or days")
+	}
+	for _, date := range excluded {
+		day, err := time.Parse(DateFormat, date)
+		if err != nil {
+			return nil, errors.New("invalid excluded date " + date)
+		}
+		rule.Excluded[day.Format(DateFormat)] = true
+	}
+	var err error
+	if start != "" {
+		if rule.Start, err = time.Parse(DateFormat, start); err != nil {
+			return nil, errors.New("invalid start date")
+		}
+	}
+	if end != "" {
+		if rule.End, err = time.Parse(DateFormat, end); err != nil {
+			return nil, errors.New("invalid end date")
+		}
+	}
+	if !rule.Start.IsZero() && !rule.End.IsZero() && rule.End.Before(rule.Start) {
+		return nil, errors.New("end date is before the start date")
+	}
+	return rule, nil
+}
+
+// RunsOn function reports whether the bus runs on the given day.
+func (r *Rule) RunsOn(day time.Time) bool {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	if (!r.Start.IsZero() && day.Before(r.Start)) || (!r.End.IsZero() && day.After(r.End)) {
+		return false
+	}
+	if r.Excluded[day.Format(DateFormat)] {
+		return false
+	}
+	switch r.Frequency {
+	case Daily:
+		return true
+	case Weekdays:
+		return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+	case Days:
+		for _, weekday := range r.Days {
+			if day.Weekday() == weekday {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Dates function returns the days from from to to, both included, that the bus runs on.
+func (r *Rule) Dates(from time.Time, to time.Time) []time.Time {
+	var dates []time.Time
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if r.RunsOn(day) {
+			dates = append(dates, day)
+		}
+	}
+	return dates
+}
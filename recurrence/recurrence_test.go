@@ -0,0 +1,85 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency string
+		days      []string
+		excluded  []string
+		start     string
+		end       string
+		wantErr   bool
+	}{
+		{name: "daily", frequency: "daily"},
+		{name: "days by full name", frequency: "days", days: []string{"Monday", "fri"}},
+		{name: "days missing", frequency: "days", wantErr: true},
+		{name: "unknown day", frequency: "days", days: []string{"someday"}, wantErr: true},
+		{name: "unknown frequency", frequency: "hourly", wantErr: true},
+		{name: "bad excluded date", frequency: "daily", excluded: []string{"2030-01-01"}, wantErr: true},
+		{name: "end before start", frequency: "daily", start: "10 01 2030", end: "01 01 2030", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.frequency, tt.days, tt.excluded, tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recurrence.New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Rule_Dates(t *testing.T) {
+	// 01 01 2030 is a Tuesday.
+	from, _ := time.Parse(DateFormat, "31 12 2029")
+	to, _ := time.Parse(DateFormat, "13 01 2030")
+	format := func(dates []time.Time) []string {
+		var out []string
+		for _, date := range dates {
+			out = append(out, date.Format("02"))
+		}
+		return out
+	}
+	tests := []struct {
+		name string
+		rule func() (*Rule, error)
+		want []string
+	}{
+		{
+			name: "weekdays",
+			rule: func() (*Rule, error) { return New(Weekdays, nil, nil, "", "") },
+			want: []string{"31", "01", "02", "03", "04", "07", "08", "09", "10", "11"},
+		},
+		{
+			name: "days with an exclusion",
+			rule: func() (*Rule, error) { return New(Days, []string{"tue", "sat"}, []string{"08 01 2030"}, "", "") },
+			want: []string{"01", "05", "12"},
+		},
+		{
+			name: "daily within the start and end",
+			rule: func() (*Rule, error) { return New(Daily, nil, nil, "11 01 2030", "20 01 2030") },
+			want: []string{"11", "12", "13"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := tt.rule()
+			if err != nil {
+				t.Fatalf("recurrence.New() error = %v", err)
+			}
+			got := format(rule.Dates(from, to))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Rule.Dates() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Rule.Dates() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -2,8 +2,6 @@ package repository
 
 import (
 	"errors"
-	"fmt"
-	"gobus/dto"
 	"gobus/entities"
 	"gobus/repository/interfaces"
 	"log"
@@ -32,6 +30,43 @@ func (ar *AdminRepositoryImpl) GetRouteByBus(scheduleID int) (*entities.Schedule
 	return schedule, nil
 }
 
+// CountSegmentCharts implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) CountSegmentCharts(scheduleID int, from time.Time) (int64, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return 0, errors.New("error connecting database")
+	}
+	var count int64
+	result := ar.DB.Model(&entities.BusSchedule{}).
+		Joins("JOIN buses ON buses.bus_id = bus_schedules.bus_id").
+		Where("buses.schedule_id=? AND bus_schedules.day>=? AND bus_schedules.segment_occupancy IS NOT NULL", scheduleID, from).
+		Count(&count)
+	if result.Error != nil {
+		log.Println("Unable to count the charts sold by segment")
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// ReplaceScheduleStops implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) ReplaceScheduleStops(scheduleID int, stops []*entities.ScheduleStop) ([]*entities.ScheduleStop, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	err := ar.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("schedule_id=?", scheduleID).Delete(&entities.ScheduleStop{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&stops).Error
+	})
+	if err != nil {
+		log.Println("Unable to replace the stops of the schedule")
+		return nil, err
+	}
+	return stops, nil
+}
+
 // ViewBookingsToBeCancelled implements interfaces.AdminRepository.
 func (ar *AdminRepositoryImpl) ViewBookingsToBeCancelled(busID int, day string) ([]*entities.Booking, error) {
 	if ar.DB == nil {
@@ -173,19 +208,11 @@ func (ar *AdminRepositoryImpl) AddFareForRoute(baseFare *entities.BaseFare) (*en
 }
 
 // AddBusSchedule implements interfaces.AdminRepository.
-func (ar *AdminRepositoryImpl) AddBusSchedule(schedule *dto.BusSchedule) (*entities.BusSchedule, error) {
+func (ar *AdminRepositoryImpl) AddBusSchedule(chart *entities.BusSchedule) (*entities.BusSchedule, error) {
 	if ar.DB == nil {
 		log.Println("Error connecting DB")
 		return nil, errors.New("error connecting database")
 	}
-	chart := &entities.BusSchedule{}
-	chart.BusID = schedule.BusID
-	parsedDate, err := time.Parse("02 01 2006", schedule.Day)
-	fmt.Println("Parsed Date:", parsedDate)
-	chart.Day = parsedDate
-	if err != nil {
-		log.Println(err)
-	}
 	result := ar.DB.Create(chart)
 	if result.Error != nil {
 		log.Println("Unable to add bus schedule")
@@ -194,6 +221,97 @@ func (ar *AdminRepositoryImpl) AddBusSchedule(schedule *dto.BusSchedule) (*entit
 	return chart, nil
 }
 
+// GetBusTypeForProvider implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) GetBusTypeForProvider(code string, providerID uint) (*entities.BusType, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	busType := &entities.BusType{}
+	result := ar.DB.Where("bus_type_code=? AND provider_id IN ?", code, []uint{providerID, 0}).Order("provider_id desc").First(busType)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return busType, nil
+}
+
+// GetSeatLayout implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) GetSeatLayout(id int) (*entities.BusSeatLayout, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	seatLayout := &entities.BusSeatLayout{}
+	result := ar.DB.Where("id= ?", id).First(seatLayout)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return seatLayout, nil
+}
+
+// SaveRecurrence implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) SaveRecurrence(recurrence *entities.ScheduleRecurrence) (*entities.ScheduleRecurrence, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	existing := &entities.ScheduleRecurrence{}
+	if err := ar.DB.Where("bus_id=?", recurrence.BusID).First(existing).Error; err == nil {
+		recurrence.ID = existing.ID
+		recurrence.CreatedAt = existing.CreatedAt
+	}
+	result := ar.DB.Save(recurrence)
+	if result.Error != nil {
+		log.Println("Unable to save the recurrence")
+		return nil, result.Error
+	}
+	return recurrence, nil
+}
+
+// FindRecurrence implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) FindRecurrence(busID int) (*entities.ScheduleRecurrence, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	recurrence := &entities.ScheduleRecurrence{}
+	result := ar.DB.Where("bus_id=?", busID).First(recurrence)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return recurrence, nil
+}
+
+// FindAllRecurrences implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) FindAllRecurrences() ([]*entities.ScheduleRecurrence, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var recurrences []*entities.ScheduleRecurrence
+	result := ar.DB.Order("bus_id").Find(&recurrences)
+	if result.Error != nil {
+		log.Println("Unable to fetch the recurrences")
+		return nil, result.Error
+	}
+	return recurrences, nil
+}
+
+// FindChartDays implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) FindChartDays(busID int, from time.Time, to time.Time) ([]time.Time, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var days []time.Time
+	result := ar.DB.Model(&entities.BusSchedule{}).Where("bus_id=? AND day BETWEEN ? AND ?", busID, from, to).Pluck("day", &days)
+	if result.Error != nil {
+		log.Println("Unable to fetch the chart days")
+		return nil, result.Error
+	}
+	return days, nil
+}
+
 // FindUserByEmail implements interfaces.AdminRepository.
 func (ar *AdminRepositoryImpl) FindUserByEmail(mail string) (*entities.User, error) {
 	if ar.DB == nil {
@@ -402,7 +520,8 @@ func (ar *AdminRepositoryImpl) EditProvider(id int, provider *entities.ServicePr
 	if provider.Password != "" {
 		foundProvider.Password = provider.Address
 	}
-	result := ar.DB.Save(&foundProvider)
+	// the wallet is only changed through the ledger
+	result := ar.DB.Omit("provider_wallet").Save(&foundProvider)
 	if result.Error != nil {
 		log.Println("User Not Updated maybe the same email already present, AdminRepositoryImpl package")
 		return nil, errors.New("user not updated")
@@ -468,7 +587,8 @@ func (ar *AdminRepositoryImpl) EditUser(id int, user *entities.User) (*entities.
 		foundUser.PhoneNumber = user.PhoneNumber
 	}
 	foundUser.IsLocked = true
-	result := ar.DB.Save(&foundUser)
+	// the wallet is only changed through the ledger
+	result := ar.DB.Omit("user_wallet").Save(&foundUser)
 	if result.Error != nil {
 		log.Println("User Not Updated maybe the same email already present, AdminRepositoryImpl package")
 		return nil, errors.New("user not updated")
@@ -560,6 +680,54 @@ func (ar *AdminRepositoryImpl) FindUserByID(id int) (*entities.User, error) {
 	return user, nil
 }
 
+// Ledger implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) Ledger() interfaces.LedgerRepository {
+	return &LedgerRepositoryImpl{DB: ar.DB}
+}
+
+// Refunds implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) Refunds() interfaces.RefundRepository {
+	return &RefundRepositoryImpl{DB: ar.DB}
+}
+
+// Reconciliation implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) Reconciliation() interfaces.ReconciliationRepository {
+	return &ReconciliationRepositoryImpl{DB: ar.DB}
+}
+
+// Settlements implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) Settlements() interfaces.SettlementRepository {
+	return &SettlementRepositoryImpl{DB: ar.DB}
+}
+
+// FindSuspiciousPayments implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) FindSuspiciousPayments() ([]*entities.SuspiciousPayment, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var attempts []*entities.SuspiciousPayment
+	if err := ar.DB.Order("id DESC").Find(&attempts).Error; err != nil {
+		log.Println("Unable to fetch the suspicious payments, AdminRepositoryImpl package")
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// FindScheduleStops implements interfaces.AdminRepository.
+func (ar *AdminRepositoryImpl) FindScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error) {
+	if ar.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var stops []*entities.ScheduleStop
+	result := ar.DB.Where("schedule_id=?", scheduleID).Order("sequence").Find(&stops)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return stops, nil
+}
+
 // NewAdminRepository function is used to initialize/instatiate Admin Repository.
 func NewAdminRepository(db *gorm.DB) interfaces.AdminRepository {
 	return &AdminRepositoryImpl{
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"errors"
+	"gobus/entities"
+	"gobus/repository/interfaces"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LedgerRepositoryImpl struct is used to define the wallet ledger Repository Implementation.
+type LedgerRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// WithTx implements interfaces.LedgerRepository.
+func (lr *LedgerRepositoryImpl) WithTx(fn func(tx interfaces.LedgerRepository) error) error {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	// inside a transaction of another repository this runs as a savepoint of it
+	return lr.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&LedgerRepositoryImpl{DB: tx})
+	})
+}
+
+// OpenAccount implements interfaces.LedgerRepository.
+func (lr *LedgerRepositoryImpl) OpenAccount(account *entities.LedgerAccount) (*entities.LedgerAccount, bool, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, false, errors.New("error connecting database")
+	}
+	result := lr.DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, DoNothing: true}).Create(account)
+	if result.Error != nil {
+		log.Println("Unable to open the ledger account, LedgerRepositoryImpl package")
+		return nil, false, result.Error
+	}
+	locked := &entities.LedgerAccount{}
+	if err := lr.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code=?", account.Code).First(locked).Error; err != nil {
+		log.Println("Unable to lock the ledger account, LedgerRepositoryImpl package")
+		return nil, false, err
+	}
+	return locked, result.RowsAffected == 1, nil
+}
+
+// SaveAccountBalance implements interfaces.LedgerRepository.
+func (lr *LedgerRepositoryImpl) SaveAccountBalance(account *entities.LedgerAccount) error {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := lr.DB.Model(account).Update("balance", account.Balance).Error; err != nil {
+		log.Println("Unable to update the ledger balance, LedgerRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// WalletBalance implements interfaces.LedgerRepository. The wallet columns hold what the wallet had in whole rupees before the ledger, the balance since is only kept on the ledger account.
+func (lr *LedgerRepositoryImpl) WalletBalance(ownerType string, ownerID uint) (int, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return 0, errors.New("error connecting database")
+	}
+	switch ownerType {
+	case "user":
+		user := &entities.User{}
+		if err := lr.DB.Where("id=?", ownerID).First(user).Error; err != nil {
+			return 0, err
+		}
+		return user.UserWallet, nil
+	case "provider":
+		provider := &entities.ServiceProvider{}
+		if err := lr.DB.Where("provider_id=?", ownerID).First(provider).Error; err != nil {
+			return 0, err
+		}
+		return provider.ProviderWallet, nil
+	}
+	return 0, nil
+}
+
+// FindEntryByKey implements interfaces.LedgerRepository, no entry with the key gives a nil entry.
+func (lr *LedgerRepositoryImpl) FindEntryByKey(key string) (*entities.LedgerEntry, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	entry := &entities.LedgerEntry{}
+	result := lr.DB.Where("key=?", key).Limit(1).Find(entry)
+	if result.Error != nil {
+		log.Println("Unable to find the ledger entry, LedgerRepositoryImpl package")
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+// AddEntry implements interfaces.LedgerRepository.
+func (lr *LedgerRepositoryImpl) AddEntry(entry *entities.LedgerEntry) error {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := lr.DB.Create(entry).Error; err != nil {
+		log.Println("Unable to add the ledger entry, LedgerRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// FindAccounts implements interfaces.LedgerRepository.
+func (lr *LedgerRepositoryImpl) FindAccounts() ([]*entities.LedgerAccount, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var accounts []*entities.LedgerAccount
+	if err := lr.DB.Order("id").Find(&accounts).Error; err != nil {
+		log.Println("Unable to fetch the ledger accounts, LedgerRepositoryImpl package")
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// AccountTotals implements interfaces.LedgerRepository.
+func (lr *LedgerRepositoryImpl) AccountTotals() (map[uint]int64, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var rows []struct {
+		AccountID uint
+		Total     int64
+	}
+	if err := lr.DB.Model(&entities.LedgerLine{}).Select("account_id, SUM(amount) AS total").Group("account_id").Scan(&rows).Error; err != nil {
+		log.Println("Unable to total the ledger accounts, LedgerRepositoryImpl package")
+		return nil, err
+	}
+	totals := map[uint]int64{}
+	for _, row := range rows {
+		totals[row.AccountID] = row.Total
+	}
+	return totals, nil
+}
+
+// UnbalancedEntries implements interfaces.LedgerRepository.
+func (lr *LedgerRepositoryImpl) UnbalancedEntries() ([]uint, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var ids []uint
+	if err := lr.DB.Model(&entities.LedgerLine{}).Select("entry_id").Group("entry_id").Having("SUM(amount) <> 0").Order("entry_id").Scan(&ids).Error; err != nil {
+		log.Println("Unable to check the ledger entries, LedgerRepositoryImpl package")
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FindStatement implements interfaces.LedgerRepository, the entries are newest first with only the lines of the account.
+func (lr *LedgerRepositoryImpl) FindStatement(accountID uint, offset int, limit int) ([]*entities.LedgerEntry, int64, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, 0, errors.New("error connecting database")
+	}
+	touching := lr.DB.Model(&entities.LedgerLine{}).Select("entry_id").Where("account_id=?", accountID)
+	var total int64
+	if err := lr.DB.Model(&entities.LedgerEntry{}).Where("id IN (?)", touching).Count(&total).Error; err != nil {
+		log.Println("Unable to count the ledger entries, LedgerRepositoryImpl package")
+		return nil, 0, err
+	}
+	var entries []*entities.LedgerEntry
+	err := lr.DB.Preload("Lines", "account_id=?", accountID).Where("id IN (?)", touching).Order("id DESC").Offset(offset).Limit(limit).Find(&entries).Error
+	if err != nil {
+		log.Println("Unable to fetch the ledger entries, LedgerRepositoryImpl package")
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// BalanceThrough implements interfaces.LedgerRepository.
+func (lr *LedgerRepositoryImpl) BalanceThrough(accountID uint, entryID uint) (int64, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return 0, errors.New("error connecting database")
+	}
+	var balance int64
+	if err := lr.DB.Model(&entities.LedgerLine{}).Select("COALESCE(SUM(amount), 0)").Where("account_id=? AND entry_id<=?", accountID, entryID).Scan(&balance).Error; err != nil {
+		log.Println("Unable to total the ledger account, LedgerRepositoryImpl package")
+		return 0, err
+	}
+	return balance, nil
+}
+
+// FindOpenAccounts implements interfaces.LedgerRepository, only the accounts of the owner type still holding money are listed.
+func (lr *LedgerRepositoryImpl) FindOpenAccounts(ownerType string) ([]*entities.LedgerAccount, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var accounts []*entities.LedgerAccount
+	if err := lr.DB.Where("owner_type=? AND balance<>0", ownerType).Order("id").Find(&accounts).Error; err != nil {
+		log.Println("Unable to fetch the ledger accounts, LedgerRepositoryImpl package")
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// BookingTotals implements interfaces.LedgerRepository, the lines of the account are summed by the booking of their entry.
+func (lr *LedgerRepositoryImpl) BookingTotals(accountID uint) (map[uint]int64, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var rows []struct {
+		BookingID uint
+		Total     int64
+	}
+	err := lr.DB.Model(&entities.LedgerLine{}).Select("ledger_entries.booking_id, SUM(ledger_lines.amount) AS total").
+		Joins("JOIN ledger_entries ON ledger_entries.id = ledger_lines.entry_id").
+		Where("ledger_lines.account_id=?", accountID).Group("ledger_entries.booking_id").Scan(&rows).Error
+	if err != nil {
+		log.Println("Unable to total the bookings of the ledger account, LedgerRepositoryImpl package")
+		return nil, err
+	}
+	totals := map[uint]int64{}
+	for _, row := range rows {
+		totals[row.BookingID] = row.Total
+	}
+	return totals, nil
+}
+
+// HeldFor implements interfaces.LedgerRepository, an account not opened yet holds nothing.
+func (lr *LedgerRepositoryImpl) HeldFor(code string, bookingID uint) (int64, error) {
+	if lr.DB == nil {
+		log.Println("Error connecting DB")
+		return 0, errors.New("error connecting database")
+	}
+	var held int64
+	err := lr.DB.Model(&entities.LedgerLine{}).Select("COALESCE(SUM(ledger_lines.amount), 0)").
+		Joins("JOIN ledger_entries ON ledger_entries.id = ledger_lines.entry_id").
+		Joins("JOIN ledger_accounts ON ledger_accounts.id = ledger_lines.account_id").
+		Where("ledger_accounts.code=? AND ledger_entries.booking_id=?", code, bookingID).Scan(&held).Error
+	if err != nil {
+		log.Println("Unable to total the booking in the ledger account, LedgerRepositoryImpl package")
+		return 0, err
+	}
+	return held, nil
+}
+
+// NewLedgerRepository function is used to instantiate the wallet ledger repository.
+func NewLedgerRepository(db *gorm.DB) interfaces.LedgerRepository {
+	return &LedgerRepositoryImpl{DB: db}
+}
@@ -5,6 +5,7 @@ import (
 	"gobus/entities"
 	"gobus/repository/interfaces"
 	"log"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -405,9 +406,342 @@ func (pr *ProviderRepositoryImpl) RegisterProvider(provider *entities.ServicePro
 	return provider, nil
 }
 
+// AddSeatLayout implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) AddSeatLayout(layout *entities.BusSeatLayout) (*entities.BusSeatLayout, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	result := pr.DB.Create(layout)
+	if result.Error != nil {
+		log.Println("Unable to add the seat layout, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return layout, nil
+}
+
+// FindSeatLayoutByID implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindSeatLayoutByID(id int) (*entities.BusSeatLayout, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	layout := &entities.BusSeatLayout{}
+	result := pr.DB.Where("id=?", id).First(layout)
+	if result.Error != nil {
+		log.Println("Seat layout doesn't exist")
+		return nil, errors.New("no seat layout found with this id")
+	}
+	return layout, nil
+}
+
+// FindSeatLayouts implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindSeatLayouts(providerID uint) ([]*entities.BusSeatLayout, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var layouts []*entities.BusSeatLayout
+	result := pr.DB.Where("provider_id=?", providerID).Order("id").Find(&layouts)
+	if result.Error != nil {
+		log.Println("Unable to fetch the seat layouts, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return layouts, nil
+}
+
+// FindSeatLayoutVersions implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindSeatLayoutVersions(parentID uint) ([]*entities.BusSeatLayout, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var layouts []*entities.BusSeatLayout
+	result := pr.DB.Where("id=? OR parent_id=?", parentID, parentID).Order("version").Find(&layouts)
+	if result.Error != nil {
+		log.Println("Unable to fetch the seat layout versions, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return layouts, nil
+}
+
+// FindBusesByType implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindBusesByType(code string, providerID uint) ([]*entities.Buses, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var buses []*entities.Buses
+	result := pr.DB.Where("bus_type_code=? AND provider_id=?", code, providerID).Find(&buses)
+	if result.Error != nil {
+		log.Println("Unable to fetch the buses, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return buses, nil
+}
+
+// AssignSeatLayout implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) AssignSeatLayout(code string, providerID uint, layoutID uint) (*entities.BusType, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	busType := &entities.BusType{}
+	result := pr.DB.Where("bus_type_code=? AND provider_id=?", code, providerID).First(busType)
+	if result.Error == nil {
+		result = pr.DB.Model(&entities.BusType{}).Where("bus_type_code=? AND provider_id=?", code, providerID).Update("seat_layout_id", layoutID)
+		if result.Error != nil {
+			log.Println("Unable to assign the seat layout, ProviderRepositoryImpl package")
+			return nil, result.Error
+		}
+		busType.SeatLayoutID = layoutID
+		return busType, nil
+	}
+	//The provider gets its own copy of the shared bus type so the assignment does not affect other providers.
+	result = pr.DB.Where("bus_type_code=? AND provider_id=?", code, 0).First(busType)
+	if result.Error != nil {
+		log.Println("Unable to find the bus type, ProviderRepositoryImpl package")
+		return nil, errors.New("bus type not found")
+	}
+	busType.BusTypeCode = code
+	busType.ProviderID = providerID
+	busType.SeatLayoutID = layoutID
+	result = pr.DB.Create(busType)
+	if result.Error != nil {
+		log.Println("Unable to assign the seat layout, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return busType, nil
+}
+
+// Ledger implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) Ledger() interfaces.LedgerRepository {
+	return &LedgerRepositoryImpl{DB: pr.DB}
+}
+
+// Settlements implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) Settlements() interfaces.SettlementRepository {
+	return &SettlementRepositoryImpl{DB: pr.DB}
+}
+
 // NewProviderRepository is used to instatiate Provider Repository
 func NewProviderRepository(db *gorm.DB) interfaces.ProviderRepository {
 	return &ProviderRepositoryImpl{
 		DB: db,
 	}
 }
+
+// FindSubStationByID implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindSubStationByID(id int) (*entities.SubStation, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	station := &entities.SubStation{}
+	result := pr.DB.Where("id=?", id).First(station)
+	if result.Error != nil {
+		log.Println("Sub station doesn't exist")
+		return nil, result.Error
+	}
+	return station, nil
+}
+
+// FindScheduleByID implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindScheduleByID(id int) (*entities.Schedule, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	schedule := &entities.Schedule{}
+	result := pr.DB.Where("schedule_id=?", id).First(schedule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return schedule, nil
+}
+
+// FindScheduleStops implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var stops []*entities.ScheduleStop
+	result := pr.DB.Where("schedule_id=?", scheduleID).Order("sequence").Find(&stops)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return stops, nil
+}
+
+// AddBoardingPoint implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) AddBoardingPoint(point *entities.BoardingPoint) (*entities.BoardingPoint, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	result := pr.DB.Create(point)
+	if result.Error != nil {
+		log.Println("Unable to add the boarding point, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return point, nil
+}
+
+// FindBoardingPointByID implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindBoardingPointByID(id int) (*entities.BoardingPoint, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	point := &entities.BoardingPoint{}
+	result := pr.DB.Where("id=?", id).First(point)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return point, nil
+}
+
+// FindBoardingPoints implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindBoardingPoints(busID uint) ([]*entities.BoardingPoint, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var points []*entities.BoardingPoint
+	result := pr.DB.Where("bus_id=?", busID).Order("id").Find(&points)
+	if result.Error != nil {
+		log.Println("Unable to fetch the boarding points, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return points, nil
+}
+
+// DeleteBoardingPoint implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) DeleteBoardingPoint(id int) error {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	result := pr.DB.Delete(&entities.BoardingPoint{}, id)
+	if result.Error != nil {
+		log.Println("Unable to remove the boarding point, ProviderRepositoryImpl package")
+		return result.Error
+	}
+	return nil
+}
+
+// SaveCancellationPolicy implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) SaveCancellationPolicy(policy *entities.CancellationPolicy) (*entities.CancellationPolicy, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	existing := &entities.CancellationPolicy{}
+	if err := pr.DB.Where("provider_id=? AND bus_id=?", policy.ProviderID, policy.BusID).First(existing).Error; err == nil {
+		policy.ID = existing.ID
+		policy.CreatedAt = existing.CreatedAt
+	}
+	result := pr.DB.Save(policy)
+	if result.Error != nil {
+		log.Println("Unable to save the cancellation policy, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return policy, nil
+}
+
+// FindCancellationPolicies implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindCancellationPolicies(providerID uint) ([]*entities.CancellationPolicy, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var policies []*entities.CancellationPolicy
+	result := pr.DB.Where("provider_id=?", providerID).Order("bus_id").Find(&policies)
+	if result.Error != nil {
+		log.Println("Unable to fetch the cancellation policies, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return policies, nil
+}
+
+// FindBookingByID implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindBookingByID(id int) (*entities.Booking, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	booking := &entities.Booking{}
+	result := pr.DB.Where("booking_id=?", id).First(booking)
+	if result.Error != nil {
+		log.Println("Unable to find the booking, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return booking, nil
+}
+
+// FindBookingItems implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindBookingItems(bookingIDs []uint) ([]*entities.BookingItem, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	items := []*entities.BookingItem{}
+	if len(bookingIDs) == 0 {
+		return items, nil
+	}
+	result := pr.DB.Where("booking_id IN ?", bookingIDs).Order("id").Find(&items)
+	if result.Error != nil {
+		log.Println("Unable to fetch the booking items, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return items, nil
+}
+
+// FindTripBookings implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindTripBookings(busID uint, day string) ([]*entities.Booking, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var bookings []*entities.Booking
+	result := pr.DB.Where("bus_id=? AND booking_date=? AND status=?", busID, day, "Success").Order("booking_id").Find(&bookings)
+	if result.Error != nil {
+		log.Println("Unable to fetch the bookings of the trip, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return bookings, nil
+}
+
+// MarkBoarded implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) MarkBoarded(itemID uint, at time.Time) (bool, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return false, errors.New("error connecting database")
+	}
+	// the condition makes two crew members scanning the same ticket board it only once
+	result := pr.DB.Model(&entities.BookingItem{}).Where("id=? AND status=? AND boarded_at IS NULL", itemID, "Booked").Update("boarded_at", at)
+	if result.Error != nil {
+		log.Println("Unable to mark the seat as boarded, ProviderRepositoryImpl package")
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// FindPassengers implements interfaces.ProviderRepository.
+func (pr *ProviderRepositoryImpl) FindPassengers(ids []uint) ([]*entities.PassengerInfo, error) {
+	if pr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	passengers := []*entities.PassengerInfo{}
+	if len(ids) == 0 {
+		return passengers, nil
+	}
+	result := pr.DB.Where("passenger_id IN ?", ids).Find(&passengers)
+	if result.Error != nil {
+		log.Println("Unable to fetch the passengers, ProviderRepositoryImpl package")
+		return nil, result.Error
+	}
+	return passengers, nil
+}
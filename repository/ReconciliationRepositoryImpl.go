@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"errors"
+	"gobus/entities"
+	"gobus/repository/interfaces"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReconciliationRepositoryImpl struct is used to define the payment reconciliation Repository Implementation.
+type ReconciliationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// WithTx implements interfaces.ReconciliationRepository.
+func (rr *ReconciliationRepositoryImpl) WithTx(fn func(tx interfaces.ReconciliationRepository) error) error {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	return rr.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&ReconciliationRepositoryImpl{DB: tx})
+	})
+}
+
+// FindPaymentOrders implements interfaces.ReconciliationRepository, it lists the orders raised since the time, oldest first.
+func (rr *ReconciliationRepositoryImpl) FindPaymentOrders(since time.Time) ([]*entities.PaymentOrder, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var orders []*entities.PaymentOrder
+	if err := rr.DB.Where("created_at>=?", since).Order("created_at").Find(&orders).Error; err != nil {
+		log.Println("Unable to fetch the payment orders, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return orders, nil
+}
+
+// FindBookings implements interfaces.ReconciliationRepository.
+func (rr *ReconciliationRepositoryImpl) FindBookings(ids []uint) ([]*entities.Booking, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var bookings []*entities.Booking
+	if len(ids) == 0 {
+		return bookings, nil
+	}
+	if err := rr.DB.Where("booking_id IN ?", ids).Find(&bookings).Error; err != nil {
+		log.Println("Unable to fetch the bookings, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// FindOrderPayments implements interfaces.ReconciliationRepository.
+func (rr *ReconciliationRepositoryImpl) FindOrderPayments(orderIDs []string) ([]*entities.RazorPay, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var payments []*entities.RazorPay
+	if len(orderIDs) == 0 {
+		return payments, nil
+	}
+	if err := rr.DB.Where("razor_pay_order_id IN ?", orderIDs).Find(&payments).Error; err != nil {
+		log.Println("Unable to fetch the payments of the orders, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return payments, nil
+}
+
+// FindConfirmedBookings implements interfaces.ReconciliationRepository, it lists the bookings made since the time that stand confirmed, oldest first.
+func (rr *ReconciliationRepositoryImpl) FindConfirmedBookings(since time.Time) ([]*entities.Booking, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var bookings []*entities.Booking
+	if err := rr.DB.Where("status=? AND created_at>=?", "Success", since).Order("created_at").Find(&bookings).Error; err != nil {
+		log.Println("Unable to fetch the confirmed bookings, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// FindBookingOrders implements interfaces.ReconciliationRepository.
+func (rr *ReconciliationRepositoryImpl) FindBookingOrders(bookingIDs []uint) ([]*entities.PaymentOrder, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var orders []*entities.PaymentOrder
+	if len(bookingIDs) == 0 {
+		return orders, nil
+	}
+	if err := rr.DB.Where("booking_id IN ?", bookingIDs).Order("created_at").Find(&orders).Error; err != nil {
+		log.Println("Unable to fetch the orders of the bookings, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return orders, nil
+}
+
+// FindBookingPayments implements interfaces.ReconciliationRepository.
+func (rr *ReconciliationRepositoryImpl) FindBookingPayments(bookingIDs []uint) ([]*entities.RazorPay, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var payments []*entities.RazorPay
+	if len(bookingIDs) == 0 {
+		return payments, nil
+	}
+	if err := rr.DB.Where("book_id IN ?", bookingIDs).Find(&payments).Error; err != nil {
+		log.Println("Unable to fetch the payments of the bookings, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return payments, nil
+}
+
+// FindEntriesFrom implements interfaces.ReconciliationRepository, it lists the ledger entries posted since the time that take money out of the account, with their lines, oldest first.
+func (rr *ReconciliationRepositoryImpl) FindEntriesFrom(account string, since time.Time) ([]*entities.LedgerEntry, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var entries []*entities.LedgerEntry
+	from := rr.DB.Model(&entities.LedgerLine{}).
+		Joins("JOIN ledger_accounts ON ledger_accounts.id = ledger_lines.account_id").
+		Where("ledger_accounts.code=? AND ledger_lines.amount<0", account).
+		Select("ledger_lines.entry_id")
+	if err := rr.DB.Preload("Lines").Where("created_at>=? AND id IN (?)", since, from).Order("id").Find(&entries).Error; err != nil {
+		log.Println("Unable to fetch the ledger entries, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindTopups implements interfaces.ReconciliationRepository.
+func (rr *ReconciliationRepositoryImpl) FindTopups(ids []uint) ([]*entities.WalletTopup, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var topups []*entities.WalletTopup
+	if len(ids) == 0 {
+		return topups, nil
+	}
+	if err := rr.DB.Where("id IN ?", ids).Find(&topups).Error; err != nil {
+		log.Println("Unable to fetch the wallet top-ups, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return topups, nil
+}
+
+// AddIssue implements interfaces.ReconciliationRepository, an issue found on an earlier run is not added again.
+func (rr *ReconciliationRepositoryImpl) AddIssue(issue *entities.ReconciliationIssue) (bool, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return false, errors.New("error connecting database")
+	}
+	result := rr.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(issue)
+	if result.Error != nil {
+		log.Println("Unable to add the reconciliation issue, ReconciliationRepositoryImpl package")
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// FindIssues implements interfaces.ReconciliationRepository, an empty status lists every issue, newest first.
+func (rr *ReconciliationRepositoryImpl) FindIssues(status string) ([]*entities.ReconciliationIssue, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var issues []*entities.ReconciliationIssue
+	query := rr.DB.Order("id DESC")
+	if status != "" {
+		query = query.Where("status=?", status)
+	}
+	if err := query.Find(&issues).Error; err != nil {
+		log.Println("Unable to fetch the reconciliation issues, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return issues, nil
+}
+
+// FindIssueForUpdate implements interfaces.ReconciliationRepository, the issue stays locked until the transaction ends.
+func (rr *ReconciliationRepositoryImpl) FindIssueForUpdate(id uint) (*entities.ReconciliationIssue, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	issue := &entities.ReconciliationIssue{}
+	if err := rr.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id=?", id).First(issue).Error; err != nil {
+		log.Println("Unable to fetch the reconciliation issue, ReconciliationRepositoryImpl package")
+		return nil, err
+	}
+	return issue, nil
+}
+
+// UpdateIssue implements interfaces.ReconciliationRepository.
+func (rr *ReconciliationRepositoryImpl) UpdateIssue(issue *entities.ReconciliationIssue) error {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := rr.DB.Save(issue).Error; err != nil {
+		log.Println("Unable to update the reconciliation issue, ReconciliationRepositoryImpl package")
+		return err
+	}
+	return nil
+}
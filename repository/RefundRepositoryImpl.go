@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"errors"
+	"gobus/entities"
+	"gobus/repository/interfaces"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RefundRepositoryImpl struct is used to define the Repository Implementation of the refunds sent back through the payment gateway.
+type RefundRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// WithTx implements interfaces.RefundRepository.
+func (rr *RefundRepositoryImpl) WithTx(fn func(tx interfaces.RefundRepository) error) error {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	return rr.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&RefundRepositoryImpl{DB: tx})
+	})
+}
+
+// Ledger implements interfaces.RefundRepository.
+func (rr *RefundRepositoryImpl) Ledger() interfaces.LedgerRepository {
+	return &LedgerRepositoryImpl{DB: rr.DB}
+}
+
+// FindBookingPayment implements interfaces.RefundRepository, a booking not paid through the gateway, or whose payment was credited to the wallet or fully refunded, gives a nil payment.
+func (rr *RefundRepositoryImpl) FindBookingPayment(bookingID uint) (*entities.RazorPay, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	razor := &entities.RazorPay{}
+	result := rr.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("book_id=? AND status IN ?", bookingID, []string{"", "Captured", "Partially Refunded"}).Limit(1).Find(razor)
+	if result.Error != nil {
+		log.Println("Unable to fetch the payment of the booking, RefundRepositoryImpl package")
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return razor, nil
+}
+
+// RefundedFrom implements interfaces.RefundRepository, it sums the refunds of the payment in paisa, a refund still being tried or left for review counts as it may yet go through.
+func (rr *RefundRepositoryImpl) RefundedFrom(paymentID string) (int64, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return 0, errors.New("error connecting database")
+	}
+	var refunded int64
+	err := rr.DB.Model(&entities.Refund{}).Where("payment_id=?", paymentID).Select("COALESCE(SUM(amount), 0)").Scan(&refunded).Error
+	if err != nil {
+		log.Println("Unable to sum the refunds of the payment, RefundRepositoryImpl package")
+		return 0, err
+	}
+	return refunded, nil
+}
+
+// AddRefund implements interfaces.RefundRepository.
+func (rr *RefundRepositoryImpl) AddRefund(refund *entities.Refund) error {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := rr.DB.Create(refund).Error; err != nil {
+		log.Println("Unable to add the refund, RefundRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// UpdateRefund implements interfaces.RefundRepository.
+func (rr *RefundRepositoryImpl) UpdateRefund(refund *entities.Refund) error {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := rr.DB.Save(refund).Error; err != nil {
+		log.Println("Unable to update the refund, RefundRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// FindRefundForUpdate implements interfaces.RefundRepository, the refund stays locked until the transaction ends.
+func (rr *RefundRepositoryImpl) FindRefundForUpdate(id uint) (*entities.Refund, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	refund := &entities.Refund{}
+	if err := rr.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id=?", id).First(refund).Error; err != nil {
+		log.Println("Unable to fetch the refund, RefundRepositoryImpl package")
+		return nil, err
+	}
+	return refund, nil
+}
+
+// FindRefundByGatewayID implements interfaces.RefundRepository, a refund the gateway id is not known for gives a nil refund.
+func (rr *RefundRepositoryImpl) FindRefundByGatewayID(gatewayID string) (*entities.Refund, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	refund := &entities.Refund{}
+	result := rr.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("gateway_id=?", gatewayID).Limit(1).Find(refund)
+	if result.Error != nil {
+		log.Println("Unable to fetch the refund, RefundRepositoryImpl package")
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return refund, nil
+}
+
+// FindUnissuedRefunds implements interfaces.RefundRepository, it lists the refunds the gateway has not taken yet, oldest first, along with the ones left sending for a quarter of an hour.
+func (rr *RefundRepositoryImpl) FindUnissuedRefunds() ([]*entities.Refund, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var refunds []*entities.Refund
+	if err := rr.DB.Where("status IN ? OR (status=? AND updated_at<?)", []string{"Pending", "Failed"}, "Sending", time.Now().Add(-15*time.Minute)).Order("id").Find(&refunds).Error; err != nil {
+		log.Println("Unable to fetch the refunds to issue, RefundRepositoryImpl package")
+		return nil, err
+	}
+	return refunds, nil
+}
+
+// FindUserRefunds implements interfaces.RefundRepository.
+func (rr *RefundRepositoryImpl) FindUserRefunds(userID uint) ([]*entities.Refund, error) {
+	if rr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var refunds []*entities.Refund
+	if err := rr.DB.Where("user_id=?", userID).Order("id desc").Find(&refunds).Error; err != nil {
+		log.Println("Unable to fetch the refunds of the user, RefundRepositoryImpl package")
+		return nil, err
+	}
+	return refunds, nil
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"errors"
+	"gobus/entities"
+	"gobus/repository/interfaces"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SettlementRepositoryImpl struct is used to define the provider settlement Repository Implementation.
+type SettlementRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// WithTx implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) WithTx(fn func(tx interfaces.SettlementRepository) error) error {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	return sr.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&SettlementRepositoryImpl{DB: tx})
+	})
+}
+
+// Ledger implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) Ledger() interfaces.LedgerRepository {
+	return &LedgerRepositoryImpl{DB: sr.DB}
+}
+
+// FindCommission implements interfaces.SettlementRepository, a provider without its own commission gives a nil commission.
+func (sr *SettlementRepositoryImpl) FindCommission(providerID uint) (*entities.ProviderCommission, error) {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	commission := &entities.ProviderCommission{}
+	result := sr.DB.Where("provider_id=?", providerID).Limit(1).Find(commission)
+	if result.Error != nil {
+		log.Println("Unable to fetch the commission, SettlementRepositoryImpl package")
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return commission, nil
+}
+
+// SaveCommission implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) SaveCommission(commission *entities.ProviderCommission) error {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := sr.DB.Save(commission).Error; err != nil {
+		log.Println("Unable to save the commission, SettlementRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// FindBookings implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) FindBookings(ids []uint) ([]*entities.Booking, error) {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var bookings []*entities.Booking
+	if len(ids) == 0 {
+		return bookings, nil
+	}
+	if err := sr.DB.Where("booking_id IN ?", ids).Find(&bookings).Error; err != nil {
+		log.Println("Unable to fetch the bookings, SettlementRepositoryImpl package")
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// AddSettlement implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) AddSettlement(settlement *entities.Settlement) error {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := sr.DB.Create(settlement).Error; err != nil {
+		log.Println("Unable to add the settlement, SettlementRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// FindUnbatched implements interfaces.SettlementRepository, the settlements stay locked until the transaction ends.
+func (sr *SettlementRepositoryImpl) FindUnbatched(providerID uint) ([]*entities.Settlement, error) {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var settlements []*entities.Settlement
+	err := sr.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("provider_id=? AND payout_id IS NULL", providerID).Order("id").Find(&settlements).Error
+	if err != nil {
+		log.Println("Unable to fetch the settlements, SettlementRepositoryImpl package")
+		return nil, err
+	}
+	return settlements, nil
+}
+
+// UnbatchedProviders implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) UnbatchedProviders() ([]uint, error) {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var ids []uint
+	if err := sr.DB.Model(&entities.Settlement{}).Where("payout_id IS NULL").Distinct().Order("provider_id").Pluck("provider_id", &ids).Error; err != nil {
+		log.Println("Unable to fetch the providers to pay out, SettlementRepositoryImpl package")
+		return nil, err
+	}
+	return ids, nil
+}
+
+// AddPayout implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) AddPayout(payout *entities.Payout) error {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := sr.DB.Create(payout).Error; err != nil {
+		log.Println("Unable to add the payout, SettlementRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// AssignPayout implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) AssignPayout(settlementIDs []uint, payoutID uint) error {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := sr.DB.Model(&entities.Settlement{}).Where("id IN ?", settlementIDs).Update("payout_id", payoutID).Error; err != nil {
+		log.Println("Unable to batch the settlements, SettlementRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// ReleaseSettlements implements interfaces.SettlementRepository, the settlements of the payout go into the next batch.
+func (sr *SettlementRepositoryImpl) ReleaseSettlements(payoutID uint) error {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := sr.DB.Model(&entities.Settlement{}).Where("payout_id=?", payoutID).Update("payout_id", nil).Error; err != nil {
+		log.Println("Unable to release the settlements, SettlementRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// FindPayout implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) FindPayout(id uint) (*entities.Payout, error) {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	payout := &entities.Payout{}
+	if err := sr.DB.Where("id=?", id).First(payout).Error; err != nil {
+		log.Println("Unable to fetch the payout, SettlementRepositoryImpl package")
+		return nil, err
+	}
+	return payout, nil
+}
+
+// FindPayoutForUpdate implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) FindPayoutForUpdate(id uint) (*entities.Payout, error) {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	payout := &entities.Payout{}
+	if err := sr.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id=?", id).First(payout).Error; err != nil {
+		log.Println("Unable to fetch the payout, SettlementRepositoryImpl package")
+		return nil, err
+	}
+	return payout, nil
+}
+
+// UpdatePayout implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) UpdatePayout(payout *entities.Payout) error {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := sr.DB.Save(payout).Error; err != nil {
+		log.Println("Unable to update the payout, SettlementRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// FindPayouts implements interfaces.SettlementRepository, a zero provider lists the payouts of every provider and an empty status those of every status.
+func (sr *SettlementRepositoryImpl) FindPayouts(providerID uint, status string) ([]*entities.Payout, error) {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	query := sr.DB.Order("id DESC")
+	if providerID != 0 {
+		query = query.Where("provider_id=?", providerID)
+	}
+	if status != "" {
+		query = query.Where("status=?", status)
+	}
+	var payouts []*entities.Payout
+	if err := query.Find(&payouts).Error; err != nil {
+		log.Println("Unable to fetch the payouts, SettlementRepositoryImpl package")
+		return nil, err
+	}
+	return payouts, nil
+}
+
+// FindPayoutSettlements implements interfaces.SettlementRepository.
+func (sr *SettlementRepositoryImpl) FindPayoutSettlements(payoutID uint) ([]*entities.Settlement, error) {
+	if sr.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var settlements []*entities.Settlement
+	if err := sr.DB.Where("payout_id=?", payoutID).Order("id").Find(&settlements).Error; err != nil {
+		log.Println("Unable to fetch the settlements of the payout, SettlementRepositoryImpl package")
+		return nil, err
+	}
+	return settlements, nil
+}
+
+// NewSettlementRepository function is used to instantiate the provider settlement repository.
+func NewSettlementRepository(db *gorm.DB) interfaces.SettlementRepository {
+	return &SettlementRepositoryImpl{DB: db}
+}
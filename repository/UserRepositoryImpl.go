@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"gobus/entities"
+	"gobus/repository/interfaces"
 	"log"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository interface {
@@ -21,7 +23,13 @@ type UserRepository interface {
 	FindCoupon() ([]*entities.Coupons, error)
 	FindCouponByID(id int) (*entities.Coupons, error)
 	GetBusTypeDetails(code string) (*entities.BusType, error)
+	GetBusTypeForProvider(code string, providerID uint) (*entities.BusType, error)
 	GetChart(busid int, day time.Time) (*entities.BusSchedule, error)
+	GetChartsForDay(busIDs []uint, day time.Time) ([]*entities.BusSchedule, error)
+	FindConnections() ([]*entities.BusScheduleCombo, error)
+	FindSegmentBuses(depart string, arrival string) ([]*entities.BusScheduleCombo, error)
+	GetSchedule(id int) (*entities.Schedule, error)
+	GetScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error)
 	GetSeatLayout(id int) (*entities.BusSeatLayout, error)
 	GetBusInfo(id int) (*entities.Buses, error)
 	GetBaseFare(scheduleID int) (*entities.BaseFare, error)
@@ -29,14 +37,42 @@ type UserRepository interface {
 	ViewBookings(email string) ([]*entities.Booking, error)
 	CancelBooking(booking *entities.Booking) (*entities.Booking, error)
 	FindBookingByID(bookID int) (*entities.Booking, error)
+	FindBookingByPNR(pnr string) (*entities.Booking, error)
+	FindBookingsWithoutPNR() ([]*entities.Booking, error)
+	SetBookingPNR(bookingID uint, pnr string) error
+	SetRefundTo(userID uint, refundTo string) error
 	UpdateUser(user *entities.User) (*entities.User, error)
 	GetProviderInfo(providerID int) (*entities.ServiceProvider, error)
 	UpdateProvider(provider *entities.ServiceProvider) (*entities.ServiceProvider, error)
 	GetUserInfo(userID int) (*entities.User, error)
 	UpdateBooking(booking *entities.Booking) (*entities.Booking, error)
 	PaymentSuccess(razor *entities.RazorPay) error
+	FindPayment(paymentID string) (*entities.RazorPay, error)
+	AddSuspiciousPayment(attempt *entities.SuspiciousPayment) error
+	AddPaymentOrder(order *entities.PaymentOrder) error
+	SavePayment(razor *entities.RazorPay) error
+	AddPaymentEvent(event *entities.PaymentEvent) (bool, error)
+	UpdatePaymentEvent(event *entities.PaymentEvent) error
+	AddTopup(topup *entities.WalletTopup) error
+	FindTopup(orderID string) (*entities.WalletTopup, error)
+	FindTopupForUpdate(orderID string) (*entities.WalletTopup, error)
+	UpdateTopup(topup *entities.WalletTopup) error
 	GetParentLocation(name string) (*entities.SubStation, error)
 	GetSubStationDetails(parent string) ([]*entities.SubStation, error)
+	GetBoardingPoint(id int) (*entities.BoardingPoint, error)
+	FindWaitlisted(busID uint, day string) ([]*entities.Booking, error)
+	AddBookingItems(items []*entities.BookingItem) error
+	FindBookingItems(bookingIDs []uint) ([]*entities.BookingItem, error)
+	UpdateBookingItem(item *entities.BookingItem) error
+	GetCancellationPolicy(providerID uint, busID uint) (*entities.CancellationPolicy, error)
+	GetBoardingPointsAt(stop string, busID uint) ([]*entities.BoardingPoint, error)
+	FindExpiredHolds(now time.Time) ([]*entities.Booking, error)
+	WithTx(fn func(tx interfaces.UserRepository) error) error
+	GetChartForUpdate(busid int, day time.Time) (*entities.BusSchedule, error)
+	GetUserInfoForUpdate(userID int) (*entities.User, error)
+	GetProviderInfoForUpdate(providerID int) (*entities.ServiceProvider, error)
+	Ledger() interfaces.LedgerRepository
+	Refunds() interfaces.RefundRepository
 }
 
 // UserRepositoryImpl struct is used to define User Repository Implementation.
@@ -44,6 +80,83 @@ type UserRepositoryImpl struct {
 	DB *gorm.DB
 }
 
+// WithTx implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) WithTx(fn func(tx interfaces.UserRepository) error) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	return ur.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&UserRepositoryImpl{DB: tx})
+	})
+}
+
+// Ledger implements interfaces.UserRepository, the ledger shares the transaction of the repository.
+func (ur *UserRepositoryImpl) Ledger() interfaces.LedgerRepository {
+	return &LedgerRepositoryImpl{DB: ur.DB}
+}
+
+// Refunds implements interfaces.UserRepository, the refunds share the transaction of the repository.
+func (ur *UserRepositoryImpl) Refunds() interfaces.RefundRepository {
+	return &RefundRepositoryImpl{DB: ur.DB}
+}
+
+// GetChartForUpdate implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetChartForUpdate(busid int, day time.Time) (*entities.BusSchedule, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	buschart := &entities.BusSchedule{}
+	result := ur.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("bus_id= ? AND day=?", busid, day).First(buschart)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return buschart, nil
+}
+
+// GetUserInfoForUpdate implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetUserInfoForUpdate(userID int) (*entities.User, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	user := &entities.User{}
+	result := ur.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id=?", userID).First(user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return user, nil
+}
+
+// GetProviderInfoForUpdate implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetProviderInfoForUpdate(providerID int) (*entities.ServiceProvider, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	provider := &entities.ServiceProvider{}
+	result := ur.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("provider_id=?", providerID).First(provider)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return provider, nil
+}
+
+// FindExpiredHolds implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) FindExpiredHolds(now time.Time) ([]*entities.Booking, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	bookings := []*entities.Booking{}
+	result := ur.DB.Where("status=? AND hold_expires_at<?", "Awaiting Payment", now).Find(&bookings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return bookings, nil
+}
+
 // GetSubStationDetails implements interfaces.UserRepository.
 func (ur *UserRepositoryImpl) GetSubStationDetails(parent string) ([]*entities.SubStation, error) {
 	if ur.DB == nil {
@@ -58,6 +171,105 @@ func (ur *UserRepositoryImpl) GetSubStationDetails(parent string) ([]*entities.S
 	return stations, nil
 }
 
+// FindWaitlisted implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) FindWaitlisted(busID uint, day string) ([]*entities.Booking, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	bookings := []*entities.Booking{}
+	result := ur.DB.Where("bus_id=? AND booking_date=? AND status=?", busID, day, "Waitlisted").Order("booking_id").Find(&bookings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return bookings, nil
+}
+
+// AddBookingItems implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) AddBookingItems(items []*entities.BookingItem) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return ur.DB.Create(&items).Error
+}
+
+// FindBookingItems implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) FindBookingItems(bookingIDs []uint) ([]*entities.BookingItem, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	items := []*entities.BookingItem{}
+	if len(bookingIDs) == 0 {
+		return items, nil
+	}
+	result := ur.DB.Where("booking_id IN ?", bookingIDs).Order("id").Find(&items)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return items, nil
+}
+
+// UpdateBookingItem implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) UpdateBookingItem(item *entities.BookingItem) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	return ur.DB.Save(item).Error
+}
+
+// GetCancellationPolicy implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetCancellationPolicy(providerID uint, busID uint) (*entities.CancellationPolicy, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	policy := &entities.CancellationPolicy{}
+	// the policy of the bus wins over the provider wide one, which has no bus
+	result := ur.DB.Where("provider_id=? AND bus_id IN ?", providerID, []uint{0, busID}).Order("bus_id DESC").First(policy)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return policy, nil
+}
+
+// GetBoardingPoint implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetBoardingPoint(id int) (*entities.BoardingPoint, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	point := &entities.BoardingPoint{}
+	result := ur.DB.Where("id=?", id).First(point)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return point, nil
+}
+
+// GetBoardingPointsAt implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetBoardingPointsAt(stop string, busID uint) ([]*entities.BoardingPoint, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	points := []*entities.BoardingPoint{}
+	query := ur.DB.Where("stop_name=?", stop)
+	if busID != 0 {
+		query = query.Where("bus_id=?", busID)
+	}
+	result := query.Order("bus_id, time_offset").Find(&points)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return points, nil
+}
+
 // PaymentSuccess implements interfaces.UserRepository.
 func (ur *UserRepositoryImpl) PaymentSuccess(razor *entities.RazorPay) error {
 	if ur.DB == nil {
@@ -71,6 +283,144 @@ func (ur *UserRepositoryImpl) PaymentSuccess(razor *entities.RazorPay) error {
 	return nil
 }
 
+// FindPayment implements interfaces.UserRepository, a payment not stored before gives a nil payment and a stored one stays locked until the transaction ends.
+func (ur *UserRepositoryImpl) FindPayment(paymentID string) (*entities.RazorPay, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	razor := &entities.RazorPay{}
+	result := ur.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("razor_payment_id=?", paymentID).Limit(1).Find(razor)
+	if result.Error != nil {
+		log.Println("Unable to fetch the payment, UserRepositoryImpl package")
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return razor, nil
+}
+
+// AddPaymentOrder implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) AddPaymentOrder(order *entities.PaymentOrder) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := ur.DB.Create(order).Error; err != nil {
+		log.Println("Unable to add the payment order, UserRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// AddSuspiciousPayment implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) AddSuspiciousPayment(attempt *entities.SuspiciousPayment) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := ur.DB.Create(attempt).Error; err != nil {
+		log.Println("Unable to record the suspicious payment, UserRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// SavePayment implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) SavePayment(razor *entities.RazorPay) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := ur.DB.Save(razor).Error; err != nil {
+		log.Println("Unable to save the payment, UserRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// AddPaymentEvent implements interfaces.UserRepository, an event stored before is left as it is and reported as not added.
+func (ur *UserRepositoryImpl) AddPaymentEvent(event *entities.PaymentEvent) (bool, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return false, errors.New("error connecting database")
+	}
+	result := ur.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+	if result.Error != nil {
+		log.Println("Unable to store the payment event, UserRepositoryImpl package")
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// UpdatePaymentEvent implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) UpdatePaymentEvent(event *entities.PaymentEvent) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := ur.DB.Save(event).Error; err != nil {
+		log.Println("Unable to update the payment event, UserRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// AddTopup implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) AddTopup(topup *entities.WalletTopup) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := ur.DB.Create(topup).Error; err != nil {
+		log.Println("Unable to add the wallet top-up, UserRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
+// FindTopup implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) FindTopup(orderID string) (*entities.WalletTopup, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	topup := &entities.WalletTopup{}
+	result := ur.DB.Where("order_id=?", orderID).First(topup)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return topup, nil
+}
+
+// FindTopupForUpdate implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) FindTopupForUpdate(orderID string) (*entities.WalletTopup, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	topup := &entities.WalletTopup{}
+	result := ur.DB.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id=?", orderID).First(topup)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return topup, nil
+}
+
+// UpdateTopup implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) UpdateTopup(topup *entities.WalletTopup) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	if err := ur.DB.Save(topup).Error; err != nil {
+		log.Println("Unable to update the wallet top-up, UserRepositoryImpl package")
+		return err
+	}
+	return nil
+}
+
 // UpdateBooking implements interfaces.UserRepository.
 func (ur *UserRepositoryImpl) UpdateBooking(booking *entities.Booking) (*entities.Booking, error) {
 	if ur.DB == nil {
@@ -152,6 +502,54 @@ func (ur *UserRepositoryImpl) FindBookingByID(bookID int) (*entities.Booking, er
 	return booking, nil
 }
 
+// FindBookingByPNR implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) FindBookingByPNR(pnr string) (*entities.Booking, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	booking := &entities.Booking{}
+	result := ur.DB.Where("pnr=?", pnr).First(booking)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return booking, nil
+}
+
+// FindBookingsWithoutPNR implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) FindBookingsWithoutPNR() ([]*entities.Booking, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var bookings []*entities.Booking
+	result := ur.DB.Where("pnr IS NULL OR pnr=''").Order("booking_id").Find(&bookings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return bookings, nil
+}
+
+// SetBookingPNR implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) SetBookingPNR(bookingID uint, pnr string) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	// only the PNR column is written so a booking changing at the same time keeps its other fields
+	return ur.DB.Model(&entities.Booking{}).Where("booking_id=? AND (pnr IS NULL OR pnr='')", bookingID).Update("pnr", pnr).Error
+}
+
+// SetRefundTo implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) SetRefundTo(userID uint, refundTo string) error {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return errors.New("error connecting database")
+	}
+	// only the preference is written so the wallet balance cached on the user is left to the ledger
+	return ur.DB.Model(&entities.User{}).Where("id=?", userID).Update("refund_to", refundTo).Error
+}
+
 // CancelBooking implements interfaces.UserRepository.
 func (ur *UserRepositoryImpl) CancelBooking(booking *entities.Booking) (*entities.Booking, error) {
 	if ur.DB == nil {
@@ -249,6 +647,23 @@ func (ur *UserRepositoryImpl) GetChart(busid int, day time.Time) (*entities.BusS
 	return buschart, nil
 }
 
+// GetChartsForDay implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetChartsForDay(busIDs []uint, day time.Time) ([]*entities.BusSchedule, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var charts []*entities.BusSchedule
+	if len(busIDs) == 0 {
+		return charts, nil
+	}
+	result := ur.DB.Where("bus_id IN ? AND day=?", busIDs, day).Find(&charts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return charts, nil
+}
+
 // GetBusTypeDetails implements interfaces.UserRepository.
 func (ur *UserRepositoryImpl) GetBusTypeDetails(code string) (*entities.BusType, error) {
 	if ur.DB == nil {
@@ -263,6 +678,20 @@ func (ur *UserRepositoryImpl) GetBusTypeDetails(code string) (*entities.BusType,
 	return bus, nil
 }
 
+// GetBusTypeForProvider implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetBusTypeForProvider(code string, providerID uint) (*entities.BusType, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	bus := &entities.BusType{}
+	result := ur.DB.Where("bus_type_code=? AND provider_id IN ?", code, []uint{providerID, 0}).Order("provider_id desc").First(bus)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return bus, nil
+}
+
 // FindCoupon implements interfaces.UserRepository.
 func (ur *UserRepositoryImpl) FindCoupon() ([]*entities.Coupons, error) {
 	if ur.DB == nil {
@@ -371,6 +800,67 @@ func (ur *UserRepositoryImpl) FindBus(depart string, arrival string) ([]*entitie
 	return buses, nil
 }
 
+// FindConnections implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) FindConnections() ([]*entities.BusScheduleCombo, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB in FindConnections method, UserRepositoryImpl package")
+		return nil, errors.New("error Connecting Database")
+	}
+	query := "SELECT * FROM buses b JOIN schedules s ON b.schedule_id = s.schedule_id"
+	buses := []*entities.BusScheduleCombo{}
+	if err := ur.DB.Raw(query).Scan(&buses).Error; err != nil {
+		log.Println("Unable to fetch the bus schedules, UserRepositoryImpl package")
+		return nil, err
+	}
+	return buses, nil
+}
+
+// FindSegmentBuses implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) FindSegmentBuses(depart string, arrival string) ([]*entities.BusScheduleCombo, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB in FindSegmentBuses method, UserRepositoryImpl package")
+		return nil, errors.New("error Connecting Database")
+	}
+	query := "SELECT b.*, s.* FROM buses b JOIN schedules s ON b.schedule_id = s.schedule_id " +
+		"JOIN schedule_stops f ON f.schedule_id = s.schedule_id AND f.station_name = ? " +
+		"JOIN schedule_stops t ON t.schedule_id = s.schedule_id AND t.station_name = ? " +
+		"WHERE f.sequence < t.sequence"
+	buses := []*entities.BusScheduleCombo{}
+	if err := ur.DB.Raw(query, depart, arrival).Scan(&buses).Error; err != nil {
+		log.Println("Unable to fetch the buses stopping on the route, UserRepositoryImpl package")
+		return nil, err
+	}
+	return buses, nil
+}
+
+// GetSchedule implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetSchedule(id int) (*entities.Schedule, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	schedule := &entities.Schedule{}
+	result := ur.DB.Where("schedule_id=?", id).First(schedule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return schedule, nil
+}
+
+// GetScheduleStops implements interfaces.UserRepository.
+func (ur *UserRepositoryImpl) GetScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error) {
+	if ur.DB == nil {
+		log.Println("Error connecting DB")
+		return nil, errors.New("error connecting database")
+	}
+	var stops []*entities.ScheduleStop
+	result := ur.DB.Where("schedule_id=?", scheduleID).Order("sequence").Find(&stops)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return stops, nil
+}
+
 // GetParentLocation function is used to fetch the parent location based on the sub station name shared.
 func (ur *UserRepositoryImpl) GetParentLocation(name string) (*entities.SubStation, error) {
 	if ur.DB == nil {
@@ -17,7 +17,13 @@ type UserRepository interface {
 	FindCoupon() ([]*entities.Coupons, error)
 	FindCouponByID(id int) (*entities.Coupons, error)
 	GetBusTypeDetails(code string) (*entities.BusType, error)
+	GetBusTypeForProvider(code string, providerID uint) (*entities.BusType, error)
 	GetChart(busid int, day time.Time) (*entities.BusSchedule, error)
+	GetChartsForDay(busIDs []uint, day time.Time) ([]*entities.BusSchedule, error)
+	FindConnections() ([]*entities.BusScheduleCombo, error)
+	FindSegmentBuses(depart string, arrival string) ([]*entities.BusScheduleCombo, error)
+	GetSchedule(id int) (*entities.Schedule, error)
+	GetScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error)
 	GetSeatLayout(id int) (*entities.BusSeatLayout, error)
 	GetBusInfo(id int) (*entities.Buses, error)
 	GetBaseFare(scheduleID int) (*entities.BaseFare, error)
@@ -25,12 +31,40 @@ type UserRepository interface {
 	ViewBookings(email string) ([]*entities.Booking, error)
 	CancelBooking(booking *entities.Booking) (*entities.Booking, error)
 	FindBookingByID(bookID int) (*entities.Booking, error)
+	FindBookingByPNR(pnr string) (*entities.Booking, error)
+	FindBookingsWithoutPNR() ([]*entities.Booking, error)
+	SetBookingPNR(bookingID uint, pnr string) error
+	SetRefundTo(userID uint, refundTo string) error
 	UpdateUser(user *entities.User) (*entities.User, error)
 	GetProviderInfo(providerID int) (*entities.ServiceProvider, error)
 	UpdateProvider(provider *entities.ServiceProvider) (*entities.ServiceProvider, error)
 	GetUserInfo(userID int) (*entities.User, error)
 	UpdateBooking(booking *entities.Booking) (*entities.Booking, error)
 	PaymentSuccess(razor *entities.RazorPay) error
+	FindPayment(paymentID string) (*entities.RazorPay, error)
+	AddSuspiciousPayment(attempt *entities.SuspiciousPayment) error
+	AddPaymentOrder(order *entities.PaymentOrder) error
+	SavePayment(razor *entities.RazorPay) error
+	AddPaymentEvent(event *entities.PaymentEvent) (bool, error)
+	UpdatePaymentEvent(event *entities.PaymentEvent) error
+	AddTopup(topup *entities.WalletTopup) error
+	FindTopup(orderID string) (*entities.WalletTopup, error)
+	FindTopupForUpdate(orderID string) (*entities.WalletTopup, error)
+	UpdateTopup(topup *entities.WalletTopup) error
 	GetParentLocation(name string) (*entities.SubStation, error)
 	GetSubStationDetails(parent string) ([]*entities.SubStation, error)
+	GetBoardingPoint(id int) (*entities.BoardingPoint, error)
+	FindWaitlisted(busID uint, day string) ([]*entities.Booking, error)
+	AddBookingItems(items []*entities.BookingItem) error
+	FindBookingItems(bookingIDs []uint) ([]*entities.BookingItem, error)
+	UpdateBookingItem(item *entities.BookingItem) error
+	GetCancellationPolicy(providerID uint, busID uint) (*entities.CancellationPolicy, error)
+	GetBoardingPointsAt(stop string, busID uint) ([]*entities.BoardingPoint, error)
+	FindExpiredHolds(now time.Time) ([]*entities.Booking, error)
+	WithTx(fn func(tx UserRepository) error) error
+	GetChartForUpdate(busid int, day time.Time) (*entities.BusSchedule, error)
+	GetUserInfoForUpdate(userID int) (*entities.User, error)
+	GetProviderInfoForUpdate(providerID int) (*entities.ServiceProvider, error)
+	Ledger() LedgerRepository
+	Refunds() RefundRepository
 }
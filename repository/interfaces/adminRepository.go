@@ -1,7 +1,6 @@
 package interfaces
 
 import (
-	"gobus/dto"
 	"gobus/entities"
 	"time"
 )
@@ -27,7 +26,7 @@ type AdminRepository interface {
 	EditStation(id int, station *entities.Stations) (*entities.Stations, error)
 	DeleteStation(id int) (*entities.Stations, error)
 	AddStation(station *entities.Stations) (*entities.Stations, error)
-	AddBusSchedule(schedule *dto.BusSchedule) (*entities.BusSchedule, error)
+	AddBusSchedule(chart *entities.BusSchedule) (*entities.BusSchedule, error)
 	AddFareForRoute(baseFare *entities.BaseFare) (*entities.BaseFare, error)
 	ViewAllBookings() ([]*entities.Booking, error)
 	ViewBookingsPerBus(busID int, day string) ([]*entities.Booking, error)
@@ -38,5 +37,19 @@ type AdminRepository interface {
 	UpdateChart(chart *entities.BusSchedule) (*entities.BusSchedule, error)
 	UpdateBooking(booking *entities.Booking) (*entities.Booking, error)
 	ViewBookingsToBeCancelled(busID int, day string) ([]*entities.Booking, error)
+	Ledger() LedgerRepository
+	FindSuspiciousPayments() ([]*entities.SuspiciousPayment, error)
+	Settlements() SettlementRepository
+	Refunds() RefundRepository
+	Reconciliation() ReconciliationRepository
+	FindScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error)
 	GetRouteByBus(scheduleID int) (*entities.Schedule, error)
+	CountSegmentCharts(scheduleID int, from time.Time) (int64, error)
+	GetBusTypeForProvider(code string, providerID uint) (*entities.BusType, error)
+	GetSeatLayout(id int) (*entities.BusSeatLayout, error)
+	SaveRecurrence(recurrence *entities.ScheduleRecurrence) (*entities.ScheduleRecurrence, error)
+	FindRecurrence(busID int) (*entities.ScheduleRecurrence, error)
+	FindAllRecurrences() ([]*entities.ScheduleRecurrence, error)
+	FindChartDays(busID int, from time.Time, to time.Time) ([]time.Time, error)
+	ReplaceScheduleStops(scheduleID int, stops []*entities.ScheduleStop) ([]*entities.ScheduleStop, error)
 }
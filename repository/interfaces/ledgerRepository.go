@@ -0,0 +1,21 @@
+package interfaces
+
+import "gobus/entities"
+
+// LedgerRepository interface is the interface used for the wallet ledger repository
+type LedgerRepository interface {
+	WithTx(fn func(tx LedgerRepository) error) error
+	OpenAccount(account *entities.LedgerAccount) (*entities.LedgerAccount, bool, error)
+	SaveAccountBalance(account *entities.LedgerAccount) error
+	WalletBalance(ownerType string, ownerID uint) (int, error)
+	FindEntryByKey(key string) (*entities.LedgerEntry, error)
+	AddEntry(entry *entities.LedgerEntry) error
+	FindAccounts() ([]*entities.LedgerAccount, error)
+	AccountTotals() (map[uint]int64, error)
+	UnbalancedEntries() ([]uint, error)
+	FindStatement(accountID uint, offset int, limit int) ([]*entities.LedgerEntry, int64, error)
+	BalanceThrough(accountID uint, entryID uint) (int64, error)
+	FindOpenAccounts(ownerType string) ([]*entities.LedgerAccount, error)
+	BookingTotals(accountID uint) (map[uint]int64, error)
+	HeldFor(code string, bookingID uint) (int64, error)
+}
@@ -1,6 +1,9 @@
 package interfaces
 
-import "gobus/entities"
+import (
+	"gobus/entities"
+	"time"
+)
 
 // ProviderRepository interface is the interface used for provider repository
 type ProviderRepository interface {
@@ -24,4 +27,26 @@ type ProviderRepository interface {
 	AddBus(bus *entities.Buses, email string) (*entities.Buses, error)
 	FindBusByNumber(number string) (*entities.Buses, error)
 	AddSubStations(station *entities.SubStation) (*entities.SubStation, error)
+	AddSeatLayout(layout *entities.BusSeatLayout) (*entities.BusSeatLayout, error)
+	FindSeatLayoutByID(id int) (*entities.BusSeatLayout, error)
+	FindSeatLayouts(providerID uint) ([]*entities.BusSeatLayout, error)
+	FindSeatLayoutVersions(parentID uint) ([]*entities.BusSeatLayout, error)
+	FindBusesByType(code string, providerID uint) ([]*entities.Buses, error)
+	AssignSeatLayout(code string, providerID uint, layoutID uint) (*entities.BusType, error)
+	FindSubStationByID(id int) (*entities.SubStation, error)
+	FindScheduleByID(id int) (*entities.Schedule, error)
+	FindScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error)
+	AddBoardingPoint(point *entities.BoardingPoint) (*entities.BoardingPoint, error)
+	FindBoardingPointByID(id int) (*entities.BoardingPoint, error)
+	FindBoardingPoints(busID uint) ([]*entities.BoardingPoint, error)
+	DeleteBoardingPoint(id int) error
+	SaveCancellationPolicy(policy *entities.CancellationPolicy) (*entities.CancellationPolicy, error)
+	FindCancellationPolicies(providerID uint) ([]*entities.CancellationPolicy, error)
+	FindBookingByID(id int) (*entities.Booking, error)
+	FindBookingItems(bookingIDs []uint) ([]*entities.BookingItem, error)
+	FindTripBookings(busID uint, day string) ([]*entities.Booking, error)
+	MarkBoarded(itemID uint, at time.Time) (bool, error)
+	FindPassengers(ids []uint) ([]*entities.PassengerInfo, error)
+	Ledger() LedgerRepository
+	Settlements() SettlementRepository
 }
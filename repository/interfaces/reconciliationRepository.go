@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"gobus/entities"
+	"time"
+)
+
+// ReconciliationRepository interface is the interface used for the repository of the payment reconciliation
+type ReconciliationRepository interface {
+	WithTx(fn func(tx ReconciliationRepository) error) error
+	FindPaymentOrders(since time.Time) ([]*entities.PaymentOrder, error)
+	FindBookings(ids []uint) ([]*entities.Booking, error)
+	FindOrderPayments(orderIDs []string) ([]*entities.RazorPay, error)
+	FindConfirmedBookings(since time.Time) ([]*entities.Booking, error)
+	FindBookingOrders(bookingIDs []uint) ([]*entities.PaymentOrder, error)
+	FindBookingPayments(bookingIDs []uint) ([]*entities.RazorPay, error)
+	FindEntriesFrom(account string, since time.Time) ([]*entities.LedgerEntry, error)
+	FindTopups(ids []uint) ([]*entities.WalletTopup, error)
+	AddIssue(issue *entities.ReconciliationIssue) (bool, error)
+	FindIssues(status string) ([]*entities.ReconciliationIssue, error)
+	FindIssueForUpdate(id uint) (*entities.ReconciliationIssue, error)
+	UpdateIssue(issue *entities.ReconciliationIssue) error
+}
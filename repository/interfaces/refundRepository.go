@@ -0,0 +1,17 @@
+package interfaces
+
+import "gobus/entities"
+
+// RefundRepository interface is the interface used for the repository of the refunds sent back through the payment gateway
+type RefundRepository interface {
+	WithTx(fn func(tx RefundRepository) error) error
+	Ledger() LedgerRepository
+	FindBookingPayment(bookingID uint) (*entities.RazorPay, error)
+	RefundedFrom(paymentID string) (int64, error)
+	AddRefund(refund *entities.Refund) error
+	UpdateRefund(refund *entities.Refund) error
+	FindRefundForUpdate(id uint) (*entities.Refund, error)
+	FindRefundByGatewayID(gatewayID string) (*entities.Refund, error)
+	FindUnissuedRefunds() ([]*entities.Refund, error)
+	FindUserRefunds(userID uint) ([]*entities.Refund, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import "gobus/entities"
+
+// SettlementRepository interface is the interface used for the provider settlement and payout repository
+type SettlementRepository interface {
+	WithTx(fn func(tx SettlementRepository) error) error
+	Ledger() LedgerRepository
+	FindCommission(providerID uint) (*entities.ProviderCommission, error)
+	SaveCommission(commission *entities.ProviderCommission) error
+	FindBookings(ids []uint) ([]*entities.Booking, error)
+	AddSettlement(settlement *entities.Settlement) error
+	FindUnbatched(providerID uint) ([]*entities.Settlement, error)
+	UnbatchedProviders() ([]uint, error)
+	AddPayout(payout *entities.Payout) error
+	AssignPayout(settlementIDs []uint, payoutID uint) error
+	ReleaseSettlements(payoutID uint) error
+	FindPayout(id uint) (*entities.Payout, error)
+	FindPayoutForUpdate(id uint) (*entities.Payout, error)
+	UpdatePayout(payout *entities.Payout) error
+	FindPayouts(providerID uint, status string) ([]*entities.Payout, error)
+	FindPayoutSettlements(payoutID uint) ([]*entities.Settlement, error)
+}
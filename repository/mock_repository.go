@@ -6,6 +6,7 @@ package repository
 
 import (
 	entities "gobus/entities"
+	interfaces "gobus/repository/interfaces"
 	reflect "reflect"
 	time "time"
 
@@ -35,6 +36,20 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddBookingItems mocks base method.
+func (m *MockUserRepository) AddBookingItems(items []*entities.BookingItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookingItems", items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBookingItems indicates an expected call of AddBookingItems.
+func (mr *MockUserRepositoryMockRecorder) AddBookingItems(items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookingItems", reflect.TypeOf((*MockUserRepository)(nil).AddBookingItems), items)
+}
+
 // AddPassenger mocks base method.
 func (m *MockUserRepository) AddPassenger(passenger *entities.PassengerInfo, email string) (*entities.PassengerInfo, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,63 @@ func (mr *MockUserRepositoryMockRecorder) AddPassenger(passenger, email interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPassenger", reflect.TypeOf((*MockUserRepository)(nil).AddPassenger), passenger, email)
 }
 
+// AddPaymentEvent mocks base method.
+func (m *MockUserRepository) AddPaymentEvent(event *entities.PaymentEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPaymentEvent", event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPaymentEvent indicates an expected call of AddPaymentEvent.
+func (mr *MockUserRepositoryMockRecorder) AddPaymentEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPaymentEvent", reflect.TypeOf((*MockUserRepository)(nil).AddPaymentEvent), event)
+}
+
+// AddPaymentOrder mocks base method.
+func (m *MockUserRepository) AddPaymentOrder(order *entities.PaymentOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPaymentOrder", order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPaymentOrder indicates an expected call of AddPaymentOrder.
+func (mr *MockUserRepositoryMockRecorder) AddPaymentOrder(order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPaymentOrder", reflect.TypeOf((*MockUserRepository)(nil).AddPaymentOrder), order)
+}
+
+// AddSuspiciousPayment mocks base method.
+func (m *MockUserRepository) AddSuspiciousPayment(attempt *entities.SuspiciousPayment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSuspiciousPayment", attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSuspiciousPayment indicates an expected call of AddSuspiciousPayment.
+func (mr *MockUserRepositoryMockRecorder) AddSuspiciousPayment(attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSuspiciousPayment", reflect.TypeOf((*MockUserRepository)(nil).AddSuspiciousPayment), attempt)
+}
+
+// AddTopup mocks base method.
+func (m *MockUserRepository) AddTopup(topup *entities.WalletTopup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTopup", topup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTopup indicates an expected call of AddTopup.
+func (mr *MockUserRepositoryMockRecorder) AddTopup(topup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTopup", reflect.TypeOf((*MockUserRepository)(nil).AddTopup), topup)
+}
+
 // CancelBooking mocks base method.
 func (m *MockUserRepository) CancelBooking(booking *entities.Booking) (*entities.Booking, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +152,51 @@ func (mr *MockUserRepositoryMockRecorder) FindBookingByID(bookID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingByID", reflect.TypeOf((*MockUserRepository)(nil).FindBookingByID), bookID)
 }
 
+// FindBookingByPNR mocks base method.
+func (m *MockUserRepository) FindBookingByPNR(pnr string) (*entities.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookingByPNR", pnr)
+	ret0, _ := ret[0].(*entities.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookingByPNR indicates an expected call of FindBookingByPNR.
+func (mr *MockUserRepositoryMockRecorder) FindBookingByPNR(pnr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingByPNR", reflect.TypeOf((*MockUserRepository)(nil).FindBookingByPNR), pnr)
+}
+
+// FindBookingItems mocks base method.
+func (m *MockUserRepository) FindBookingItems(bookingIDs []uint) ([]*entities.BookingItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookingItems", bookingIDs)
+	ret0, _ := ret[0].([]*entities.BookingItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookingItems indicates an expected call of FindBookingItems.
+func (mr *MockUserRepositoryMockRecorder) FindBookingItems(bookingIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingItems", reflect.TypeOf((*MockUserRepository)(nil).FindBookingItems), bookingIDs)
+}
+
+// FindBookingsWithoutPNR mocks base method.
+func (m *MockUserRepository) FindBookingsWithoutPNR() ([]*entities.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookingsWithoutPNR")
+	ret0, _ := ret[0].([]*entities.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookingsWithoutPNR indicates an expected call of FindBookingsWithoutPNR.
+func (mr *MockUserRepositoryMockRecorder) FindBookingsWithoutPNR() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingsWithoutPNR", reflect.TypeOf((*MockUserRepository)(nil).FindBookingsWithoutPNR))
+}
+
 // FindBus mocks base method.
 func (m *MockUserRepository) FindBus(depart, arrival string) ([]*entities.BusScheduleCombo, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +212,21 @@ func (mr *MockUserRepositoryMockRecorder) FindBus(depart, arrival interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBus", reflect.TypeOf((*MockUserRepository)(nil).FindBus), depart, arrival)
 }
 
+// FindConnections mocks base method.
+func (m *MockUserRepository) FindConnections() ([]*entities.BusScheduleCombo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindConnections")
+	ret0, _ := ret[0].([]*entities.BusScheduleCombo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindConnections indicates an expected call of FindConnections.
+func (mr *MockUserRepositoryMockRecorder) FindConnections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConnections", reflect.TypeOf((*MockUserRepository)(nil).FindConnections))
+}
+
 // FindCoupon mocks base method.
 func (m *MockUserRepository) FindCoupon() ([]*entities.Coupons, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +257,36 @@ func (mr *MockUserRepositoryMockRecorder) FindCouponByID(id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCouponByID", reflect.TypeOf((*MockUserRepository)(nil).FindCouponByID), id)
 }
 
+// FindExpiredHolds mocks base method.
+func (m *MockUserRepository) FindExpiredHolds(now time.Time) ([]*entities.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredHolds", now)
+	ret0, _ := ret[0].([]*entities.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredHolds indicates an expected call of FindExpiredHolds.
+func (mr *MockUserRepositoryMockRecorder) FindExpiredHolds(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredHolds", reflect.TypeOf((*MockUserRepository)(nil).FindExpiredHolds), now)
+}
+
+// FindPayment mocks base method.
+func (m *MockUserRepository) FindPayment(paymentID string) (*entities.RazorPay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPayment", paymentID)
+	ret0, _ := ret[0].(*entities.RazorPay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPayment indicates an expected call of FindPayment.
+func (mr *MockUserRepositoryMockRecorder) FindPayment(paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPayment", reflect.TypeOf((*MockUserRepository)(nil).FindPayment), paymentID)
+}
+
 // FindSchedule mocks base method.
 func (m *MockUserRepository) FindSchedule(depart, arrival string) (*entities.Schedule, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +302,51 @@ func (mr *MockUserRepositoryMockRecorder) FindSchedule(depart, arrival interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSchedule", reflect.TypeOf((*MockUserRepository)(nil).FindSchedule), depart, arrival)
 }
 
+// FindSegmentBuses mocks base method.
+func (m *MockUserRepository) FindSegmentBuses(depart, arrival string) ([]*entities.BusScheduleCombo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSegmentBuses", depart, arrival)
+	ret0, _ := ret[0].([]*entities.BusScheduleCombo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSegmentBuses indicates an expected call of FindSegmentBuses.
+func (mr *MockUserRepositoryMockRecorder) FindSegmentBuses(depart, arrival interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSegmentBuses", reflect.TypeOf((*MockUserRepository)(nil).FindSegmentBuses), depart, arrival)
+}
+
+// FindTopup mocks base method.
+func (m *MockUserRepository) FindTopup(orderID string) (*entities.WalletTopup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTopup", orderID)
+	ret0, _ := ret[0].(*entities.WalletTopup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTopup indicates an expected call of FindTopup.
+func (mr *MockUserRepositoryMockRecorder) FindTopup(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTopup", reflect.TypeOf((*MockUserRepository)(nil).FindTopup), orderID)
+}
+
+// FindTopupForUpdate mocks base method.
+func (m *MockUserRepository) FindTopupForUpdate(orderID string) (*entities.WalletTopup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTopupForUpdate", orderID)
+	ret0, _ := ret[0].(*entities.WalletTopup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTopupForUpdate indicates an expected call of FindTopupForUpdate.
+func (mr *MockUserRepositoryMockRecorder) FindTopupForUpdate(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTopupForUpdate", reflect.TypeOf((*MockUserRepository)(nil).FindTopupForUpdate), orderID)
+}
+
 // FindUserByEmail mocks base method.
 func (m *MockUserRepository) FindUserByEmail(email string) (*entities.User, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +362,21 @@ func (mr *MockUserRepositoryMockRecorder) FindUserByEmail(email interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByEmail", reflect.TypeOf((*MockUserRepository)(nil).FindUserByEmail), email)
 }
 
+// FindWaitlisted mocks base method.
+func (m *MockUserRepository) FindWaitlisted(busID uint, day string) ([]*entities.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWaitlisted", busID, day)
+	ret0, _ := ret[0].([]*entities.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWaitlisted indicates an expected call of FindWaitlisted.
+func (mr *MockUserRepositoryMockRecorder) FindWaitlisted(busID, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWaitlisted", reflect.TypeOf((*MockUserRepository)(nil).FindWaitlisted), busID, day)
+}
+
 // GetBaseFare mocks base method.
 func (m *MockUserRepository) GetBaseFare(scheduleID int) (*entities.BaseFare, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +392,36 @@ func (mr *MockUserRepositoryMockRecorder) GetBaseFare(scheduleID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseFare", reflect.TypeOf((*MockUserRepository)(nil).GetBaseFare), scheduleID)
 }
 
+// GetBoardingPoint mocks base method.
+func (m *MockUserRepository) GetBoardingPoint(id int) (*entities.BoardingPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardingPoint", id)
+	ret0, _ := ret[0].(*entities.BoardingPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardingPoint indicates an expected call of GetBoardingPoint.
+func (mr *MockUserRepositoryMockRecorder) GetBoardingPoint(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardingPoint", reflect.TypeOf((*MockUserRepository)(nil).GetBoardingPoint), id)
+}
+
+// GetBoardingPointsAt mocks base method.
+func (m *MockUserRepository) GetBoardingPointsAt(stop string, busID uint) ([]*entities.BoardingPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardingPointsAt", stop, busID)
+	ret0, _ := ret[0].([]*entities.BoardingPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardingPointsAt indicates an expected call of GetBoardingPointsAt.
+func (mr *MockUserRepositoryMockRecorder) GetBoardingPointsAt(stop, busID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardingPointsAt", reflect.TypeOf((*MockUserRepository)(nil).GetBoardingPointsAt), stop, busID)
+}
+
 // GetBusInfo mocks base method.
 func (m *MockUserRepository) GetBusInfo(id int) (*entities.Buses, error) {
 	m.ctrl.T.Helper()
@@ -200,6 +452,36 @@ func (mr *MockUserRepositoryMockRecorder) GetBusTypeDetails(code interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBusTypeDetails", reflect.TypeOf((*MockUserRepository)(nil).GetBusTypeDetails), code)
 }
 
+// GetBusTypeForProvider mocks base method.
+func (m *MockUserRepository) GetBusTypeForProvider(code string, providerID uint) (*entities.BusType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBusTypeForProvider", code, providerID)
+	ret0, _ := ret[0].(*entities.BusType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBusTypeForProvider indicates an expected call of GetBusTypeForProvider.
+func (mr *MockUserRepositoryMockRecorder) GetBusTypeForProvider(code, providerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBusTypeForProvider", reflect.TypeOf((*MockUserRepository)(nil).GetBusTypeForProvider), code, providerID)
+}
+
+// GetCancellationPolicy mocks base method.
+func (m *MockUserRepository) GetCancellationPolicy(providerID, busID uint) (*entities.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCancellationPolicy", providerID, busID)
+	ret0, _ := ret[0].(*entities.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCancellationPolicy indicates an expected call of GetCancellationPolicy.
+func (mr *MockUserRepositoryMockRecorder) GetCancellationPolicy(providerID, busID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCancellationPolicy", reflect.TypeOf((*MockUserRepository)(nil).GetCancellationPolicy), providerID, busID)
+}
+
 // GetChart mocks base method.
 func (m *MockUserRepository) GetChart(busid int, day time.Time) (*entities.BusSchedule, error) {
 	m.ctrl.T.Helper()
@@ -215,6 +497,36 @@ func (mr *MockUserRepositoryMockRecorder) GetChart(busid, day interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChart", reflect.TypeOf((*MockUserRepository)(nil).GetChart), busid, day)
 }
 
+// GetChartForUpdate mocks base method.
+func (m *MockUserRepository) GetChartForUpdate(busid int, day time.Time) (*entities.BusSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChartForUpdate", busid, day)
+	ret0, _ := ret[0].(*entities.BusSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChartForUpdate indicates an expected call of GetChartForUpdate.
+func (mr *MockUserRepositoryMockRecorder) GetChartForUpdate(busid, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChartForUpdate", reflect.TypeOf((*MockUserRepository)(nil).GetChartForUpdate), busid, day)
+}
+
+// GetChartsForDay mocks base method.
+func (m *MockUserRepository) GetChartsForDay(busIDs []uint, day time.Time) ([]*entities.BusSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChartsForDay", busIDs, day)
+	ret0, _ := ret[0].([]*entities.BusSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChartsForDay indicates an expected call of GetChartsForDay.
+func (mr *MockUserRepositoryMockRecorder) GetChartsForDay(busIDs, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChartsForDay", reflect.TypeOf((*MockUserRepository)(nil).GetChartsForDay), busIDs, day)
+}
+
 // GetParentLocation mocks base method.
 func (m *MockUserRepository) GetParentLocation(name string) (*entities.SubStation, error) {
 	m.ctrl.T.Helper()
@@ -245,6 +557,51 @@ func (mr *MockUserRepositoryMockRecorder) GetProviderInfo(providerID interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderInfo", reflect.TypeOf((*MockUserRepository)(nil).GetProviderInfo), providerID)
 }
 
+// GetProviderInfoForUpdate mocks base method.
+func (m *MockUserRepository) GetProviderInfoForUpdate(providerID int) (*entities.ServiceProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviderInfoForUpdate", providerID)
+	ret0, _ := ret[0].(*entities.ServiceProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProviderInfoForUpdate indicates an expected call of GetProviderInfoForUpdate.
+func (mr *MockUserRepositoryMockRecorder) GetProviderInfoForUpdate(providerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderInfoForUpdate", reflect.TypeOf((*MockUserRepository)(nil).GetProviderInfoForUpdate), providerID)
+}
+
+// GetSchedule mocks base method.
+func (m *MockUserRepository) GetSchedule(id int) (*entities.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", id)
+	ret0, _ := ret[0].(*entities.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockUserRepositoryMockRecorder) GetSchedule(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockUserRepository)(nil).GetSchedule), id)
+}
+
+// GetScheduleStops mocks base method.
+func (m *MockUserRepository) GetScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleStops", scheduleID)
+	ret0, _ := ret[0].([]*entities.ScheduleStop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleStops indicates an expected call of GetScheduleStops.
+func (mr *MockUserRepositoryMockRecorder) GetScheduleStops(scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleStops", reflect.TypeOf((*MockUserRepository)(nil).GetScheduleStops), scheduleID)
+}
+
 // GetSeatLayout mocks base method.
 func (m *MockUserRepository) GetSeatLayout(id int) (*entities.BusSeatLayout, error) {
 	m.ctrl.T.Helper()
@@ -290,6 +647,35 @@ func (mr *MockUserRepositoryMockRecorder) GetUserInfo(userID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfo", reflect.TypeOf((*MockUserRepository)(nil).GetUserInfo), userID)
 }
 
+// GetUserInfoForUpdate mocks base method.
+func (m *MockUserRepository) GetUserInfoForUpdate(userID int) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInfoForUpdate", userID)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInfoForUpdate indicates an expected call of GetUserInfoForUpdate.
+func (mr *MockUserRepositoryMockRecorder) GetUserInfoForUpdate(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfoForUpdate", reflect.TypeOf((*MockUserRepository)(nil).GetUserInfoForUpdate), userID)
+}
+
+// Ledger mocks base method.
+func (m *MockUserRepository) Ledger() interfaces.LedgerRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ledger")
+	ret0, _ := ret[0].(interfaces.LedgerRepository)
+	return ret0
+}
+
+// Ledger indicates an expected call of Ledger.
+func (mr *MockUserRepositoryMockRecorder) Ledger() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ledger", reflect.TypeOf((*MockUserRepository)(nil).Ledger))
+}
+
 // MakeBooking mocks base method.
 func (m *MockUserRepository) MakeBooking(booking *entities.Booking) (*entities.Booking, error) {
 	m.ctrl.T.Helper()
@@ -319,6 +705,20 @@ func (mr *MockUserRepositoryMockRecorder) PaymentSuccess(razor interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentSuccess", reflect.TypeOf((*MockUserRepository)(nil).PaymentSuccess), razor)
 }
 
+// Refunds mocks base method.
+func (m *MockUserRepository) Refunds() interfaces.RefundRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refunds")
+	ret0, _ := ret[0].(interfaces.RefundRepository)
+	return ret0
+}
+
+// Refunds indicates an expected call of Refunds.
+func (mr *MockUserRepositoryMockRecorder) Refunds() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refunds", reflect.TypeOf((*MockUserRepository)(nil).Refunds))
+}
+
 // RegisterUser mocks base method.
 func (m *MockUserRepository) RegisterUser(user *entities.User) (*entities.User, error) {
 	m.ctrl.T.Helper()
@@ -334,6 +734,48 @@ func (mr *MockUserRepositoryMockRecorder) RegisterUser(user interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserRepository)(nil).RegisterUser), user)
 }
 
+// SavePayment mocks base method.
+func (m *MockUserRepository) SavePayment(razor *entities.RazorPay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePayment", razor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePayment indicates an expected call of SavePayment.
+func (mr *MockUserRepositoryMockRecorder) SavePayment(razor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePayment", reflect.TypeOf((*MockUserRepository)(nil).SavePayment), razor)
+}
+
+// SetBookingPNR mocks base method.
+func (m *MockUserRepository) SetBookingPNR(bookingID uint, pnr string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookingPNR", bookingID, pnr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBookingPNR indicates an expected call of SetBookingPNR.
+func (mr *MockUserRepositoryMockRecorder) SetBookingPNR(bookingID, pnr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookingPNR", reflect.TypeOf((*MockUserRepository)(nil).SetBookingPNR), bookingID, pnr)
+}
+
+// SetRefundTo mocks base method.
+func (m *MockUserRepository) SetRefundTo(userID uint, refundTo string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRefundTo", userID, refundTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRefundTo indicates an expected call of SetRefundTo.
+func (mr *MockUserRepositoryMockRecorder) SetRefundTo(userID, refundTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRefundTo", reflect.TypeOf((*MockUserRepository)(nil).SetRefundTo), userID, refundTo)
+}
+
 // UpdateBooking mocks base method.
 func (m *MockUserRepository) UpdateBooking(booking *entities.Booking) (*entities.Booking, error) {
 	m.ctrl.T.Helper()
@@ -349,6 +791,20 @@ func (mr *MockUserRepositoryMockRecorder) UpdateBooking(booking interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBooking", reflect.TypeOf((*MockUserRepository)(nil).UpdateBooking), booking)
 }
 
+// UpdateBookingItem mocks base method.
+func (m *MockUserRepository) UpdateBookingItem(item *entities.BookingItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBookingItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBookingItem indicates an expected call of UpdateBookingItem.
+func (mr *MockUserRepositoryMockRecorder) UpdateBookingItem(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingItem", reflect.TypeOf((*MockUserRepository)(nil).UpdateBookingItem), item)
+}
+
 // UpdateChart mocks base method.
 func (m *MockUserRepository) UpdateChart(chart *entities.BusSchedule) (*entities.BusSchedule, error) {
 	m.ctrl.T.Helper()
@@ -364,6 +820,20 @@ func (mr *MockUserRepositoryMockRecorder) UpdateChart(chart interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChart", reflect.TypeOf((*MockUserRepository)(nil).UpdateChart), chart)
 }
 
+// UpdatePaymentEvent mocks base method.
+func (m *MockUserRepository) UpdatePaymentEvent(event *entities.PaymentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentEvent indicates an expected call of UpdatePaymentEvent.
+func (mr *MockUserRepositoryMockRecorder) UpdatePaymentEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentEvent", reflect.TypeOf((*MockUserRepository)(nil).UpdatePaymentEvent), event)
+}
+
 // UpdateProvider mocks base method.
 func (m *MockUserRepository) UpdateProvider(provider *entities.ServiceProvider) (*entities.ServiceProvider, error) {
 	m.ctrl.T.Helper()
@@ -379,6 +849,20 @@ func (mr *MockUserRepositoryMockRecorder) UpdateProvider(provider interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProvider", reflect.TypeOf((*MockUserRepository)(nil).UpdateProvider), provider)
 }
 
+// UpdateTopup mocks base method.
+func (m *MockUserRepository) UpdateTopup(topup *entities.WalletTopup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTopup", topup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTopup indicates an expected call of UpdateTopup.
+func (mr *MockUserRepositoryMockRecorder) UpdateTopup(topup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTopup", reflect.TypeOf((*MockUserRepository)(nil).UpdateTopup), topup)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserRepository) UpdateUser(user *entities.User) (*entities.User, error) {
 	m.ctrl.T.Helper()
@@ -423,3 +907,17 @@ func (mr *MockUserRepositoryMockRecorder) ViewBookings(email interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewBookings", reflect.TypeOf((*MockUserRepository)(nil).ViewBookings), email)
 }
+
+// WithTx mocks base method.
+func (m *MockUserRepository) WithTx(fn func(tx interfaces.UserRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockUserRepositoryMockRecorder) WithTx(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockUserRepository)(nil).WithTx), fn)
+}
@@ -1 +1,7 @@
 mockgen -source=UserRepositoryImpl.go -destination=mock_repository.go -package=repository
+mockgen -source=interfaces/adminRepository.go -destination=mock_admin_repository.go -package=repository
+mockgen -source=interfaces/providerRepository.go -destination=mock_provider_repository.go -package=repository
+mockgen -source=interfaces/ledgerRepository.go -destination=mock_ledger_repository.go -package=repository
+mockgen -source=interfaces/refundRepository.go -destination=mock_refund_repository.go -package=repository
+mockgen -source=interfaces/reconciliationRepository.go -destination=mock_reconciliation_repository.go -package=repository
+mockgen -source=interfaces/settlementRepository.go -destination=mock_settlement_repository.go -package=repository
@@ -3,9 +3,11 @@ package repository
 import (
 	"errors"
 	"gobus/entities"
+	"gobus/repository/interfaces"
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 
@@ -111,3 +113,67 @@ func Test_userRepo_Register(t *testing.T) {
 		})
 	}
 }
+
+func Test_userRepo_GetChartForUpdate(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`SELECT * FROM "bus_schedules" WHERE (bus_id= $1 AND day=$2) AND "bus_schedules"."deleted_at" IS NULL ORDER BY "bus_schedules"."id" LIMIT 1 FOR UPDATE`)
+	tests := []struct {
+		Name       string
+		beforeTest func(sqlmock.Sqlmock)
+		want       *entities.BusSchedule
+		wantErr    bool
+	}{
+		{
+			Name: "Success chart locked inside the transaction",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectQuery(query).
+					WithArgs(1, day).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "bus_id", "day", "status"}).
+						AddRow(1, 1, day, "Active"))
+				s.ExpectCommit()
+			},
+			want: &entities.BusSchedule{Model: gorm.Model{ID: 1}, BusID: 1, Day: day, Status: "Active"},
+		},
+		{
+			Name: "Fail chart not found",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectQuery(query).
+					WithArgs(1, day).
+					WillReturnError(gorm.ErrRecordNotFound)
+				s.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New()
+			defer mockDB.Close()
+			testdb, _ := gorm.Open(postgres.New(postgres.Config{Conn: mockDB}), &gorm.Config{})
+			u := &UserRepositoryImpl{
+				DB: testdb,
+			}
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+			var got *entities.BusSchedule
+			err := u.WithTx(func(tx interfaces.UserRepository) error {
+				var err error
+				got, err = tx.GetChartForUpdate(1, day)
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("userRepo.GetChartForUpdate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userRepo.GetChartForUpdate() = %v, want %v", got, tt.want)
+			}
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				t.Errorf("userRepo.GetChartForUpdate() did not lock the chart inside the transaction: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package routeplanner
+
+import (
+	"sort"
+	"time"
+)
+
+// Default planning limits used when the request leaves them out.
+const (
+	DefaultMaxLegs    = 3
+	MaxLegsAllowed    = 4
+	DefaultMinLayover = 30 * time.Minute
+	DefaultMaxLayover = 12 * time.Hour
+	DefaultLimit      = 10
+)
+
+// Connection struct is one daily bus run between two stations, the times are the time of day in "15:04:05" format.
+type Connection struct {
+	BusID     uint
+	From      string
+	To        string
+	Departure string
+	Arrival   string
+	Fare      float64
+}
+
+// Options struct is used to bound the search.
+type Options struct {
+	MaxLegs    int
+	MinLayover time.Duration
+	MaxLayover time.Duration
+	Limit      int
+}
+
+// Leg struct is a connection pinned to the day it is taken on.
+type Leg struct {
+	Connection
+	Depart time.Time
+	Arrive time.Time
+}
+
+// Itinerary struct is a chain of legs going from the origin to the destination.
+type Itinerary struct {
+	Legs     []Leg
+	Duration time.Duration
+	Layover  time.Duration
+	Fare     float64
+}
+
+// Plan function is used to find the itineraries from one station to another starting on the given day, the schedules are treated as a time-dependent graph where a leg can only be taken after the previous one arrives plus the minimum layover.
+func Plan(connections []Connection, from string, to string, day time.Time, opts Options) []Itinerary {
+	opts = withDefaults(opts)
+	outgoing := map[string][]Connection{}
+	for _, connection := range connections {
+		outgoing[connection.From] = append(outgoing[connection.From], connection)
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	var itineraries []Itinerary
+	visited := map[string]bool{from: true}
+	var walk func(station string, legs []Leg)
+	walk = func(station string, legs []Leg) {
+		if station == to {
+			itineraries = append(itineraries, newItinerary(legs))
+			return
+		}
+		if len(legs) == opts.MaxLegs {
+			return
+		}
+		for _, connection := range outgoing[station] {
+			if visited[connection.To] {
+				continue
+			}
+			var leg Leg
+			if len(legs) == 0 {
+				leg = pin(connection, day)
+			} else {
+				previous := legs[len(legs)-1]
+				leg = pinAfter(connection, previous.Arrive.Add(opts.MinLayover))
+				if leg.Depart.Sub(previous.Arrive) > opts.MaxLayover {
+					continue
+				}
+			}
+			visited[connection.To] = true
+			walk(connection.To, append(legs[:len(legs):len(legs)], leg))
+			visited[connection.To] = false
+		}
+	}
+	walk(from, nil)
+	sort.SliceStable(itineraries, func(i, j int) bool {
+		if itineraries[i].Duration != itineraries[j].Duration {
+			return itineraries[i].Duration < itineraries[j].Duration
+		}
+		return itineraries[i].Fare < itineraries[j].Fare
+	})
+	if len(itineraries) > opts.Limit {
+		itineraries = itineraries[:opts.Limit]
+	}
+	return itineraries
+}
+
+func withDefaults(opts Options) Options {
+	if opts.MaxLegs <= 0 {
+		opts.MaxLegs = DefaultMaxLegs
+	}
+	if opts.MaxLegs > MaxLegsAllowed {
+		opts.MaxLegs = MaxLegsAllowed
+	}
+	if opts.MinLayover <= 0 {
+		opts.MinLayover = DefaultMinLayover
+	}
+	if opts.MaxLayover <= 0 {
+		opts.MaxLayover = DefaultMaxLayover
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	return opts
+}
+
+// pin function is used to place the connection on the given day.
+func pin(connection Connection, day time.Time) Leg {
+	depart := day.Add(timeOfDay(connection.Departure))
+	arrive := day.Add(timeOfDay(connection.Arrival))
+	if !arrive.After(depart) {
+		arrive = arrive.Add(24 * time.Hour)
+	}
+	return Leg{Connection: connection, Depart: depart, Arrive: arrive}
+}
+
+// pinAfter function is used to place the connection on its first run leaving at or after the given time.
+func pinAfter(connection Connection, earliest time.Time) Leg {
+	day := time.Date(earliest.Year(), earliest.Month(), earliest.Day(), 0, 0, 0, 0, earliest.Location())
+	leg := pin(connection, day)
+	if leg.Depart.Before(earliest) {
+		leg = pin(connection, day.Add(24*time.Hour))
+	}
+	return leg
+}
+
+func timeOfDay(clock string) time.Duration {
+	parsed, err := time.Parse("15:04:05", clock)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute + time.Duration(parsed.Second())*time.Second
+}
+
+func newItinerary(legs []Leg) Itinerary {
+	itinerary := Itinerary{Legs: legs}
+	for i, leg := range legs {
+		itinerary.Fare += leg.Fare
+		if i > 0 {
+			itinerary.Layover += leg.Depart.Sub(legs[i-1].Arrive)
+		}
+	}
+	itinerary.Duration = legs[len(legs)-1].Arrive.Sub(legs[0].Depart)
+	return itinerary
+}
@@ -0,0 +1,58 @@
+package routeplanner
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Plan(t *testing.T) {
+	day, _ := time.Parse("02 01 2006", "01 01 2030")
+	connections := []Connection{
+		{BusID: 1, From: "Kannur", To: "Kozhikode", Departure: "06:00:00", Arrival: "08:00:00", Fare: 200},
+		{BusID: 2, From: "Kozhikode", To: "Bangalore", Departure: "08:15:00", Arrival: "16:00:00", Fare: 600},
+		{BusID: 3, From: "Kozhikode", To: "Bangalore", Departure: "09:00:00", Arrival: "17:00:00", Fare: 500},
+		{BusID: 4, From: "Kannur", To: "Bangalore", Departure: "21:00:00", Arrival: "06:00:00", Fare: 900},
+		{BusID: 5, From: "Bangalore", To: "Kannur", Departure: "10:00:00", Arrival: "18:00:00", Fare: 900},
+	}
+	itineraries := Plan(connections, "Kannur", "Bangalore", day, Options{})
+	if len(itineraries) != 2 {
+		t.Fatalf("routeplanner.Plan() found %d itineraries, want 2", len(itineraries))
+	}
+	direct, connecting := itineraries[0], itineraries[1]
+	if len(direct.Legs) != 1 || direct.Legs[0].BusID != 4 || direct.Duration != 9*time.Hour {
+		t.Errorf("routeplanner.Plan() first itinerary = %+v, want the 9 hour direct bus", direct)
+	}
+	if direct.Legs[0].Arrive.Format("02 01 2006") != "02 01 2030" {
+		t.Errorf("routeplanner.Plan() overnight leg arrives %v, want the next day", direct.Legs[0].Arrive)
+	}
+	// Bus 2 leaves only 15 minutes after bus 1 arrives, below the default minimum layover.
+	if len(connecting.Legs) != 2 || connecting.Legs[1].BusID != 3 || connecting.Fare != 700 || connecting.Layover != time.Hour {
+		t.Errorf("routeplanner.Plan() second itinerary = %+v, want bus 1 then bus 3", connecting)
+	}
+
+	itineraries = Plan(connections, "Kannur", "Bangalore", day, Options{MaxLegs: 1})
+	if len(itineraries) != 1 {
+		t.Errorf("routeplanner.Plan() with one leg found %d itineraries, want 1", len(itineraries))
+	}
+
+	itineraries = Plan(connections, "Kannur", "Bangalore", day, Options{MinLayover: 10 * time.Minute, Limit: 5})
+	if len(itineraries) != 3 || itineraries[1].Legs[1].BusID != 2 {
+		t.Errorf("routeplanner.Plan() with a 10 minute layover = %+v, want bus 2 to connect", itineraries)
+	}
+}
+
+func Test_Plan_NextDayConnection(t *testing.T) {
+	day, _ := time.Parse("02 01 2006", "01 01 2030")
+	connections := []Connection{
+		{BusID: 1, From: "A", To: "B", Departure: "18:00:00", Arrival: "22:00:00"},
+		{BusID: 2, From: "B", To: "C", Departure: "07:00:00", Arrival: "09:00:00"},
+	}
+	itineraries := Plan(connections, "A", "C", day, Options{})
+	if len(itineraries) != 1 || itineraries[0].Legs[1].Depart.Format("02 01 2006 15:04") != "02 01 2030 07:00" {
+		t.Fatalf("routeplanner.Plan() = %+v, want the morning bus of the next day", itineraries)
+	}
+	itineraries = Plan(connections, "A", "C", day, Options{MaxLayover: 6 * time.Hour})
+	if len(itineraries) != 0 {
+		t.Errorf("routeplanner.Plan() ignored the maximum layover")
+	}
+}
@@ -38,9 +38,22 @@ func (ar *AdminRouters) Routes() {
 		adminGroup.DELETE("/stations/remove/:id", ar.admin.DeleteStation)
 		adminGroup.POST("/busschedule/addtochart", ar.admin.AddBusSchedule)
 		adminGroup.POST("/busschedule/addbasefare", ar.admin.AddBaseFare)
+		adminGroup.POST("/busschedule/recurrence", ar.admin.SetRecurrence)
+		adminGroup.GET("/busschedule/recurrence/:id", ar.admin.FindRecurrence)
+		adminGroup.POST("/busschedule/backfill", ar.admin.BackfillCharts)
+		adminGroup.PUT("/schedule/stops/:id", ar.admin.SetScheduleStops)
 		adminGroup.GET("/bookings/view", ar.admin.ViewAllBookings)
 		adminGroup.GET("/bookings/viewbybus", ar.admin.ViewBookingsPerBus)
 		adminGroup.POST("/bookings/cancelbus", ar.admin.CancelBus)
+		adminGroup.GET("/ledger/reconcile", ar.admin.ReconcileLedger)
+		adminGroup.GET("/payments/suspicious", ar.admin.ViewSuspiciousPayments)
+		adminGroup.GET("/payments/reconciliation", ar.admin.ViewReconciliationIssues)
+		adminGroup.POST("/payments/reconciliation/run", ar.admin.ReconcilePayments)
+		adminGroup.POST("/payments/reconciliation/:id/resolve", ar.admin.ResolveReconciliationIssue)
+		adminGroup.PUT("/provider_management/commission/:id", ar.admin.SetCommission)
+		adminGroup.GET("/payouts", ar.admin.ViewPayouts)
+		adminGroup.POST("/payouts/:id/approve", ar.admin.ApprovePayout)
+		adminGroup.POST("/payouts/:id/reject", ar.admin.RejectPayout)
 	}
 	// adminGroup.POST("/login", ar.admin.Login)
 }
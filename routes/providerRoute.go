@@ -40,6 +40,24 @@ func (pr *ProviderRouters) ProRoutes() {
 		providerGroup.GET("/activate_coupon/:id", pr.provider.ActivateCoupon)
 		providerGroup.GET("/coupon/view_code", pr.provider.FindCouponByCode)
 		providerGroup.POST("station/add_sub_station", pr.provider.AddSubStations)
+		providerGroup.POST("/layout/preview", pr.provider.PreviewSeatLayout)
+		providerGroup.POST("/layout/add", pr.provider.AddSeatLayout)
+		providerGroup.GET("/layout/view", pr.provider.FindSeatLayouts)
+		providerGroup.GET("/layout/view/:id", pr.provider.FindSeatLayoutByID)
+		providerGroup.GET("/layout/versions/:id", pr.provider.FindSeatLayoutVersions)
+		providerGroup.PUT("/layout/assign/:id", pr.provider.AssignSeatLayout)
+		providerGroup.POST("/boardingpoint/add", pr.provider.AddBoardingPoint)
+		providerGroup.GET("/boardingpoint/view/:id", pr.provider.FindBoardingPoints)
+		providerGroup.DELETE("/boardingpoint/remove/:id", pr.provider.DeleteBoardingPoint)
+		providerGroup.PUT("/cancellationpolicy", pr.provider.SetCancellationPolicy)
+		providerGroup.GET("/cancellationpolicy/view", pr.provider.FindCancellationPolicies)
+		providerGroup.POST("/boarding/scan", pr.provider.ScanTicket)
+		providerGroup.GET("/boarding/trip/:id", pr.provider.TripBoarding)
+		providerGroup.GET("/manifest/:id", pr.provider.TripManifest)
+		providerGroup.GET("/wallet", pr.provider.WalletStatement)
+		providerGroup.GET("/payouts", pr.provider.ViewPayouts)
+		providerGroup.POST("/payouts/request", pr.provider.RequestPayout)
+		providerGroup.GET("/payouts/:id/statement", pr.provider.SettlementStatement)
 	}
 }
 
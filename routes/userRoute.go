@@ -26,13 +26,25 @@ func (as *UserRouters) URoutes() {
 	as.router.R.POST("/user/addpassenger", as.jwt.ValidateToken("user"), as.user.AddPassenger)
 	as.router.R.GET("/user/viewallpassenger", as.jwt.ValidateToken("user"), as.user.ViewAllPassengers)
 	as.router.R.POST("/user/bookseat", as.jwt.ValidateToken("user"), as.user.BookSeat)
-	as.router.R.GET("/user/payment/:bookid", as.user.MakePayment)
+	as.router.R.GET("/user/planroute", as.jwt.ValidateToken("user"), as.user.PlanRoutes)
+	as.router.R.POST("/user/bookitinerary", as.jwt.ValidateToken("user"), as.user.BookItinerary)
+	as.router.R.GET("/user/payment/:pnr", as.user.MakePayment)
 	as.router.R.GET("/user/payment/success", as.user.PaymentSuccess)
+	as.router.R.POST("/user/payment/webhook", as.user.PaymentWebhook)
+	as.router.R.GET("/user/wallet", as.jwt.ValidateToken("user"), as.user.WalletStatement)
+	as.router.R.POST("/user/wallet/topup", as.jwt.ValidateToken("user"), as.user.TopupWallet)
+	as.router.R.GET("/user/refunds", as.jwt.ValidateToken("user"), as.user.ViewRefunds)
+	as.router.R.PUT("/user/refunds/preference", as.jwt.ValidateToken("user"), as.user.SetRefundPreference)
 	as.router.R.GET("/user/coupon/view", as.jwt.ValidateToken("user"), as.user.FindCoupon)
 	as.router.R.GET("/user/bookings/view", as.jwt.ValidateToken("user"), as.user.ViewBookings)
-	as.router.R.POST("/user/bookings/cancel/:id", as.jwt.ValidateToken("user"), as.user.CancelBooking)
+	as.router.R.POST("/user/bookings/cancel/:pnr", as.jwt.ValidateToken("user"), as.user.CancelBooking)
+	as.router.R.POST("/user/bookings/cancelseats/:pnr", as.jwt.ValidateToken("user"), as.user.CancelSeats)
+	as.router.R.POST("/user/bookings/refundquote/:pnr", as.jwt.ValidateToken("user"), as.user.RefundQuote)
+	as.router.R.POST("/user/bookings/reschedule/:pnr", as.jwt.ValidateToken("user"), as.user.RescheduleBooking)
+	as.router.R.GET("/user/bookings/:pnr/ticket", as.jwt.ValidateToken("user"), as.user.BookingTicket)
 	as.router.R.GET("/user/seatstatus", as.jwt.ValidateToken("user"), as.user.SeatStatus)
 	as.router.R.GET("/success", as.user.SuccessPage)
+	as.router.R.GET("/booking/lookup", as.user.LookupBooking)
 	as.router.R.GET("/user/getsubstationlist", as.user.SubStationsDetails)
 	as.router.R.GET("/", as.user.IndexPage)
 }
@@ -0,0 +1,95 @@
+package seathold
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// DefaultHoldMinutes is the hold window used when SEAT_HOLD_MINUTES is not set.
+const DefaultHoldMinutes = 10
+
+// DefaultRedisAddr is the Redis address used when REDIS_ADDR is not set.
+const DefaultRedisAddr = "localhost:6379"
+
+var ctx = context.Background()
+
+// SeatHold interface is used to place, check and release the temporary hold on the seats of a booking.
+type SeatHold interface {
+	Hold(bookingID uint, ttl time.Duration) error
+	IsHeld(bookingID uint) (bool, error)
+	Release(bookingID uint) error
+}
+
+// RedisSeatHold struct is used to keep the seat holds in Redis, the key expires along with the hold.
+type RedisSeatHold struct {
+	rdb *redis.Client
+}
+
+// HoldDuration function returns the configured hold window for seats awaiting payment.
+func HoldDuration() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("SEAT_HOLD_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = DefaultHoldMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func holdKey(bookingID uint) string {
+	return fmt.Sprintf("seathold:%d", bookingID)
+}
+
+// Hold implements SeatHold.
+func (rs *RedisSeatHold) Hold(bookingID uint, ttl time.Duration) error {
+	return rs.rdb.Set(ctx, holdKey(bookingID), time.Now().Add(ttl).Unix(), ttl).Err()
+}
+
+// IsHeld implements SeatHold.
+func (rs *RedisSeatHold) IsHeld(bookingID uint) (bool, error) {
+	count, err := rs.rdb.Exists(ctx, holdKey(bookingID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+// Release implements SeatHold.
+func (rs *RedisSeatHold) Release(bookingID uint) error {
+	return rs.rdb.Del(ctx, holdKey(bookingID)).Err()
+}
+
+// DatabaseHold struct leaves the hold to the hold_expires_at of the booking, it is used when Redis cannot be reached.
+type DatabaseHold struct{}
+
+// Hold implements SeatHold.
+func (DatabaseHold) Hold(bookingID uint, ttl time.Duration) error { return nil }
+
+// IsHeld implements SeatHold.
+func (DatabaseHold) IsHeld(bookingID uint) (bool, error) { return false, nil }
+
+// Release implements SeatHold.
+func (DatabaseHold) Release(bookingID uint) error { return nil }
+
+// NewRedisSeatHold function is used to connect to the Redis at REDIS_ADDR and instantiate the RedisSeatHold.
+func NewRedisSeatHold() (*RedisSeatHold, error) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = DefaultRedisAddr
+	}
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       0,
+	})
+	if _, err := rdb.Ping(ctx).Result(); err != nil {
+		rdb.Close()
+		return nil, fmt.Errorf("connecting to Redis at %s: %w", addr, err)
+	}
+	return &RedisSeatHold{
+		rdb: rdb,
+	}, nil
+}
@@ -0,0 +1,227 @@
+package seatmap
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// MaxHops is the number of stop to stop hops a route can have, the hops of a seat are tracked as bits.
+const MaxHops = 63
+
+// Chart struct holds the reservation state of every deck of a BusSchedule, true means the seat is reserved on at least one hop of the route.
+type Chart struct {
+	Decks [MaxDecks][][]bool
+	hops  int
+	// segments holds the occupied hops of the seats booked for a part of the route, a reserved seat missing here is taken on every hop.
+	segments map[string]uint64
+}
+
+// Segment struct is the part of the route between two stops, From and To are the stop sequence numbers with From before To.
+type Segment struct {
+	From int
+	To   int
+}
+
+func (s Segment) mask() uint64 {
+	return (uint64(1) << uint(s.To)) - (uint64(1) << uint(s.From))
+}
+
+type deckOneLayout struct {
+	DeckOneLayout [][]bool `json:"deckOneLayout"`
+}
+
+type deckTwoLayout struct {
+	DeckTwoLayout [][]bool `json:"deckTwoLayout"`
+}
+
+// LoadChart function is used to decode the deck layouts stored on a BusSchedule, empty layouts give empty decks.
+func LoadChart(deckOne []byte, deckTwo []byte) (*Chart, error) {
+	chart := &Chart{}
+	if len(deckOne) > 0 && string(deckOne) != "null" {
+		one := deckOneLayout{}
+		if err := json.Unmarshal(deckOne, &one); err != nil {
+			return nil, err
+		}
+		chart.Decks[0] = one.DeckOneLayout
+	}
+	if len(deckTwo) > 0 && string(deckTwo) != "null" {
+		two := deckTwoLayout{}
+		if err := json.Unmarshal(deckTwo, &two); err != nil {
+			return nil, err
+		}
+		chart.Decks[1] = two.DeckTwoLayout
+	}
+	return chart, nil
+}
+
+// LoadSegments function is used to decode the per hop occupancy stored on a BusSchedule for a route with the given number of hops.
+func (c *Chart) LoadSegments(data []byte, hops int) error {
+	if hops < 1 || hops > MaxHops {
+		return errors.New("route has an invalid number of stops")
+	}
+	c.hops = hops
+	c.segments = map[string]uint64{}
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(data, &c.segments)
+}
+
+// EncodeSegments function is used to encode the per hop occupancy for storing it on the BusSchedule.
+func (c *Chart) EncodeSegments() ([]byte, error) {
+	if len(c.segments) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(c.segments)
+}
+
+// FullRoute function returns the segment going from the first to the last stop.
+func (c *Chart) FullRoute() Segment {
+	if c.hops < 1 {
+		return Segment{From: 0, To: 1}
+	}
+	return Segment{From: 0, To: c.hops}
+}
+
+// CheckSegment function is used to check that the segment lies on the route of the chart.
+func (c *Chart) CheckSegment(segment Segment) error {
+	if segment.From < 0 || segment.From >= segment.To || segment.To > c.FullRoute().To {
+		return errors.New("invalid segment of the route")
+	}
+	return nil
+}
+
+// NewChart function is used to create an empty chart sized for the given seat map.
+func NewChart(m *SeatMap) *Chart {
+	chart := &Chart{}
+	for d, deck := range m.Decks {
+		chart.grow(d, deck.Rows-1, deck.Columns-1)
+	}
+	return chart
+}
+
+// Encode function is used to encode the chart back into the deck layouts of a BusSchedule.
+func (c *Chart) Encode() ([]byte, []byte, error) {
+	deckOne, err := json.Marshal(deckOneLayout{DeckOneLayout: c.Decks[0]})
+	if err != nil {
+		return nil, nil, err
+	}
+	deckTwo, err := json.Marshal(deckTwoLayout{DeckTwoLayout: c.Decks[1]})
+	if err != nil {
+		return nil, nil, err
+	}
+	return deckOne, deckTwo, nil
+}
+
+// IsReserved function reports whether the seat is reserved on the chart.
+func (c *Chart) IsReserved(seat Seat) bool {
+	d := seat.Deck - 1
+	if d < 0 || d >= MaxDecks || seat.Row >= len(c.Decks[d]) || seat.Column >= len(c.Decks[d][seat.Row]) {
+		return false
+	}
+	return c.Decks[d][seat.Row][seat.Column]
+}
+
+// occupied function returns the hops on which the seat is taken.
+func (c *Chart) occupied(seat Seat) uint64 {
+	if hops, ok := c.segments[seat.ID]; ok {
+		return hops
+	}
+	if c.IsReserved(seat) {
+		return c.FullRoute().mask()
+	}
+	return 0
+}
+
+// Available function returns the seats of the map that are free on the whole route.
+func (c *Chart) Available(m *SeatMap) []Seat {
+	return c.AvailableFor(m, c.FullRoute())
+}
+
+// AvailableFor function returns the seats of the map that are free on every hop of the segment.
+func (c *Chart) AvailableFor(m *SeatMap, segment Segment) []Seat {
+	var seats []Seat
+	for _, seat := range m.Seats() {
+		if c.occupied(seat)&segment.mask() == 0 {
+			seats = append(seats, seat)
+		}
+	}
+	return seats
+}
+
+// Reserve function is used to reserve all the given seats for the whole route, nothing is reserved when one of them is invalid or already taken.
+func (c *Chart) Reserve(m *SeatMap, ids []string) ([]Seat, error) {
+	return c.ReserveSegment(m, ids, c.FullRoute())
+}
+
+// ReserveSegment function is used to reserve all the given seats for the segment, nothing is reserved when one of them is invalid or already taken on any hop of it.
+func (c *Chart) ReserveSegment(m *SeatMap, ids []string, segment Segment) ([]Seat, error) {
+	if err := c.CheckSegment(segment); err != nil {
+		return nil, err
+	}
+	seats := make([]Seat, 0, len(ids))
+	picked := map[string]bool{}
+	for _, id := range ids {
+		seat, ok := m.Seat(id)
+		if !ok {
+			return nil, errors.New("invalid seat entered")
+		}
+		if picked[seat.ID] || c.occupied(seat)&segment.mask() != 0 {
+			return nil, errors.New("seat already reserved")
+		}
+		picked[seat.ID] = true
+		seats = append(seats, seat)
+	}
+	for _, seat := range seats {
+		c.setHops(seat, c.occupied(seat)|segment.mask())
+	}
+	return seats, nil
+}
+
+// Release function is used to free the given seats on the whole route, unknown seat IDs are ignored.
+func (c *Chart) Release(m *SeatMap, ids []string) {
+	c.ReleaseSegment(m, ids, c.FullRoute())
+}
+
+// ReleaseSegment function is used to free the given seats on the hops of the segment, the seat stays reserved on the other hops.
+func (c *Chart) ReleaseSegment(m *SeatMap, ids []string, segment Segment) {
+	for _, id := range ids {
+		if seat, ok := m.Seat(strings.TrimSpace(id)); ok {
+			c.setHops(seat, c.occupied(seat)&^segment.mask())
+		}
+	}
+}
+
+// setHops function is used to store the occupied hops of the seat, a seat taken on the whole route needs no per hop entry.
+func (c *Chart) setHops(seat Seat, hops uint64) {
+	if c.segments == nil {
+		c.segments = map[string]uint64{}
+	}
+	if hops == 0 || hops == c.FullRoute().mask() {
+		delete(c.segments, seat.ID)
+	} else {
+		c.segments[seat.ID] = hops
+	}
+	c.set(seat, hops != 0)
+}
+
+func (c *Chart) set(seat Seat, reserved bool) {
+	d := seat.Deck - 1
+	if d < 0 || d >= MaxDecks {
+		return
+	}
+	c.grow(d, seat.Row, seat.Column)
+	c.Decks[d][seat.Row][seat.Column] = reserved
+}
+
+func (c *Chart) grow(d int, row int, column int) {
+	for len(c.Decks[d]) <= row {
+		c.Decks[d] = append(c.Decks[d], []bool{})
+	}
+	for i := range c.Decks[d] {
+		for len(c.Decks[d][i]) <= column {
+			c.Decks[d][i] = append(c.Decks[d][i], false)
+		}
+	}
+}
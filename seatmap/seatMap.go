@@ -0,0 +1,265 @@
+package seatmap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gobus/entities"
+	"sort"
+	"strings"
+)
+
+// Seat classes supported by the seat map.
+const (
+	Sleeper = "sleeper"
+	Seater  = "seater"
+)
+
+// MaxDecks is the number of decks a bus chart can hold.
+const MaxDecks = 2
+
+// Seat struct is used to describe one cell of a deck, the cell can be a bookable seat, an aisle or a blocked cell.
+type Seat struct {
+	ID      string `json:"id,omitempty"`
+	Deck    int    `json:"deck"`
+	Row     int    `json:"row"`
+	Column  int    `json:"col"`
+	Class   string `json:"class,omitempty"`
+	Aisle   bool   `json:"aisle,omitempty"`
+	Blocked bool   `json:"blocked,omitempty"`
+}
+
+// Bookable function reports whether the cell can be sold to a passenger.
+func (s Seat) Bookable() bool {
+	return !s.Aisle && !s.Blocked
+}
+
+// Deck struct is used to store the size of a deck and the cells placed on it.
+type Deck struct {
+	Rows    int    `json:"rows"`
+	Columns int    `json:"columns"`
+	Cells   []Seat `json:"cells"`
+}
+
+// SeatMap struct is the first-class seat layout of a bus, seats are addressed by their ID instead of their position in the name.
+type SeatMap struct {
+	Decks []Deck `json:"decks"`
+	index map[string]Seat
+}
+
+// Parse function is used to decode and validate an encoded seat map.
+func Parse(data []byte) (*SeatMap, error) {
+	seatMap := &SeatMap{}
+	if err := json.Unmarshal(data, seatMap); err != nil {
+		return nil, err
+	}
+	if err := seatMap.Validate(); err != nil {
+		return nil, err
+	}
+	return seatMap, nil
+}
+
+// Encode function is used to encode the seat map for storing it on the BusSeatLayout.
+func (m *SeatMap) Encode() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+// Validate function checks that every cell sits inside its deck, that no two cells share a position and that the seat IDs are unique.
+func (m *SeatMap) Validate() error {
+	if len(m.Decks) == 0 || len(m.Decks) > MaxDecks {
+		return fmt.Errorf("a seat map needs between 1 and %d decks", MaxDecks)
+	}
+	m.index = map[string]Seat{}
+	for d, deck := range m.Decks {
+		if deck.Rows <= 0 || deck.Columns <= 0 {
+			return fmt.Errorf("deck %d has no rows or columns", d+1)
+		}
+		taken := map[[2]int]bool{}
+		seats := 0
+		for i := range deck.Cells {
+			cell := &m.Decks[d].Cells[i]
+			cell.Deck = d + 1
+			if cell.Row < 0 || cell.Row >= deck.Rows || cell.Column < 0 || cell.Column >= deck.Columns {
+				return fmt.Errorf("cell at row %d column %d is outside deck %d", cell.Row, cell.Column, d+1)
+			}
+			position := [2]int{cell.Row, cell.Column}
+			if taken[position] {
+				return fmt.Errorf("deck %d has two cells at row %d column %d", d+1, cell.Row, cell.Column)
+			}
+			taken[position] = true
+			if !cell.Bookable() {
+				continue
+			}
+			if cell.ID == "" {
+				return fmt.Errorf("seat at row %d column %d of deck %d has no ID", cell.Row, cell.Column, d+1)
+			}
+			if cell.Class != Sleeper && cell.Class != Seater {
+				return fmt.Errorf("seat %s has an unknown class %q", cell.ID, cell.Class)
+			}
+			if _, ok := m.index[cell.ID]; ok {
+				return fmt.Errorf("seat ID %s is used more than once", cell.ID)
+			}
+			m.index[cell.ID] = *cell
+			seats++
+		}
+		if seats == 0 {
+			return fmt.Errorf("deck %d has no seats", d+1)
+		}
+	}
+	return nil
+}
+
+// Seat function is used to look up a bookable seat by its ID.
+func (m *SeatMap) Seat(id string) (Seat, bool) {
+	if m.index == nil {
+		m.Validate()
+	}
+	seat, ok := m.index[strings.ToUpper(strings.TrimSpace(id))]
+	return seat, ok
+}
+
+// Seats function returns every bookable seat ordered by deck, row and column.
+func (m *SeatMap) Seats() []Seat {
+	var seats []Seat
+	for _, deck := range m.Decks {
+		for _, cell := range deck.Cells {
+			if cell.Bookable() {
+				seats = append(seats, cell)
+			}
+		}
+	}
+	sort.SliceStable(seats, func(i, j int) bool {
+		if seats[i].Deck != seats[j].Deck {
+			return seats[i].Deck < seats[j].Deck
+		}
+		if seats[i].Row != seats[j].Row {
+			return seats[i].Row < seats[j].Row
+		}
+		return seats[i].Column < seats[j].Column
+	})
+	return seats
+}
+
+// CountByClass function returns the number of bookable seats of every class.
+func (m *SeatMap) CountByClass() map[string]int {
+	counts := map[string]int{}
+	for _, seat := range m.Seats() {
+		counts[seat.Class]++
+	}
+	return counts
+}
+
+// CheckCapacity function is used to check that the seat map holds exactly the number of sleeper and seater seats of a bus.
+func (m *SeatMap) CheckCapacity(sleeper uint, seater uint) error {
+	counts := m.CountByClass()
+	if counts[Sleeper] != int(sleeper) {
+		return fmt.Errorf("layout has %d sleeper seats but the bus has %d", counts[Sleeper], sleeper)
+	}
+	if counts[Seater] != int(seater) {
+		return fmt.Errorf("layout has %d seater seats but the bus has %d", counts[Seater], seater)
+	}
+	return nil
+}
+
+// Grid function is used to render every deck as rows of cells, a cell holds the seat ID, "|" for an aisle or "X" for a blocked cell.
+func (m *SeatMap) Grid() [][][]string {
+	grid := make([][][]string, len(m.Decks))
+	for d, deck := range m.Decks {
+		grid[d] = make([][]string, deck.Rows)
+		for i := range grid[d] {
+			grid[d][i] = make([]string, deck.Columns)
+		}
+		for _, cell := range deck.Cells {
+			switch {
+			case cell.Aisle:
+				grid[d][cell.Row][cell.Column] = "|"
+			case cell.Blocked:
+				grid[d][cell.Row][cell.Column] = "X"
+			default:
+				grid[d][cell.Row][cell.Column] = cell.ID
+			}
+		}
+	}
+	return grid
+}
+
+// SeatName function builds the default seat ID from a zero based row and column, 0,0 becomes 01A.
+func SeatName(row int, column int) string {
+	letters := ""
+	for column >= 0 {
+		letters = string(rune('A'+column%26)) + letters
+		column = column/26 - 1
+	}
+	return fmt.Sprintf("%02d%s", row+1, letters)
+}
+
+// Default function is used to build a fully seated map for the given deck sizes, the column letters of deck two continue after the ones of deck one.
+func Default(deckOneRows, deckOneColumns int, deckOneClass string, deckTwoRows, deckTwoColumns int, deckTwoClass string) *SeatMap {
+	seatMap := &SeatMap{}
+	sizes := [][2]int{{deckOneRows, deckOneColumns}, {deckTwoRows, deckTwoColumns}}
+	classes := []string{deckOneClass, deckTwoClass}
+	offset := 0
+	for d, size := range sizes {
+		if size[0] <= 0 || size[1] <= 0 {
+			continue
+		}
+		deck := Deck{Rows: size[0], Columns: size[1]}
+		for i := 0; i < size[0]; i++ {
+			for j := 0; j < size[1]; j++ {
+				deck.Cells = append(deck.Cells, Seat{ID: SeatName(i, j+offset), Deck: d + 1, Row: i, Column: j, Class: classes[d]})
+			}
+		}
+		seatMap.Decks = append(seatMap.Decks, deck)
+		offset += size[1]
+	}
+	seatMap.Validate()
+	return seatMap
+}
+
+// DeckClasses function returns the seat class of deck one and deck two for the legacy bus type codes.
+func DeckClasses(busTypeCode string) (string, string) {
+	code := strings.TrimPrefix(busTypeCode, "AC_")
+	switch code {
+	case "SL":
+		return Sleeper, Sleeper
+	case "SL_SE":
+		return Seater, Sleeper
+	default:
+		return Seater, Seater
+	}
+}
+
+// FromLayout function is used to get the seat map of a BusSeatLayout, layouts saved before the seat map existed are built from their deck sizes.
+func FromLayout(layout *entities.BusSeatLayout, busTypeCode string) (*SeatMap, error) {
+	if layout == nil {
+		return nil, errors.New("seat layout not found")
+	}
+	if len(layout.SeatMap) > 0 {
+		return Parse(layout.SeatMap)
+	}
+	classOne, classTwo := DeckClasses(busTypeCode)
+	seatMap := Default(layout.DeckOneRows, layout.DeckOneColumns, classOne, layout.DeckTwoRows, layout.DeckTwoColumns, classTwo)
+	if len(seatMap.Decks) == 0 {
+		return nil, errors.New("seat layout has no decks")
+	}
+	return seatMap, nil
+}
+
+// FromChart function is used to build a default seat map from the size of the chart grids when the bus has no layout assigned.
+func FromChart(chart *Chart, busTypeCode string) (*SeatMap, error) {
+	classOne, classTwo := DeckClasses(busTypeCode)
+	sizes := [MaxDecks][2]int{}
+	for d := 0; d < MaxDecks && d < len(chart.Decks); d++ {
+		sizes[d][0] = len(chart.Decks[d])
+		for _, row := range chart.Decks[d] {
+			if len(row) > sizes[d][1] {
+				sizes[d][1] = len(row)
+			}
+		}
+	}
+	seatMap := Default(sizes[0][0], sizes[0][1], classOne, sizes[1][0], sizes[1][1], classTwo)
+	if len(seatMap.Decks) == 0 {
+		return nil, errors.New("chart has no seats")
+	}
+	return seatMap, nil
+}
@@ -0,0 +1,161 @@
+package seatmap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Default_SeatNames(t *testing.T) {
+	seatMap := Default(2, 3, Seater, 1, 3, Sleeper)
+	var got []string
+	for _, seat := range seatMap.Seats() {
+		got = append(got, seat.ID)
+	}
+	want := []string{"01A", "01B", "01C", "02A", "02B", "02C", "01D", "01E", "01F"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("seatmap.Default() seats = %v, want %v", got, want)
+	}
+	if seat, _ := seatMap.Seat("01e"); seat.Class != Sleeper || seat.Deck != 2 {
+		t.Errorf("seatmap.Seat() = %+v, want a deck two sleeper", seat)
+	}
+	if got := SeatName(120, 27); got != "121AB" {
+		t.Errorf("seatmap.SeatName() = %v, want 121AB", got)
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "2+1 layout with an aisle",
+			data: `{"decks":[{"rows":1,"columns":4,"cells":[
+				{"id":"1A","row":0,"col":0,"class":"seater"},
+				{"id":"1B","row":0,"col":1,"class":"seater"},
+				{"row":0,"col":2,"aisle":true},
+				{"id":"1C","row":0,"col":3,"class":"sleeper"}]}]}`,
+		},
+		{
+			name:    "duplicate seat ID",
+			data:    `{"decks":[{"rows":1,"columns":2,"cells":[{"id":"1A","row":0,"col":0,"class":"seater"},{"id":"1A","row":0,"col":1,"class":"seater"}]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "cell outside the deck",
+			data:    `{"decks":[{"rows":1,"columns":1,"cells":[{"id":"1A","row":3,"col":0,"class":"seater"}]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "deck without seats",
+			data:    `{"decks":[{"rows":1,"columns":1,"cells":[{"row":0,"col":0,"aisle":true}]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown class",
+			data:    `{"decks":[{"rows":1,"columns":1,"cells":[{"id":"1A","row":0,"col":0,"class":"bunk"}]}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("seatmap.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_CheckCapacity(t *testing.T) {
+	seatMap := Default(10, 3, Seater, 4, 3, Sleeper)
+	if err := seatMap.CheckCapacity(12, 30); err != nil {
+		t.Errorf("seatmap.CheckCapacity() error = %v, want nil", err)
+	}
+	if err := seatMap.CheckCapacity(12, 36); err == nil {
+		t.Errorf("seatmap.CheckCapacity() accepted a seater count mismatch")
+	}
+}
+
+func Test_Chart_ReserveRelease(t *testing.T) {
+	seatMap, err := Parse([]byte(`{"decks":[{"rows":1,"columns":4,"cells":[
+		{"id":"1A","row":0,"col":0,"class":"seater"},
+		{"row":0,"col":1,"aisle":true},
+		{"id":"1B","row":0,"col":2,"class":"seater"},
+		{"id":"1C","row":0,"col":3,"class":"seater","blocked":true}]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chart, err := LoadChart(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chart.Reserve(seatMap, []string{"1B", "1C"}); err == nil {
+		t.Errorf("chart.Reserve() reserved a blocked seat")
+	}
+	if len(chart.Available(seatMap)) != 2 {
+		t.Errorf("chart.Reserve() changed the chart after a failed reservation")
+	}
+	if _, err := chart.Reserve(seatMap, []string{"1B"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chart.Reserve(seatMap, []string{"1A", "1B"}); err == nil {
+		t.Errorf("chart.Reserve() reserved an already reserved seat")
+	}
+	deckOne, deckTwo, _ := chart.Encode()
+	reloaded, _ := LoadChart(deckOne, deckTwo)
+	if got := reloaded.Available(seatMap); len(got) != 1 || got[0].ID != "1A" {
+		t.Errorf("chart.Available() = %v, want only 1A", got)
+	}
+	reloaded.Release(seatMap, []string{"1B"})
+	if len(reloaded.Available(seatMap)) != 2 {
+		t.Errorf("chart.Release() did not free 1B")
+	}
+}
+
+func Test_Chart_Segments(t *testing.T) {
+	seatMap := Default(1, 1, Seater, 0, 0, Seater)
+	chart, _ := LoadChart(nil, nil)
+	if err := chart.LoadSegments(nil, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chart.ReserveSegment(seatMap, []string{"01A"}, Segment{From: 0, To: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chart.ReserveSegment(seatMap, []string{"01A"}, Segment{From: 0, To: 2}); err == nil {
+		t.Errorf("chart.ReserveSegment() reserved a seat taken on an overlapping hop")
+	}
+	if _, err := chart.ReserveSegment(seatMap, []string{"01A"}, Segment{From: 1, To: 3}); err != nil {
+		t.Errorf("chart.ReserveSegment() error = %v, the seat is free after the first stop", err)
+	}
+	if len(chart.Available(seatMap)) != 0 {
+		t.Errorf("chart.Available() lists a seat taken on every hop")
+	}
+	if _, err := chart.ReserveSegment(seatMap, []string{"01A"}, Segment{From: 2, To: 4}); err == nil {
+		t.Errorf("chart.ReserveSegment() accepted a segment beyond the last stop")
+	}
+
+	deckOne, deckTwo, _ := chart.Encode()
+	segments, _ := chart.EncodeSegments()
+	reloaded, _ := LoadChart(deckOne, deckTwo)
+	reloaded.LoadSegments(segments, 3)
+	reloaded.ReleaseSegment(seatMap, []string{"01A"}, Segment{From: 0, To: 1})
+	if got := reloaded.AvailableFor(seatMap, Segment{From: 0, To: 1}); len(got) != 1 {
+		t.Errorf("chart.ReleaseSegment() did not free the first hop")
+	}
+	if got := reloaded.AvailableFor(seatMap, Segment{From: 1, To: 2}); len(got) != 0 {
+		t.Errorf("chart.ReleaseSegment() freed a hop of another booking")
+	}
+
+	// A seat reserved before segments were tracked is taken on every hop.
+	legacy, _ := LoadChart([]byte(`{"deckOneLayout":[[true]]}`), nil)
+	legacy.LoadSegments(nil, 3)
+	legacy.ReleaseSegment(seatMap, []string{"01A"}, Segment{From: 2, To: 3})
+	if got := legacy.AvailableFor(seatMap, Segment{From: 2, To: 3}); len(got) != 1 {
+		t.Errorf("chart.ReleaseSegment() did not free the last hop of a legacy reservation")
+	}
+	if got := legacy.AvailableFor(seatMap, Segment{From: 0, To: 2}); len(got) != 0 {
+		t.Errorf("chart.ReleaseSegment() freed the other hops of a legacy reservation")
+	}
+}
@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"gobus/dto"
 	"gobus/entities"
+	"gobus/ledger"
 	"gobus/middleware"
+	"gobus/payment"
+	"gobus/recurrence"
 	repository "gobus/repository/interfaces"
+	"gobus/seatmap"
 	service "gobus/services/interfaces"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/twilio/twilio-go"
@@ -38,8 +44,9 @@ func sendCancellationEmail(recipientEmail, cancel string) error {
 
 // AdminServiceImpl struct is used to Implement the Admin Service.
 type AdminServiceImpl struct {
-	repo repository.AdminRepository
-	jwt  *middleware.JwtUtil
+	repo    repository.AdminRepository
+	jwt     *middleware.JwtUtil
+	gateway payment.PaymentGateway
 }
 
 // WhatsappNotifier function was added to notify the customer on bus cancellation, but will not notify via whatsapp only via sms.
@@ -82,25 +89,38 @@ func (as *AdminServiceImpl) CancelBus(busID int, day string) (string, error) {
 		// }
 		go func(booking *entities.Booking) {
 			defer close(result)
-			amount := booking.FarePostDiscount
+			amount := ledger.Paisa(booking.FarePostDiscount)
 			user, _ := as.repo.FindUserByID(int(booking.UserID))
-			provider, _ := as.repo.FindProviderByID(int(bus.ProviderID))
-			user.UserWallet += int(amount)
-			provider.ProviderWallet -= int(amount)
-			booking.Status = "Cancelled by Admin"
-			if _, err := as.repo.UpdateProvider(provider); err != nil {
-				log.Println("Error updating the provider, in adminServiceImpl file")
+			destination, _ := refundDestination("", user)
+			var issued *entities.Refund
+			err := as.repo.Refunds().WithTx(func(tx repository.RefundRepository) error {
+				source, err := refundSource(tx.Ledger(), booking, bus.ProviderID, amount)
+				if err != nil {
+					return err
+				}
+				issued, err = refundBooking(tx, booking, source, amount, fmt.Sprintf("booking:%d:bus-cancelled", booking.BookingID), destination)
+				return err
+			})
+			if err != nil {
+				log.Println("Error refunding the booking, in adminServiceImpl file")
 				result <- err
+				return
 			}
-			if _, err := as.repo.UpdateUser(user); err != nil {
-				log.Println("Error updating the user, in adminServiceImpl file")
-				result <- err
+			if issued != nil {
+				if sent, err := issueRefund(as.repo.Refunds(), as.gateway, issued.ID); err == nil {
+					issued = sent
+				}
 			}
+			booking.Status = "Cancelled by Admin"
 			if _, err := as.repo.UpdateBooking(booking); err != nil {
 				log.Println("Error updating the booking, in adminServiceImpl file")
 				result <- err
 			}
-			message := fmt.Sprintf("The bus %d has been cancelled for the day %s due to unforeseen circumstances. Sorry for the inconvinience caused. Your amount has been refunded to your wallet. \n Booking Info \n Booking ID: %d \n UserID: %d \n UsedCouponID: %d \n Actual Fare: %d \n FarePostDiscount: %d \n BusID: %d \n Departure Location: %s \n Arrival Location: %s \n BookingDate: %s", busID, day, booking.BookingID, booking.UserID, booking.UsedCouponID, int(booking.ActualFare), int(booking.FarePostDiscount), booking.BusID, schedule.DepartureStation, schedule.ArrivalStation, booking.BookingDate)
+			refunded := "Your amount has been refunded to your wallet."
+			if issued != nil {
+				refunded = fmt.Sprintf("Rs %.2f is being refunded to the payment it was made with.", rupees(issued.Amount))
+			}
+			message := fmt.Sprintf("The bus %d has been cancelled for the day %s due to unforeseen circumstances. Sorry for the inconvinience caused. %s \n Booking Info \n PNR: %s \n UserID: %d \n UsedCouponID: %d \n Actual Fare: %d \n FarePostDiscount: %d \n BusID: %d \n Departure Location: %s \n Arrival Location: %s \n BookingDate: %s", busID, day, refunded, booking.PNR, booking.UserID, booking.UsedCouponID, int(booking.ActualFare), int(booking.FarePostDiscount), booking.BusID, schedule.DepartureStation, schedule.ArrivalStation, booking.BookingDate)
 			if err := sendCancellationEmail(user.Email, message); err != nil {
 				log.Println("Error sending bus cancellation email, in adminServiceImpl file")
 				result <- err
@@ -145,6 +165,94 @@ func (as *AdminServiceImpl) ViewBookingsPerBus(busID int, day string) ([]*entiti
 	return bookings, nil
 }
 
+// SetScheduleStops implements interfaces.AdminService.
+func (as *AdminServiceImpl) SetScheduleStops(scheduleID int, request *dto.ScheduleStopsRequest) ([]*entities.ScheduleStop, error) {
+	schedule, err := as.repo.GetRouteByBus(scheduleID)
+	if err != nil || schedule.ScheduleID == 0 {
+		log.Println("Schedule not found, in adminServiceImpl file")
+		return nil, errors.New("schedule not found")
+	}
+	stops, err := checkScheduleStops(schedule, request.Stops)
+	if err != nil {
+		log.Println("Invalid stops for the schedule, in adminServiceImpl file")
+		return nil, err
+	}
+	// The sold hops of a chart are tracked by stop position, so the stops cannot move under a chart that already sold part of the route.
+	today, _ := time.Parse("02 01 2006", time.Now().Format("02 01 2006"))
+	sold, err := as.repo.CountSegmentCharts(scheduleID, today)
+	if err != nil {
+		return nil, err
+	}
+	if sold > 0 {
+		log.Println("Schedule has segment bookings, in adminServiceImpl file")
+		return nil, errors.New("schedule has upcoming charts sold by segment")
+	}
+	return as.repo.ReplaceScheduleStops(scheduleID, stops)
+}
+
+// checkScheduleStops function is used to check that the stops run in order from the departure to the arrival station of the schedule and to build them.
+func checkScheduleStops(schedule *entities.Schedule, requested []*dto.ScheduleStop) ([]*entities.ScheduleStop, error) {
+	if len(requested) < 2 || len(requested)-1 > seatmap.MaxHops {
+		return nil, errors.New("a schedule needs between 2 and " + strconv.Itoa(seatmap.MaxHops+1) + " stops")
+	}
+	first, last := requested[0], requested[len(requested)-1]
+	if !strings.EqualFold(first.StationName, schedule.DepartureStation) || !strings.EqualFold(last.StationName, schedule.ArrivalStation) {
+		return nil, errors.New("stops must start at " + schedule.DepartureStation + " and end at " + schedule.ArrivalStation)
+	}
+	stops := make([]*entities.ScheduleStop, 0, len(requested))
+	seen := map[string]bool{}
+	previous := -1
+	for i, stop := range requested {
+		name := strings.ToLower(stop.StationName)
+		if seen[name] {
+			return nil, errors.New("station " + stop.StationName + " is listed twice")
+		}
+		seen[name] = true
+		if i > 0 {
+			arrival, err := time.Parse("15:04:05", stop.ArrivalTime)
+			if err != nil {
+				return nil, errors.New("invalid arrival time at " + stop.StationName)
+			}
+			minutes := stop.DayOffset*24*60 + arrival.Hour()*60 + arrival.Minute()
+			if minutes <= previous {
+				return nil, errors.New("bus reaches " + stop.StationName + " before leaving the previous stop")
+			}
+			if stop.SegmentFare == 0 {
+				return nil, errors.New("missing fare for the hop reaching " + stop.StationName)
+			}
+			previous = minutes
+		}
+		if i < len(requested)-1 {
+			departure, err := time.Parse("15:04:05", stop.DepartureTime)
+			if err != nil {
+				return nil, errors.New("invalid departure time at " + stop.StationName)
+			}
+			minutes := stop.DayOffset*24*60 + departure.Hour()*60 + departure.Minute()
+			if minutes < previous {
+				return nil, errors.New("bus leaves " + stop.StationName + " before reaching it")
+			}
+			previous = minutes
+		}
+		entry := &entities.ScheduleStop{
+			ScheduleID:    schedule.ScheduleID,
+			Sequence:      i,
+			StationName:   stop.StationName,
+			ArrivalTime:   stop.ArrivalTime,
+			DepartureTime: stop.DepartureTime,
+			DayOffset:     stop.DayOffset,
+			SegmentFare:   stop.SegmentFare,
+		}
+		if i == 0 {
+			entry.ArrivalTime, entry.SegmentFare = "", 0
+		}
+		if i == len(requested)-1 {
+			entry.DepartureTime = ""
+		}
+		stops = append(stops, entry)
+	}
+	return stops, nil
+}
+
 // AddFareForRoute implements interfaces.AdminService.
 func (as *AdminServiceImpl) AddFareForRoute(baseFare *entities.BaseFare) (*entities.BaseFare, error) {
 	baseFares, err := as.repo.AddFareForRoute(baseFare)
@@ -157,7 +265,21 @@ func (as *AdminServiceImpl) AddFareForRoute(baseFare *entities.BaseFare) (*entit
 
 // AddBusSchedule implements interfaces.AdminService.
 func (as *AdminServiceImpl) AddBusSchedule(schedule *dto.BusSchedule) (*entities.BusSchedule, error) {
-	schedules, err := as.repo.AddBusSchedule(schedule)
+	parsedDate, err := time.Parse("02 01 2006", schedule.Day)
+	if err != nil {
+		log.Println("Error parsing the date, in adminServiceImpl file")
+		return nil, errors.New("invalid day")
+	}
+	if _, err := as.repo.GetChart(int(schedule.BusID), parsedDate); err == nil {
+		log.Println("Chart already exists, in adminServiceImpl file")
+		return nil, errors.New("chart already exists for the bus on this day")
+	}
+	chart, err := as.emptyChart(schedule.BusID)
+	if err != nil {
+		return nil, err
+	}
+	chart.Day = parsedDate
+	schedules, err := as.repo.AddBusSchedule(chart)
 	if err != nil {
 		log.Println("Error Creating schedule, in adminServiceImpl file")
 		return schedules, err
@@ -165,6 +287,146 @@ func (as *AdminServiceImpl) AddBusSchedule(schedule *dto.BusSchedule) (*entities
 	return schedules, nil
 }
 
+// SetRecurrence implements interfaces.AdminService.
+func (as *AdminServiceImpl) SetRecurrence(request *dto.RecurrenceRequest) (*entities.ScheduleRecurrence, error) {
+	if _, err := recurrence.New(request.Frequency, request.Days, request.ExcludedDates, request.StartDate, request.EndDate); err != nil {
+		log.Println("Invalid recurrence, in adminServiceImpl file")
+		return nil, err
+	}
+	if _, err := as.repo.GetBusInfo(int(request.BusID)); err != nil {
+		log.Println("Bus not found, in adminServiceImpl file")
+		return nil, errors.New("no bus found with this id")
+	}
+	rule := &entities.ScheduleRecurrence{
+		BusID:         request.BusID,
+		Frequency:     request.Frequency,
+		Days:          request.Days,
+		ExcludedDates: request.ExcludedDates,
+		StartDate:     request.StartDate,
+		EndDate:       request.EndDate,
+	}
+	rule, err := as.repo.SaveRecurrence(rule)
+	if err != nil {
+		log.Println("Error saving the recurrence, in adminServiceImpl file")
+		return nil, err
+	}
+	return rule, nil
+}
+
+// FindRecurrence implements interfaces.AdminService.
+func (as *AdminServiceImpl) FindRecurrence(busID int) (*entities.ScheduleRecurrence, error) {
+	rule, err := as.repo.FindRecurrence(busID)
+	if err != nil {
+		log.Println("Recurrence not found, in adminServiceImpl file")
+		return nil, errors.New("no recurrence set for the bus")
+	}
+	return rule, nil
+}
+
+// GenerateCharts implements interfaces.AdminService.
+func (as *AdminServiceImpl) GenerateCharts(busID int, from string, to string) (*dto.ChartGenerationResult, error) {
+	fromDay, err := time.Parse("02 01 2006", from)
+	if err != nil {
+		return nil, errors.New("invalid from date")
+	}
+	toDay, err := time.Parse("02 01 2006", to)
+	if err != nil {
+		return nil, errors.New("invalid to date")
+	}
+	if toDay.Before(fromDay) || toDay.Sub(fromDay) > recurrence.MaxRange {
+		log.Println("Invalid date range, in adminServiceImpl file")
+		return nil, errors.New("date range must run forward and span at most a year")
+	}
+	var rules []*entities.ScheduleRecurrence
+	if busID != 0 {
+		rule, err := as.FindRecurrence(busID)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	} else {
+		rules, err = as.repo.FindAllRecurrences()
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := &dto.ChartGenerationResult{}
+	for _, rule := range rules {
+		if err := as.generateBusCharts(rule, fromDay, toDay, result); err != nil {
+			log.Println("Unable to generate the charts of bus", rule.BusID, err)
+			result.Failed = append(result.Failed, fmt.Sprintf("bus %d: %s", rule.BusID, err.Error()))
+		}
+	}
+	return result, nil
+}
+
+// generateBusCharts function is used to create the missing charts of one bus for the days its recurrence runs on.
+func (as *AdminServiceImpl) generateBusCharts(rule *entities.ScheduleRecurrence, from time.Time, to time.Time, result *dto.ChartGenerationResult) error {
+	runs, err := recurrence.New(rule.Frequency, rule.Days, rule.ExcludedDates, rule.StartDate, rule.EndDate)
+	if err != nil {
+		return err
+	}
+	dates := runs.Dates(from, to)
+	if len(dates) == 0 {
+		return nil
+	}
+	existing, err := as.repo.FindChartDays(int(rule.BusID), from, to)
+	if err != nil {
+		return err
+	}
+	have := map[string]bool{}
+	for _, day := range existing {
+		have[day.Format("02 01 2006")] = true
+	}
+	empty, err := as.emptyChart(rule.BusID)
+	if err != nil {
+		return err
+	}
+	for _, day := range dates {
+		if have[day.Format("02 01 2006")] {
+			result.Skipped++
+			continue
+		}
+		chart := *empty
+		chart.Day = day
+		if _, err := as.repo.AddBusSchedule(&chart); err != nil {
+			return err
+		}
+		result.Created++
+	}
+	return nil
+}
+
+// emptyChart function is used to build a chart with every seat of the bus free, sized from the seat layout of its bus type.
+func (as *AdminServiceImpl) emptyChart(busID uint) (*entities.BusSchedule, error) {
+	bus, err := as.repo.GetBusInfo(int(busID))
+	if err != nil {
+		log.Println("Bus not found, in adminServiceImpl file")
+		return nil, errors.New("no bus found with this id")
+	}
+	busType, err := as.repo.GetBusTypeForProvider(bus.BusTypeCode, bus.ProviderID)
+	if err != nil || busType.SeatLayoutID == 0 {
+		log.Println("No seat layout for the bus type, in adminServiceImpl file")
+		return nil, errors.New("no seat layout assigned to the bus type " + bus.BusTypeCode)
+	}
+	layout, err := as.repo.GetSeatLayout(int(busType.SeatLayoutID))
+	if err != nil {
+		log.Println("Seat layout not found, in adminServiceImpl file")
+		return nil, err
+	}
+	seatMap, err := seatmap.FromLayout(layout, bus.BusTypeCode)
+	if err != nil {
+		log.Println("Error decoding the seat map, in adminServiceImpl file")
+		return nil, err
+	}
+	deckOne, deckTwo, err := seatmap.NewChart(seatMap).Encode()
+	if err != nil {
+		log.Println("Error encoding the chart, in adminServiceImpl file")
+		return nil, err
+	}
+	return &entities.BusSchedule{BusID: bus.BusID, DeckOneSeatLayout: deckOne, DeckTwoSeatLayout: deckTwo, Status: "Active"}, nil
+}
+
 // AddStation implements interfaces.AdminService.
 func (as *AdminServiceImpl) AddStation(station *entities.Stations) (*entities.Stations, error) {
 	stations, err := as.repo.AddStation(station)
@@ -405,10 +667,31 @@ func (as *AdminServiceImpl) UpdateUser(id int, user entities.User) (*entities.Us
 	return updatedUser, nil
 }
 
+// ReconcileLedger implements interfaces.AdminService.
+func (as *AdminServiceImpl) ReconcileLedger() (*ledger.Report, error) {
+	report, err := ledger.Reconcile(as.repo.Ledger())
+	if err != nil {
+		log.Println("Error reconciling the ledger, in adminServiceImpl file")
+		return nil, err
+	}
+	return report, nil
+}
+
+// ViewSuspiciousPayments implements interfaces.AdminService.
+func (as *AdminServiceImpl) ViewSuspiciousPayments() ([]*entities.SuspiciousPayment, error) {
+	attempts, err := as.repo.FindSuspiciousPayments()
+	if err != nil {
+		log.Println("Error fetching the suspicious payments, in adminServiceImpl file")
+		return nil, err
+	}
+	return attempts, nil
+}
+
 // NewAdminService function return AdminServiceImpl of type AdminService interface
-func NewAdminService(repository repository.AdminRepository, jwt *middleware.JwtUtil) service.AdminService {
+func NewAdminService(repository repository.AdminRepository, jwt *middleware.JwtUtil, gateway payment.PaymentGateway) service.AdminService {
 	return &AdminServiceImpl{
-		repo: repository,
-		jwt:  jwt,
+		repo:    repository,
+		jwt:     jwt,
+		gateway: gateway,
 	}
 }
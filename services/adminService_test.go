@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/repository"
+	"gobus/seatmap"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+func Test_GenerateCharts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC)
+	seatMap, _ := seatmap.Default(2, 2, seatmap.Seater, 0, 0, seatmap.Seater).Encode()
+	daily := &entities.ScheduleRecurrence{BusID: 1, Frequency: "daily"}
+	// laidOut expects the lookups building the empty chart of bus 1 from seat layout 4
+	laidOut := func(adminRepo *repository.MockAdminRepository) {
+		adminRepo.EXPECT().GetBusInfo(1).Return(&entities.Buses{BusID: 1, BusTypeCode: "SE", ProviderID: 1}, nil)
+		adminRepo.EXPECT().GetBusTypeForProvider("SE", uint(1)).Return(&entities.BusType{BusTypeCode: "SE", ProviderID: 1, SeatLayoutID: 4}, nil)
+		adminRepo.EXPECT().GetSeatLayout(4).Return(&entities.BusSeatLayout{Model: gorm.Model{ID: 4}, ProviderID: 1, DeckOneRows: 2, DeckOneColumns: 2, SeatMap: seatMap}, nil)
+	}
+	type args struct {
+		busID int
+		from  string
+		to    string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(adminRepo *repository.MockAdminRepository)
+		want       *dto.ChartGenerationResult
+		wantErr    bool
+	}{
+		{
+			name: "success missing days created for every bus",
+			args: args{busID: 0, from: "01 01 2030", to: "03 01 2030"},
+			beforeTest: func(adminRepo *repository.MockAdminRepository) {
+				adminRepo.EXPECT().FindAllRecurrences().Return([]*entities.ScheduleRecurrence{daily}, nil)
+				adminRepo.EXPECT().FindChartDays(1, from, to).Return([]time.Time{from.AddDate(0, 0, 1)}, nil)
+				laidOut(adminRepo)
+				adminRepo.EXPECT().AddBusSchedule(gomock.Any()).DoAndReturn(func(chart *entities.BusSchedule) (*entities.BusSchedule, error) {
+					if chart.BusID != 1 || chart.Status != "Active" || len(chart.DeckOneSeatLayout) == 0 || chart.Day.Equal(from.AddDate(0, 0, 1)) {
+						t.Errorf("services.GenerateCharts() added the chart %+v", chart)
+					}
+					return chart, nil
+				}).Times(2)
+			},
+			want:    &dto.ChartGenerationResult{Created: 2, Skipped: 1},
+			wantErr: false,
+		},
+		{
+			name: "success excluded day left out for one bus",
+			args: args{busID: 1, from: "01 01 2030", to: "03 01 2030"},
+			beforeTest: func(adminRepo *repository.MockAdminRepository) {
+				adminRepo.EXPECT().FindRecurrence(1).Return(&entities.ScheduleRecurrence{BusID: 1, Frequency: "daily", ExcludedDates: pq.StringArray{"02 01 2030"}}, nil)
+				adminRepo.EXPECT().FindChartDays(1, from, to).Return(nil, nil)
+				laidOut(adminRepo)
+				adminRepo.EXPECT().AddBusSchedule(gomock.Any()).DoAndReturn(func(chart *entities.BusSchedule) (*entities.BusSchedule, error) {
+					return chart, nil
+				}).Times(2)
+			},
+			want:    &dto.ChartGenerationResult{Created: 2},
+			wantErr: false,
+		},
+		{
+			name: "bus type without seat layout reported",
+			args: args{busID: 0, from: "01 01 2030", to: "03 01 2030"},
+			beforeTest: func(adminRepo *repository.MockAdminRepository) {
+				adminRepo.EXPECT().FindAllRecurrences().Return([]*entities.ScheduleRecurrence{daily}, nil)
+				adminRepo.EXPECT().FindChartDays(1, from, to).Return(nil, nil)
+				adminRepo.EXPECT().GetBusInfo(1).Return(&entities.Buses{BusID: 1, BusTypeCode: "SE", ProviderID: 1}, nil)
+				adminRepo.EXPECT().GetBusTypeForProvider("SE", uint(1)).Return(&entities.BusType{BusTypeCode: "SE"}, nil)
+			},
+			want:    &dto.ChartGenerationResult{Failed: []string{"bus 1: no seat layout assigned to the bus type SE"}},
+			wantErr: false,
+		},
+		{
+			name: "bus without recurrence",
+			args: args{busID: 1, from: "01 01 2030", to: "03 01 2030"},
+			beforeTest: func(adminRepo *repository.MockAdminRepository) {
+				adminRepo.EXPECT().FindRecurrence(1).Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+		{
+			name:       "range running backwards",
+			args:       args{busID: 0, from: "03 01 2030", to: "01 01 2030"},
+			beforeTest: func(adminRepo *repository.MockAdminRepository) {},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockAdminRepository(ctrl)
+			tt.beforeTest(mockRepo)
+			a := &AdminServiceImpl{repo: mockRepo}
+			got, err := a.GenerateCharts(tt.args.busID, tt.args.from, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.GenerateCharts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.GenerateCharts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"errors"
+	"gobus/boardingpass"
+	"gobus/dto"
+	"gobus/entities"
+	"log"
+	"os"
+	"time"
+)
+
+// ticketSigningKey function returns the key the boarding passes are signed with.
+func ticketSigningKey() []byte {
+	return []byte(os.Getenv("TICKET_SIGNING_KEY"))
+}
+
+// boardingPass function is used to sign the pass of the seats still booked on the booking, it is printed as the QR code of the ticket.
+func boardingPass(booking *entities.Booking, items []*entities.BookingItem) (string, error) {
+	day, err := time.Parse("02 01 2006", booking.BookingDate)
+	if err != nil {
+		return "", err
+	}
+	return boardingpass.Sign(&boardingpass.Pass{BookingID: booking.BookingID, Day: day, Seats: bookedSeats(items)}, ticketSigningKey())
+}
+
+// ScanTicket implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) ScanTicket(request *dto.BoardingScanRequest, email string) (*dto.BoardingScanResponse, error) {
+	bus, day, err := ps.providerTrip(int(request.BusID), request.Date, email)
+	if err != nil {
+		return nil, err
+	}
+	pass, err := boardingpass.Verify(request.Payload, ticketSigningKey())
+	if err != nil {
+		log.Println("Ticket could not be verified, in boarding file")
+		return nil, err
+	}
+	date := day.Format("02 01 2006")
+	if pass.Day.Format("02 01 2006") != date {
+		return nil, errors.New("ticket is for the day " + pass.Day.Format("02 01 2006"))
+	}
+	booking, err := ps.repo.FindBookingByID(int(pass.BookingID))
+	if err != nil {
+		log.Println("Booking of the ticket not found, in boarding file")
+		return nil, errors.New("no booking found for this ticket")
+	}
+	if booking.BusID != bus.BusID || booking.BookingDate != date {
+		return nil, errors.New("ticket is for another trip")
+	}
+	switch booking.Status {
+	case "Success":
+	case "Rescheduled":
+		return nil, errors.New("ticket has been rescheduled to another trip")
+	case "Cancelled by User", "Cancelled by Admin":
+		return nil, errors.New("ticket has been cancelled")
+	default:
+		return nil, errors.New("ticket is not confirmed")
+	}
+	items, err := ps.repo.FindBookingItems([]uint{booking.BookingID})
+	if err != nil {
+		log.Println("Error fetching the booking items, in boarding file")
+		return nil, err
+	}
+	response := &dto.BoardingScanResponse{BookingID: booking.BookingID, PNR: booking.PNR}
+	now := time.Now()
+	for _, seat := range pass.Seats {
+		item := findItem(items, func(item *entities.BookingItem) bool { return item.SeatID == seat })
+		switch {
+		case item == nil:
+			response.Cancelled = append(response.Cancelled, seat)
+		case item.BoardedAt != nil:
+			response.AlreadyBoarded = append(response.AlreadyBoarded, seat)
+		default:
+			boarded, err := ps.repo.MarkBoarded(item.ID, now)
+			if err != nil {
+				return nil, err
+			}
+			if !boarded {
+				response.AlreadyBoarded = append(response.AlreadyBoarded, seat)
+				continue
+			}
+			response.Boarded = append(response.Boarded, seat)
+		}
+	}
+	if len(response.Boarded) == 0 {
+		if len(response.AlreadyBoarded) > 0 {
+			return nil, errors.New("ticket has already been scanned")
+		}
+		return nil, errors.New("every seat of the ticket has been cancelled")
+	}
+	response.Trip, err = ps.tripBoarding(bus, day)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// TripBoarding implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) TripBoarding(busID int, date string, email string) (*dto.TripBoarding, error) {
+	bus, day, err := ps.providerTrip(busID, date, email)
+	if err != nil {
+		return nil, err
+	}
+	return ps.tripBoarding(bus, day)
+}
+
+// providerTrip function is used to check that the bus belongs to the provider and read the day of the trip.
+func (ps *ProviderServiceImpl) providerTrip(busID int, date string, email string) (*entities.Buses, time.Time, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in boarding file")
+		return nil, time.Time{}, err
+	}
+	bus, err := ps.repo.FindBusByID(busID)
+	if err != nil || bus.ProviderID != provider.ProviderID {
+		log.Println("Bus not found for the provider, in boarding file")
+		return nil, time.Time{}, errors.New("no bus found with this id")
+	}
+	day, err := time.ParseInLocation("02 01 2006", date, time.Local)
+	if err != nil {
+		log.Println("Error parsing the date, in boarding file")
+		return nil, time.Time{}, err
+	}
+	return bus, day, nil
+}
+
+// tripBoarding function is used to count the booked seats of the trip by whether they boarded, a seat is a no-show once the bus has left its stop.
+func (ps *ProviderServiceImpl) tripBoarding(bus *entities.Buses, day time.Time) (*dto.TripBoarding, error) {
+	date := day.Format("02 01 2006")
+	bookings, err := ps.repo.FindTripBookings(bus.BusID, date)
+	if err != nil {
+		log.Println("Error fetching the bookings of the trip, in boarding file")
+		return nil, err
+	}
+	ids := make([]uint, 0, len(bookings))
+	from := map[uint]string{}
+	for _, booking := range bookings {
+		ids = append(ids, booking.BookingID)
+		from[booking.BookingID] = booking.FromStation
+	}
+	items, err := ps.repo.FindBookingItems(ids)
+	if err != nil {
+		log.Println("Error fetching the booking items, in boarding file")
+		return nil, err
+	}
+	stops, err := ps.tripStops(bus)
+	if err != nil {
+		return nil, err
+	}
+	trip := &dto.TripBoarding{BusID: bus.BusID, Date: date}
+	now := time.Now()
+	for _, item := range items {
+		if item.Status != ItemBooked {
+			continue
+		}
+		trip.Booked++
+		switch {
+		case item.BoardedAt != nil:
+			trip.Boarded++
+		case now.After(stopDeparture(day, stops, from[item.BookingID])):
+			trip.NoShow++
+		default:
+			trip.YetToBoard++
+		}
+	}
+	return trip, nil
+}
+
+// tripStops function returns the stops of the route of the bus, a schedule without stops is left from its departure station.
+func (ps *ProviderServiceImpl) tripStops(bus *entities.Buses) ([]*entities.ScheduleStop, error) {
+	stops, err := ps.repo.FindScheduleStops(bus.ScheduleID)
+	if err == nil && len(stops) > 0 {
+		return stops, nil
+	}
+	schedule, err := ps.repo.FindScheduleByID(int(bus.ScheduleID))
+	if err != nil {
+		log.Println("Error fetching the schedule, in boarding file")
+		return nil, err
+	}
+	return []*entities.ScheduleStop{{ScheduleID: bus.ScheduleID, StationName: schedule.DepartureStation, DepartureTime: schedule.DepartureTime}}, nil
+}
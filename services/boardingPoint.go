@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"gobus/entities"
+	repository "gobus/repository/interfaces"
+	"gobus/seatmap"
+	"log"
+	"strings"
+	"time"
+)
+
+// Kinds of boarding points, a boarding point is where passengers get on and a dropping point where they get off.
+const (
+	BoardingPointType = "boarding"
+	DroppingPointType = "dropping"
+)
+
+// stopOfPoint function returns the position of the stop the point belongs to on the route, -1 when the bus no longer stops there.
+func stopOfPoint(stops []*entities.ScheduleStop, point *entities.BoardingPoint) int {
+	index, err := stopIndex(stops, point.StopName, -1)
+	if err != nil {
+		return -1
+	}
+	return index
+}
+
+// pointTime function returns the time of day the bus is at the point, that is the time at its stop moved by the offset of the point.
+func pointTime(stop *entities.ScheduleStop, point *entities.BoardingPoint) string {
+	clock := stop.DepartureTime
+	if point.PointType == DroppingPointType || clock == "" {
+		clock = stop.ArrivalTime
+	}
+	if clock == "" {
+		clock = stop.DepartureTime
+	}
+	parsed, err := time.Parse("15:04:05", clock)
+	if err != nil {
+		return ""
+	}
+	return parsed.Add(time.Duration(point.TimeOffset) * time.Minute).Format("15:04:05")
+}
+
+// applyBoardingPoints function is used to check the boarding and dropping points picked for the booking against its bus and segment and to note them on the booking.
+func applyBoardingPoints(repo repository.UserRepository, draft *bookingDraft, inventory *seatInventory, segment seatmap.Segment) error {
+	if id := draft.request.BoardingPointID; id != 0 {
+		point, err := pickPoint(repo, id, draft.bus.BusID, BoardingPointType, inventory.stops[segment.From])
+		if err != nil {
+			return err
+		}
+		draft.booking.BoardingPointID = point.ID
+		draft.booking.BoardingPoint = pointLabel(point)
+		draft.booking.BoardingTime = pointTime(inventory.stops[segment.From], point)
+	}
+	if id := draft.request.DroppingPointID; id != 0 {
+		point, err := pickPoint(repo, id, draft.bus.BusID, DroppingPointType, inventory.stops[segment.To])
+		if err != nil {
+			return err
+		}
+		draft.booking.DroppingPointID = point.ID
+		draft.booking.DroppingPoint = pointLabel(point)
+		draft.booking.DroppingTime = pointTime(inventory.stops[segment.To], point)
+	}
+	return nil
+}
+
+// pickPoint function is used to fetch a point and make sure it is of the given kind and serves the bus at the stop.
+func pickPoint(repo repository.UserRepository, id uint, busID uint, pointType string, stop *entities.ScheduleStop) (*entities.BoardingPoint, error) {
+	point, err := repo.GetBoardingPoint(int(id))
+	if err != nil {
+		log.Println("Boarding point not found, in boardingPoint file")
+		return nil, errors.New("no " + pointType + " point found with this id")
+	}
+	if point.BusID != busID || point.PointType != pointType {
+		log.Println("Boarding point belongs to another bus, in boardingPoint file")
+		return nil, errors.New("not a " + pointType + " point of this bus")
+	}
+	if !strings.EqualFold(point.StopName, stop.StationName) {
+		log.Println("Boarding point is at another stop, in boardingPoint file")
+		return nil, errors.New(pointType + " point must be at " + stop.StationName)
+	}
+	return point, nil
+}
+
+// pointLabel function returns the name of the point followed by its address, as shown on the booking.
+func pointLabel(point *entities.BoardingPoint) string {
+	if point.Address == "" {
+		return point.Name
+	}
+	return point.Name + ", " + point.Address
+}
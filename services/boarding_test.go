@@ -0,0 +1,172 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gobus/boardingpass"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/repository/interfaces"
+	"strings"
+	"testing"
+	"time"
+)
+
+// boardingRepo is an in memory provider repository holding one bus and its bookings.
+type boardingRepo struct {
+	interfaces.ProviderRepository
+	bus      *entities.Buses
+	stops    []*entities.ScheduleStop
+	bookings []*entities.Booking
+	items    []*entities.BookingItem
+}
+
+func (r *boardingRepo) FindProviderByEmail(email string) (*entities.ServiceProvider, error) {
+	if email != "crew@gobus.com" {
+		return &entities.ServiceProvider{ProviderID: 2}, nil
+	}
+	return &entities.ServiceProvider{ProviderID: 1}, nil
+}
+
+func (r *boardingRepo) FindBusByID(id int) (*entities.Buses, error) {
+	if uint(id) != r.bus.BusID {
+		return nil, errors.New("record not found")
+	}
+	return r.bus, nil
+}
+
+func (r *boardingRepo) FindScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error) {
+	return r.stops, nil
+}
+
+func (r *boardingRepo) FindScheduleByID(id int) (*entities.Schedule, error) {
+	return &entities.Schedule{ScheduleID: uint(id), DepartureStation: "Kannur", ArrivalStation: "Bangalore", DepartureTime: "22:00:00", ArrivalTime: "06:00:00"}, nil
+}
+
+func (r *boardingRepo) FindPassengers(ids []uint) ([]*entities.PassengerInfo, error) {
+	var passengers []*entities.PassengerInfo
+	for _, id := range ids {
+		passengers = append(passengers, &entities.PassengerInfo{PassengerID: id, Name: fmt.Sprint("Passenger ", id), Age: 30, Gender: "F"})
+	}
+	return passengers, nil
+}
+
+func (r *boardingRepo) FindBookingByID(id int) (*entities.Booking, error) {
+	for _, booking := range r.bookings {
+		if booking.BookingID == uint(id) {
+			return booking, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *boardingRepo) FindBookingItems(bookingIDs []uint) ([]*entities.BookingItem, error) {
+	var items []*entities.BookingItem
+	for _, item := range r.items {
+		for _, id := range bookingIDs {
+			if item.BookingID == id {
+				copied := *item
+				items = append(items, &copied)
+			}
+		}
+	}
+	return items, nil
+}
+
+func (r *boardingRepo) FindTripBookings(busID uint, day string) ([]*entities.Booking, error) {
+	var bookings []*entities.Booking
+	for _, booking := range r.bookings {
+		if booking.BusID == busID && booking.BookingDate == day && booking.Status == "Success" {
+			bookings = append(bookings, booking)
+		}
+	}
+	return bookings, nil
+}
+
+func (r *boardingRepo) MarkBoarded(itemID uint, at time.Time) (bool, error) {
+	for _, item := range r.items {
+		if item.ID == itemID && item.Status == ItemBooked && item.BoardedAt == nil {
+			item.BoardedAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func Test_ScanTicket(t *testing.T) {
+	t.Setenv("TICKET_SIGNING_KEY", "boarding-secret")
+	today := time.Now()
+	date := today.Format("02 01 2006")
+	repo := &boardingRepo{
+		bus: &entities.Buses{BusID: 1, ProviderID: 1, ScheduleID: 1},
+		// the bus leaves its first stop at the end of the day so nobody is a no-show yet
+		stops: []*entities.ScheduleStop{{StationName: "Kannur", DepartureTime: "23:59:59"}, {StationName: "Bangalore", ArrivalTime: "23:59:59"}},
+		bookings: []*entities.Booking{
+			{BookingID: 1, PNR: "PNRONE2345", BusID: 1, BookingDate: date, Status: "Success"},
+			{BookingID: 2, PNR: "PNRTWO2345", BusID: 1, BookingDate: date, Status: "Cancelled by User"},
+			{BookingID: 3, PNR: "PNRTHREE23", BusID: 1, BookingDate: date, Status: "Success"},
+		},
+		items: []*entities.BookingItem{
+			{ID: 1, BookingID: 1, SeatID: "01A", Status: ItemBooked},
+			{ID: 2, BookingID: 1, SeatID: "01B", Status: ItemBooked},
+			{ID: 3, BookingID: 1, SeatID: "02A", Status: ItemCancelled},
+			{ID: 4, BookingID: 2, SeatID: "03A", Status: ItemCancelled},
+			{ID: 5, BookingID: 3, SeatID: "04A", Status: ItemBooked},
+		},
+	}
+	ps := &ProviderServiceImpl{repo: repo}
+	sign := func(bookingID uint, day time.Time, seats ...string) string {
+		payload, err := boardingpass.Sign(&boardingpass.Pass{BookingID: bookingID, Day: day, Seats: seats}, []byte("boarding-secret"))
+		if err != nil {
+			t.Fatalf("boardingpass.Sign() error = %v", err)
+		}
+		return payload
+	}
+	ticket := sign(1, today, "01A", "01B", "02A")
+
+	scan, err := ps.ScanTicket(&dto.BoardingScanRequest{Payload: ticket, BusID: 1, Date: date}, "crew@gobus.com")
+	if err != nil {
+		t.Fatalf("services.ScanTicket() error = %v", err)
+	}
+	if len(scan.Boarded) != 2 || len(scan.Cancelled) != 1 || scan.Cancelled[0] != "02A" {
+		t.Errorf("services.ScanTicket() boarded %v and skipped %v", scan.Boarded, scan.Cancelled)
+	}
+	if trip := scan.Trip; trip.Booked != 3 || trip.Boarded != 2 || trip.YetToBoard != 1 || trip.NoShow != 0 {
+		t.Errorf("services.ScanTicket() trip = %+v", trip)
+	}
+
+	tests := []struct {
+		name    string
+		request *dto.BoardingScanRequest
+		email   string
+	}{
+		{name: "duplicate scan", request: &dto.BoardingScanRequest{Payload: ticket, BusID: 1, Date: date}},
+		{name: "cancelled booking", request: &dto.BoardingScanRequest{Payload: sign(2, today, "03A"), BusID: 1, Date: date}},
+		{name: "tampered seats", request: &dto.BoardingScanRequest{Payload: strings.Replace(ticket, "01A,01B,02A", "01A,01B,04A", 1), BusID: 1, Date: date}},
+		{name: "signed with another key", request: &dto.BoardingScanRequest{Payload: "GOBUS1|3|" + today.Format("20060102") + "|04A|AAAAAAAAAAAAAAAAAAAAAA", BusID: 1, Date: date}},
+		{name: "another day", request: &dto.BoardingScanRequest{Payload: sign(3, today.AddDate(0, 0, 1), "04A"), BusID: 1, Date: date}},
+		{name: "another provider", request: &dto.BoardingScanRequest{Payload: sign(3, today, "04A"), BusID: 1, Date: date}, email: "other@gobus.com"},
+		{name: "another bus", request: &dto.BoardingScanRequest{Payload: sign(3, today, "04A"), BusID: 2, Date: date}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email := tt.email
+			if email == "" {
+				email = "crew@gobus.com"
+			}
+			if _, err := ps.ScanTicket(tt.request, email); err == nil {
+				t.Errorf("services.ScanTicket() accepted the ticket")
+			}
+		})
+	}
+
+	// once the bus has left, the seats not boarded are no-shows
+	repo.stops[0].DepartureTime = "00:00:00"
+	trip, err := ps.TripBoarding(1, date, "crew@gobus.com")
+	if err != nil {
+		t.Fatalf("services.TripBoarding() error = %v", err)
+	}
+	if trip.Booked != 3 || trip.Boarded != 2 || trip.NoShow != 1 || trip.YetToBoard != 0 {
+		t.Errorf("services.TripBoarding() = %+v", trip)
+	}
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ledger"
+	repository "gobus/repository/interfaces"
+	"gobus/seathold"
+	"log"
+	"math"
+	"sort"
+	"time"
+)
+
+// bookingDraft struct holds a booking checked outside the transaction along with what is needed to reserve it.
+type bookingDraft struct {
+	request  *dto.BookingRequest
+	booking  *entities.Booking
+	bus      *entities.Buses
+	day      time.Time
+	discount int
+	items    []*entities.BookingItem
+}
+
+// draftBooking function is used to validate a booking request and fetch its bus, fare and coupon before any row is locked.
+func (usi *UserServiceImpl) draftBooking(bookreq *dto.BookingRequest, user *entities.User, email string) (*bookingDraft, error) {
+	draft, err := usi.draftTrip(bookreq, user, email)
+	if err != nil {
+		return nil, err
+	}
+	coupon, err := usi.repo.FindCouponByID(int(bookreq.UsedCouponID))
+	if err != nil {
+		log.Println("Error finding coupon, in userServiceImpl file")
+		return nil, err
+	}
+	if !coupon.IsActive {
+		log.Println("Coupon not active or valid, in userServiceImpl file")
+		return nil, errors.New("coupon not active or valid")
+	}
+	draft.booking.UsedCouponID = bookreq.UsedCouponID
+	draft.discount = int(coupon.Discount)
+	return draft, nil
+}
+
+// draftTrip function is used to validate the passengers, bus and day of a booking request, the discount is left for the caller to set.
+func (usi *UserServiceImpl) draftTrip(bookreq *dto.BookingRequest, user *entities.User, email string) (*bookingDraft, error) {
+	if !bookreq.Waitlist && len(bookreq.PassengerID) != len(bookreq.SeatsReserved) {
+		log.Println("Error seat-passenger mismatch, in userServiceImpl file")
+		return nil, errors.New("seat-passenger count mismatch")
+	}
+	booking := &entities.Booking{}
+	booking.BookingDate = bookreq.BookingDate
+	booking.SeatReserved = bookreq.SeatsReserved
+	booking.BusID = bookreq.BusID
+	booking.UserID = user.ID
+	booking.PaymentType = bookreq.PreferredPaymentType
+	passengers, _ := usi.repo.ViewAllPassengers(email)
+	known := map[int64]bool{}
+	for _, passenger := range passengers {
+		known[int64(passenger.PassengerID)] = true
+	}
+	for _, passengerID := range bookreq.PassengerID {
+		if !known[passengerID] {
+			log.Println("Passenger Id not valid, in userServiceImpl file")
+			return nil, errors.New("unknown passenger Id provided")
+		}
+	}
+	booking.PassengerID = bookreq.PassengerID
+	//Getting bus info
+	bus, err := usi.repo.GetBusInfo(int(bookreq.BusID))
+	if err != nil {
+		log.Println("Error fetching bus details, in userServiceImpl file")
+		return nil, err
+	}
+	parsedDate, err := time.Parse("02 01 2006", bookreq.BookingDate)
+	if err != nil {
+		log.Println("Error parsing the date, in userServiceImpl file")
+		return nil, err
+	}
+	booking.Status = "Awaiting Payment"
+	booking.PNR = newPNR()
+	return &bookingDraft{
+		request: bookreq,
+		booking: booking,
+		bus:     bus,
+		day:     parsedDate,
+	}, nil
+}
+
+// bookingDiscount function returns the discount in percent the fares of the booking were given.
+func bookingDiscount(booking *entities.Booking) int {
+	if booking.ActualFare <= 0 {
+		return 0
+	}
+	return int(math.Round(100 - booking.FarePostDiscount*100/booking.ActualFare))
+}
+
+// commitDrafts function is used to reserve the seats of every draft, take the payment from the wallet when asked and store the bookings in one transaction, nothing is booked when any draft fails.
+func (usi *UserServiceImpl) commitDrafts(user *entities.User, drafts []*bookingDraft, payByWallet bool) ([]*entities.Booking, error) {
+	//Charts are locked in the same order by every caller so two itineraries sharing buses cannot deadlock.
+	locking := append([]*bookingDraft{}, drafts...)
+	sort.SliceStable(locking, func(i, j int) bool {
+		if locking[i].bus.BusID != locking[j].bus.BusID {
+			return locking[i].bus.BusID < locking[j].bus.BusID
+		}
+		return locking[i].day.Before(locking[j].day)
+	})
+	holdDuration := seathold.HoldDuration()
+	var booked []*entities.Booking
+	err := usi.repo.WithTx(func(tx repository.UserRepository) error {
+		booked = nil
+		for _, draft := range locking {
+			if err := reserveDraft(tx, draft); err != nil {
+				return err
+			}
+		}
+		var payable []*bookingDraft
+		for _, draft := range drafts {
+			if draft.booking.Status == "Awaiting Payment" {
+				payable = append(payable, draft)
+			}
+		}
+		if payByWallet && len(payable) > 0 {
+			if err := payFromWallet(tx, user, payable); err != nil {
+				return err
+			}
+		}
+		for _, draft := range drafts {
+			booking := *draft.booking
+			if booking.Status == "Awaiting Payment" {
+				holdExpiry := time.Now().Add(holdDuration)
+				booking.HoldExpiresAt = &holdExpiry
+			}
+			made, err := tx.MakeBooking(&booking)
+			if err != nil {
+				log.Println("Unable to make the booking, in userServiceImpl file")
+				return err
+			}
+			if made.Status == "Success" {
+				if err := postWalletPayment(tx, made); err != nil {
+					return err
+				}
+			}
+			for _, item := range draft.items {
+				item.BookingID = made.BookingID
+			}
+			if len(draft.items) > 0 {
+				if err := tx.AddBookingItems(draft.items); err != nil {
+					log.Println("Unable to store the booking items, in userServiceImpl file")
+					return err
+				}
+			}
+			booked = append(booked, made)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, booking := range booked {
+		if booking.Status == "Awaiting Payment" {
+			if err := usi.hold.Hold(booking.BookingID, holdDuration); err != nil {
+				log.Println("Unable to place the seat hold, in userServiceImpl file")
+			}
+		}
+		if booking.Status == "Waitlisted" {
+			smsNotifier(fmt.Sprintf("You are on the %s waitlist of the bus %d for the day %s, PNR %s.", booking.SeatClass, booking.BusID, booking.BookingDate, booking.PNR), user.PhoneNumber)
+			continue
+		}
+		message := fmt.Sprintf("The seats %s of the bus %d has been booked for the day %s, PNR %s.", booking.SeatReserved[:], booking.BusID, booking.BookingDate, booking.PNR)
+		if booking.BoardingPoint != "" {
+			message += fmt.Sprintf(" Board at %s at %s.", booking.BoardingPoint, booking.BoardingTime)
+		}
+		if booking.DroppingPoint != "" {
+			message += fmt.Sprintf(" Drop at %s at %s.", booking.DroppingPoint, booking.DroppingTime)
+		}
+		smsNotifier(message, user.PhoneNumber)
+		if booking.Status == "Success" {
+			usi.mailTicket(booking)
+		}
+	}
+	return booked, nil
+}
+
+// reserveDraft function is used to lock the chart of the draft, reserve its seats and price them by seat class.
+func reserveDraft(tx repository.UserRepository, draft *bookingDraft) error {
+	chart, err := tx.GetChartForUpdate(int(draft.bus.BusID), draft.day)
+	if err != nil {
+		log.Println("Error fetching bus schedule, in userServiceImpl file")
+		return err
+	}
+	if chart.Status != "Active" {
+		log.Println("Bus Schedule seems to be cancelled or Inactive, in userServiceImpl file")
+		return errors.New("schedule not in active state")
+	}
+	inventory, err := loadInventory(tx, draft.bus, chart)
+	if err != nil {
+		return err
+	}
+	segment, err := inventory.segment(draft.request.FromStation, draft.request.ToStation)
+	if err != nil {
+		log.Println("Invalid stops for the bus, in userServiceImpl file")
+		return err
+	}
+	draft.booking.FromStation = inventory.stops[segment.From].StationName
+	draft.booking.ToStation = inventory.stops[segment.To].StationName
+	if err := applyBoardingPoints(tx, draft, inventory, segment); err != nil {
+		return err
+	}
+	if draft.request.Waitlist {
+		return waitlistDraft(draft, inventory, segment)
+	}
+	seats, err := inventory.chart.ReserveSegment(inventory.seatMap, draft.request.SeatsReserved, segment)
+	if err != nil {
+		log.Println("Seat you are trying to book is already reserved or invalid seat entered, in userServiceImpl file")
+		if len(inventory.chart.AvailableFor(inventory.seatMap, segment)) < len(draft.request.SeatsReserved) {
+			return errors.New("not enough seats left, join the waitlist instead")
+		}
+		return err
+	}
+	if err := inventory.store(chart); err != nil {
+		return err
+	}
+	baseFare := inventory.segmentFare(segment)
+	totalFare := 0.0
+	fares := make([]float64, 0, len(seats))
+	for _, seat := range seats {
+		fares = append(fares, seatFare(baseFare, draft.bus.BusTypeCode, seat.Class))
+		totalFare += fares[len(fares)-1]
+	}
+	draft.items = newBookingItems(draft.request.PassengerID, draft.request.SeatsReserved, fares, draft.discount)
+	draft.booking.ActualFare = totalFare
+	draft.booking.FarePostDiscount = totalFare * float64((100-float64(draft.discount))/100)
+	if _, err := tx.UpdateChart(chart); err != nil {
+		log.Println("Could not update the chart, in userService file")
+		return err
+	}
+	return nil
+}
+
+// payFromWallet function is used to pay every draft from the user wallet, the drafts stay awaiting payment when the wallet cannot cover all of them.
+func payFromWallet(tx repository.UserRepository, user *entities.User, drafts []*bookingDraft) error {
+	balance, err := walletBalance(tx, user.ID)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, draft := range drafts {
+		total += ledger.Paisa(draft.booking.FarePostDiscount)
+	}
+	if total > balance {
+		log.Println("Insuffucient fund to make the booking using wallet,Redirecting to RazorPay.")
+		return nil
+	}
+	for _, draft := range drafts {
+		draft.booking.Status = "Success"
+	}
+	return nil
+}
+
+// postWalletPayment function is used to move the fare of a booking paid from the wallet into the escrow of its trip, once the booking has its id.
+func postWalletPayment(tx repository.UserRepository, booking *entities.Booking) error {
+	escrow, err := tripEscrow(booking)
+	if err != nil {
+		return err
+	}
+	return postEntry(tx.Ledger(), ledger.Transfer(paymentKey(booking.BookingID), ledger.KindPayment, booking.BookingID, "Fare paid from the wallet", ledger.UserAccount(booking.UserID), escrow, ledger.Paisa(booking.FarePostDiscount)))
+}
+
+// newItineraryRef function is used to generate the reference shared by the bookings of one itinerary.
+func newItineraryRef() string {
+	ref := make([]byte, 8)
+	rand.Read(ref)
+	return hex.EncodeToString(ref)
+}
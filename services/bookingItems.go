@@ -0,0 +1,324 @@
+package services
+
+import (
+	"errors"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ledger"
+	repository "gobus/repository/interfaces"
+	"log"
+	"strings"
+	"time"
+)
+
+// Statuses of a booking item.
+const (
+	ItemBooked    = "Booked"
+	ItemCancelled = "Cancelled"
+)
+
+// newBookingItems function is used to pair the passengers of a booking with their seats and split the fare over them.
+func newBookingItems(passengerIDs []int64, seats []string, fares []float64, discount int) []*entities.BookingItem {
+	var items []*entities.BookingItem
+	for i, seat := range seats {
+		item := &entities.BookingItem{SeatID: seat, Status: ItemBooked}
+		if i < len(passengerIDs) {
+			item.PassengerID = uint(passengerIDs[i])
+		}
+		if i < len(fares) {
+			item.Fare = fares[i]
+			item.FarePostDiscount = fares[i] * float64((100-float64(discount))/100)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// bookingItems function returns the items of the booking, a booking made before items were kept gets them built from its seats and fare.
+func bookingItems(tx repository.UserRepository, booking *entities.Booking) ([]*entities.BookingItem, error) {
+	items, err := tx.FindBookingItems([]uint{booking.BookingID})
+	if err != nil {
+		log.Println("Error fetching the booking items, in bookingItems file")
+		return nil, err
+	}
+	if len(items) > 0 || len(booking.SeatReserved) == 0 {
+		return items, nil
+	}
+	count := float64(len(booking.SeatReserved))
+	items = newBookingItems(booking.PassengerID, booking.SeatReserved, nil, 0)
+	for _, item := range items {
+		item.BookingID = booking.BookingID
+		item.Fare = booking.ActualFare / count
+		item.FarePostDiscount = booking.FarePostDiscount / count
+	}
+	if err := tx.AddBookingItems(items); err != nil {
+		log.Println("Error storing the booking items, in bookingItems file")
+		return nil, err
+	}
+	return items, nil
+}
+
+// bookedSeats function returns the seats of the items that are still booked.
+func bookedSeats(items []*entities.BookingItem) []string {
+	var seats []string
+	for _, item := range items {
+		if item.Status == ItemBooked {
+			seats = append(seats, item.SeatID)
+		}
+	}
+	return seats
+}
+
+// pickItems function is used to find the booked items matching the seats and passengers asked for, nothing asked means every booked item.
+func pickItems(items []*entities.BookingItem, seats []string, passengerIDs []uint) ([]*entities.BookingItem, error) {
+	if len(seats) == 0 && len(passengerIDs) == 0 {
+		var picked []*entities.BookingItem
+		for _, item := range items {
+			if item.Status == ItemBooked {
+				picked = append(picked, item)
+			}
+		}
+		return picked, nil
+	}
+	wanted := map[*entities.BookingItem]bool{}
+	for _, seat := range seats {
+		item := findItem(items, func(item *entities.BookingItem) bool { return strings.EqualFold(item.SeatID, strings.TrimSpace(seat)) })
+		if item == nil {
+			return nil, errors.New("seat " + seat + " is not booked on this booking")
+		}
+		wanted[item] = true
+	}
+	for _, passengerID := range passengerIDs {
+		item := findItem(items, func(item *entities.BookingItem) bool { return item.PassengerID == passengerID })
+		if item == nil {
+			return nil, errors.New("passenger is not travelling on this booking")
+		}
+		wanted[item] = true
+	}
+	var picked []*entities.BookingItem
+	for _, item := range items {
+		if wanted[item] {
+			picked = append(picked, item)
+		}
+	}
+	return picked, nil
+}
+
+func findItem(items []*entities.BookingItem, match func(item *entities.BookingItem) bool) *entities.BookingItem {
+	for _, item := range items {
+		if item.Status == ItemBooked && match(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+// CancelSeats implements interfaces.UserService.
+func (usi *UserServiceImpl) CancelSeats(bookID int, request *dto.SeatCancelRequest, email string) (*entities.Booking, error) {
+	user, err := usi.repo.FindUserByEmail(email)
+	if err != nil {
+		log.Println("Error finding user, in bookingItems file")
+		return nil, err
+	}
+	if len(request.Seats) == 0 && len(request.PassengerIDs) == 0 {
+		return nil, errors.New("pick the seats or passengers to cancel")
+	}
+	return usi.cancelItems(bookID, user.ID, request.Seats, request.PassengerIDs, request.RefundTo)
+}
+
+// cancelItems function is used to cancel the picked items of a booking, free their seats, refund their share of the paid fare as the cancellation policy allows to the wallet or the payment, and cancel the booking once no item is left, only the user who made the booking can cancel it.
+func (usi *UserServiceImpl) cancelItems(bookID int, userID uint, seats []string, passengerIDs []uint, refundTo string) (*entities.Booking, error) {
+	if _, err := refundDestination(refundTo, nil); err != nil {
+		return nil, err
+	}
+	booking, err := usi.repo.FindBookingByID(bookID)
+	if err != nil {
+		log.Println("Error finding booking that has to be cancelled, in userServiceImpl file")
+		return nil, err
+	}
+	if booking.UserID != userID {
+		log.Println("Booking belongs to another user, in bookingItems file")
+		return nil, errors.New("no booking found with this id")
+	}
+	parsedDate, err := time.Parse("02 01 2006", booking.BookingDate)
+	if err != nil {
+		log.Println("Error parsing the date, in userServiceImpl file")
+		return nil, err
+	}
+	partial := len(seats) > 0 || len(passengerIDs) > 0
+	var cancelledBooking *entities.Booking
+	var user *entities.User
+	var promoted []*entities.Booking
+	var issued *entities.Refund
+	refund := 0.0
+	err = usi.repo.WithTx(func(tx repository.UserRepository) error {
+		//Getting bus chart
+		chart, err := tx.GetChartForUpdate(int(booking.BusID), parsedDate)
+		if err != nil {
+			log.Println("Error fetching bus schedule, in userServiceImpl file")
+			return err
+		}
+		booking, err = tx.FindBookingByID(bookID)
+		if err != nil {
+			log.Println("Error finding booking that has to be cancelled, in userServiceImpl file")
+			return err
+		}
+		if booking.Status == "Waitlisted" {
+			if partial {
+				return errors.New("a waitlisted booking can only be cancelled as a whole")
+			}
+			booking.Status = "Cancelled by User"
+			cancelledBooking, err = tx.CancelBooking(booking)
+			return err
+		}
+		if booking.Status != "Success" && booking.Status != "Awaiting Payment" {
+			log.Println("Booking is not in a cancellable state, in userServiceImpl file")
+			return errors.New("booking cannot be cancelled")
+		}
+		items, err := bookingItems(tx, booking)
+		if err != nil {
+			return err
+		}
+		picked, err := pickItems(items, seats, passengerIDs)
+		if err != nil {
+			return err
+		}
+		if len(picked) == 0 {
+			return errors.New("nothing left to cancel on this booking")
+		}
+		freed := bookedSeats(picked)
+		if err := releaseSeats(tx, chart, booking, freed); err != nil {
+			return err
+		}
+		if _, err := tx.UpdateChart(chart); err != nil {
+			log.Println("Could not update the chart, in userService file")
+			return err
+		}
+		terms, err := cancellationTerms(tx, booking)
+		if err != nil {
+			return err
+		}
+		var charge float64
+		refund, charge = terms.refundOf(booking, picked)
+		booking.RefundAmount += refund
+		booking.CancellationCharge += charge
+		booking.RefundPercent = terms.quote.RefundPercent
+		booking.RefundRule = terms.quote.Rule
+		share := paidShare(booking)
+		now := time.Now()
+		for _, item := range picked {
+			item.RefundAmount = terms.quote.Refund(item.FarePostDiscount * share)
+			item.Status = ItemCancelled
+			item.CancelledAt = &now
+			if err := tx.UpdateBookingItem(item); err != nil {
+				log.Println("Could not cancel the booking item, in bookingItems file")
+				return err
+			}
+		}
+		if refund > 0 {
+			user, issued, err = refundCancelled(tx, booking, refund, cancelKey(booking.BookingID, picked), refundTo)
+			if err != nil {
+				return err
+			}
+		}
+		if len(bookedSeats(items)) == 0 {
+			booking.Status = "Cancelled by User"
+			booking.HoldExpiresAt = nil
+		} else {
+			// the fares of a partly cancelled booking only cover the seats still booked
+			for _, item := range picked {
+				booking.ActualFare -= item.Fare
+				booking.FarePostDiscount -= item.FarePostDiscount
+				if booking.Status != "Success" {
+					booking.RescheduleCredit -= item.FarePostDiscount * share
+				}
+			}
+		}
+		cancelledBooking, err = tx.CancelBooking(booking)
+		if err != nil {
+			log.Println("unable to cancel the booking, in userServiceImpl file")
+			return err
+		}
+		cancelledBooking.Items = items
+		promoted, err = promoteWaitlist(tx, chart, booking.BookingDate)
+		if err != nil {
+			log.Println("Unable to promote the waitlist, in userServiceImpl file")
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	usi.notifyPromoted(promoted)
+	if cancelledBooking.Status == "Cancelled by User" {
+		if err := usi.hold.Release(cancelledBooking.BookingID); err != nil {
+			log.Println("Error releasing the seat hold, in userServiceImpl file")
+		}
+	}
+	if issued != nil {
+		if sent, err := issueRefund(usi.repo.Refunds(), usi.gateway, issued.ID); err == nil {
+			issued = sent
+		}
+	}
+	if refund > 0 {
+		smsNotifier(refundNotice(cancelledBooking.PNR, issued), user.PhoneNumber)
+	}
+	return cancelledBooking, nil
+}
+
+// refundToWallet function is used to move the refund of a booking from the escrow of its trip, or the provider wallet once paid out, back to the user wallet, the key posts the refund once.
+func refundToWallet(tx repository.UserRepository, booking *entities.Booking, refund float64, key string) (*entities.User, error) {
+	user, _, err := refundCancelled(tx, booking, refund, key, RefundToWallet)
+	return user, err
+}
+
+// refundCancelled function is used to move the refund of a booking from the escrow of its trip, or the provider wallet once paid out, to the wallet or the payment as asked, the key posts the refund once. It returns the refund left to issue through the gateway, if any.
+func refundCancelled(tx repository.UserRepository, booking *entities.Booking, refund float64, key string, requested string) (*entities.User, *entities.Refund, error) {
+	bus, err := tx.GetBusInfo(int(booking.BusID))
+	if err != nil {
+		log.Println("Error fetching bus details, in userServiceImpl file")
+		return nil, nil, err
+	}
+	source, err := refundSource(tx.Ledger(), booking, bus.ProviderID, ledger.Paisa(refund))
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := tx.GetUserInfo(int(booking.UserID))
+	if err != nil {
+		log.Println("Error fetching the user info, in userServiceImpl file")
+		return nil, nil, err
+	}
+	destination, err := refundDestination(requested, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	issued, err := refundBooking(tx.Refunds(), booking, source, ledger.Paisa(refund), key, destination)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, issued, nil
+}
+
+// setBookingItems function is used to attach the items to the bookings listed to the user.
+func setBookingItems(repo repository.UserRepository, bookings []*entities.Booking) {
+	if len(bookings) == 0 {
+		return
+	}
+	ids := make([]uint, 0, len(bookings))
+	for _, booking := range bookings {
+		ids = append(ids, booking.BookingID)
+	}
+	items, err := repo.FindBookingItems(ids)
+	if err != nil {
+		log.Println("Error fetching the booking items, in bookingItems file")
+		return
+	}
+	byBooking := map[uint][]*entities.BookingItem{}
+	for _, item := range items {
+		byBooking[item.BookingID] = append(byBooking[item.BookingID], item)
+	}
+	for _, booking := range bookings {
+		booking.Items = byBooking[booking.BookingID]
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"errors"
+	"gobus/cancellation"
+	"gobus/dto"
+	"gobus/entities"
+	repository "gobus/repository/interfaces"
+	"log"
+	"time"
+)
+
+// SetCancellationPolicy implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) SetCancellationPolicy(request *dto.CancellationPolicyRequest, email string) (*dto.CancellationPolicyResponse, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in cancellationPolicy file")
+		return nil, err
+	}
+	if request.BusID != 0 {
+		bus, err := ps.repo.FindBusByID(int(request.BusID))
+		if err != nil || bus.ProviderID != provider.ProviderID {
+			log.Println("Bus not found for the provider, in cancellationPolicy file")
+			return nil, errors.New("no bus found with this id")
+		}
+	}
+	tiers := make([]cancellation.Tier, 0, len(request.Tiers))
+	for _, tier := range request.Tiers {
+		tiers = append(tiers, cancellation.Tier{HoursBefore: tier.HoursBefore, RefundPercent: tier.RefundPercent})
+	}
+	policy, err := cancellation.New(tiers)
+	if err != nil {
+		log.Println("Invalid cancellation policy, in cancellationPolicy file")
+		return nil, err
+	}
+	encoded, err := policy.Encode()
+	if err != nil {
+		return nil, err
+	}
+	saved, err := ps.repo.SaveCancellationPolicy(&entities.CancellationPolicy{
+		ProviderID: provider.ProviderID,
+		BusID:      request.BusID,
+		Tiers:      encoded,
+	})
+	if err != nil {
+		log.Println("Error saving the cancellation policy, in cancellationPolicy file")
+		return nil, err
+	}
+	return cancellationPolicyResponse(saved, policy), nil
+}
+
+// FindCancellationPolicies implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) FindCancellationPolicies(email string) ([]*dto.CancellationPolicyResponse, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in cancellationPolicy file")
+		return nil, err
+	}
+	policies, err := ps.repo.FindCancellationPolicies(provider.ProviderID)
+	if err != nil {
+		log.Println("Error fetching the cancellation policies, in cancellationPolicy file")
+		return nil, err
+	}
+	responses := []*dto.CancellationPolicyResponse{}
+	for _, stored := range policies {
+		policy, err := cancellation.Decode(stored.Tiers)
+		if err != nil {
+			log.Println("Error decoding the cancellation policy, in cancellationPolicy file")
+			continue
+		}
+		responses = append(responses, cancellationPolicyResponse(stored, policy))
+	}
+	return responses, nil
+}
+
+func cancellationPolicyResponse(stored *entities.CancellationPolicy, policy *cancellation.Policy) *dto.CancellationPolicyResponse {
+	response := &dto.CancellationPolicyResponse{ID: stored.ID, ProviderID: stored.ProviderID, BusID: stored.BusID}
+	for _, tier := range policy.Tiers {
+		response.Tiers = append(response.Tiers, dto.RefundTier{HoursBefore: tier.HoursBefore, RefundPercent: tier.RefundPercent})
+		response.Rules = append(response.Rules, tier.String())
+	}
+	return response
+}
+
+// refundTerms struct holds what the policy of the bus gives for cancelling a booking now.
+type refundTerms struct {
+	departure time.Time
+	quote     cancellation.Quote
+}
+
+// cancellationTerms function is used to evaluate the cancellation policy of the bus of the booking against the time it leaves the boarding stop.
+func cancellationTerms(repo repository.UserRepository, booking *entities.Booking) (*refundTerms, error) {
+	bus, err := repo.GetBusInfo(int(booking.BusID))
+	if err != nil {
+		log.Println("Error fetching bus details, in cancellationPolicy file")
+		return nil, err
+	}
+	policy := cancellation.Default()
+	if stored, err := repo.GetCancellationPolicy(bus.ProviderID, bus.BusID); err == nil {
+		if policy, err = cancellation.Decode(stored.Tiers); err != nil {
+			log.Println("Error decoding the cancellation policy, in cancellationPolicy file")
+			return nil, err
+		}
+	}
+	departure, err := departureTime(repo, bus, booking)
+	if err != nil {
+		return nil, err
+	}
+	return &refundTerms{departure: departure, quote: policy.Evaluate(departure, time.Now())}, nil
+}
+
+// departureTime function returns when the bus of the booking leaves the stop the booking starts at.
+func departureTime(repo repository.UserRepository, bus *entities.Buses, booking *entities.Booking) (time.Time, error) {
+	day, err := time.ParseInLocation("02 01 2006", booking.BookingDate, time.Local)
+	if err != nil {
+		log.Println("Error parsing the date, in cancellationPolicy file")
+		return time.Time{}, err
+	}
+	stops, err := routeStops(repo, bus.ScheduleID, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return stopDeparture(day, stops, booking.FromStation), nil
+}
+
+// stopDeparture function returns when the bus leaves the station on the day, a station off the route stands for the first stop.
+func stopDeparture(day time.Time, stops []*entities.ScheduleStop, station string) time.Time {
+	from, err := stopIndex(stops, station, 0)
+	if err != nil {
+		from = 0
+	}
+	clock := stops[from].DepartureTime
+	if clock == "" {
+		clock = stops[from].ArrivalTime
+	}
+	return day.Add(time.Duration(stopMinutes(clock, stops[from].DayOffset)) * time.Minute)
+}
+
+// paidShare function returns the part of the fare of the booking already paid, a rescheduled booking awaiting payment has paid its credit and an unpaid one nothing.
+func paidShare(booking *entities.Booking) float64 {
+	if booking.Status == "Success" {
+		return 1
+	}
+	if booking.RescheduleCredit <= 0 || booking.FarePostDiscount <= 0 {
+		return 0
+	}
+	return booking.RescheduleCredit / booking.FarePostDiscount
+}
+
+// refundOf function returns the refund and the cancellation charge of the items out of what has been paid for them.
+func (terms *refundTerms) refundOf(booking *entities.Booking, items []*entities.BookingItem) (float64, float64) {
+	share := paidShare(booking)
+	refund, charge := 0.0, 0.0
+	for _, item := range items {
+		paid := item.FarePostDiscount * share
+		itemRefund := terms.quote.Refund(paid)
+		refund += itemRefund
+		charge += paid - itemRefund
+	}
+	return refund, charge
+}
+
+// RefundQuote implements interfaces.UserService.
+func (usi *UserServiceImpl) RefundQuote(bookID int, request *dto.SeatCancelRequest, email string) (*dto.RefundQuote, error) {
+	user, err := usi.repo.FindUserByEmail(email)
+	if err != nil {
+		log.Println("Error finding user, in cancellationPolicy file")
+		return nil, err
+	}
+	booking, err := usi.repo.FindBookingByID(bookID)
+	if err != nil || booking.UserID != user.ID {
+		log.Println("Booking not found for the user, in cancellationPolicy file")
+		return nil, errors.New("no booking found with this id")
+	}
+	if booking.Status != "Success" && booking.Status != "Awaiting Payment" && booking.Status != "Waitlisted" {
+		return nil, errors.New("booking cannot be cancelled")
+	}
+	terms, err := cancellationTerms(usi.repo, booking)
+	if err != nil {
+		return nil, err
+	}
+	quote := &dto.RefundQuote{
+		BookingID:        booking.BookingID,
+		Departure:        terms.departure.Format("02 01 2006 15:04"),
+		HoursToDeparture: terms.quote.HoursLeft,
+		Rule:             terms.quote.Rule,
+		RefundPercent:    terms.quote.RefundPercent,
+	}
+	if booking.Status == "Waitlisted" {
+		return quote, nil
+	}
+	items, err := usi.repo.FindBookingItems([]uint{booking.BookingID})
+	if err != nil {
+		log.Println("Error fetching the booking items, in cancellationPolicy file")
+		return nil, err
+	}
+	if len(items) == 0 {
+		// a booking made before items were kept is quoted as a whole, as it would be cancelled
+		items = newBookingItems(booking.PassengerID, booking.SeatReserved, nil, 0)
+		for _, item := range items {
+			item.FarePostDiscount = booking.FarePostDiscount / float64(len(items))
+		}
+	}
+	picked, err := pickItems(items, request.Seats, request.PassengerIDs)
+	if err != nil {
+		return nil, err
+	}
+	quote.Seats = bookedSeats(picked)
+	for _, item := range picked {
+		quote.PaidAmount += item.FarePostDiscount * paidShare(booking)
+	}
+	quote.RefundAmount, quote.CancellationCharge = terms.refundOf(booking, picked)
+	return quote, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_RefundQuote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	soon := time.Now().AddDate(0, 0, 2).Format("02 01 2006")
+	booking := func(userID uint, status string) *entities.Booking {
+		return &entities.Booking{BookingID: 1, UserID: userID, BusID: 1, BookingDate: soon, SeatReserved: []string{"01A"}, FarePostDiscount: 500, Status: status}
+	}
+	tests := []struct {
+		name       string
+		beforeTest func(userRepo *repository.MockUserRepository)
+		want       *dto.RefundQuote
+		wantErr    bool
+	}{
+		{
+			name: "success 75% two days ahead",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1}, nil)
+				userRepo.EXPECT().FindBookingByID(1).Return(booking(1, "Success"), nil)
+				userRepo.EXPECT().FindBookingItems([]uint{1}).Return([]*entities.BookingItem{{ID: 1, BookingID: 1, PassengerID: 1, SeatID: "01A", Fare: 500, FarePostDiscount: 500, Status: ItemBooked}}, nil)
+			},
+			want:    &dto.RefundQuote{BookingID: 1, Seats: []string{"01A"}, Rule: "75% refund from 24h before departure", RefundPercent: 75, PaidAmount: 500, CancellationCharge: 125, RefundAmount: 375},
+			wantErr: false,
+		},
+		{
+			name: "success waitlisted booking has nothing paid to quote",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1}, nil)
+				userRepo.EXPECT().FindBookingByID(1).Return(booking(1, "Waitlisted"), nil)
+			},
+			want:    &dto.RefundQuote{BookingID: 1, Rule: "75% refund from 24h before departure", RefundPercent: 75},
+			wantErr: false,
+		},
+		{
+			name: "booking of another user",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1}, nil)
+				userRepo.EXPECT().FindBookingByID(1).Return(booking(2, "Success"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "booking cancelled before",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1}, nil)
+				userRepo.EXPECT().FindBookingByID(1).Return(booking(1, "Cancelled by User"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no user",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			expectRoute(mockRepo)
+			tt.beforeTest(mockRepo)
+			u := &UserServiceImpl{repo: mockRepo}
+			got, err := u.RefundQuote(1, &dto.SeatCancelRequest{}, "abc@gmail.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.RefundQuote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.BookingID != tt.want.BookingID || len(got.Seats) != len(tt.want.Seats) || got.Rule != tt.want.Rule || got.RefundPercent != tt.want.RefundPercent ||
+				got.PaidAmount != tt.want.PaidAmount || got.CancellationCharge != tt.want.CancellationCharge || got.RefundAmount != tt.want.RefundAmount {
+				t.Errorf("services.RefundQuote() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"gobus/dto"
 	"gobus/entities"
+	"gobus/ledger"
 )
 
 // AdminService inteface is used as an interface for AdminServiceImplementation.
@@ -27,8 +28,24 @@ type AdminService interface {
 	DeleteStation(id int) (*entities.Stations, error)
 	AddStation(station *entities.Stations) (*entities.Stations, error)
 	AddBusSchedule(schedule *dto.BusSchedule) (*entities.BusSchedule, error)
+	SetRecurrence(request *dto.RecurrenceRequest) (*entities.ScheduleRecurrence, error)
+	FindRecurrence(busID int) (*entities.ScheduleRecurrence, error)
+	GenerateCharts(busID int, from string, to string) (*dto.ChartGenerationResult, error)
 	AddFareForRoute(baseFare *entities.BaseFare) (*entities.BaseFare, error)
+	SetScheduleStops(scheduleID int, request *dto.ScheduleStopsRequest) ([]*entities.ScheduleStop, error)
 	ViewAllBookings() ([]*entities.Booking, error)
 	ViewBookingsPerBus(busID int, day string) ([]*entities.Booking, error)
 	CancelBus(busID int, day string) (string, error)
+	ReconcileLedger() (*ledger.Report, error)
+	ViewSuspiciousPayments() ([]*entities.SuspiciousPayment, error)
+	SetCommission(providerID int, request *dto.CommissionRequest) (*entities.ProviderCommission, error)
+	SettleTrips() (int, error)
+	BatchPayouts() (int, error)
+	RetryRefunds() (int, error)
+	ReconcilePayments() (*dto.PaymentReconciliation, error)
+	ViewReconciliationIssues(status string) ([]*entities.ReconciliationIssue, error)
+	ResolveReconciliationIssue(id int, resolution *dto.IssueResolution) (*entities.ReconciliationIssue, error)
+	ViewPayouts(status string) ([]*dto.Payout, error)
+	ApprovePayout(id int, decision *dto.PayoutDecision) (*dto.Payout, error)
+	RejectPayout(id int, decision *dto.PayoutDecision) (*dto.Payout, error)
 }
@@ -27,4 +27,24 @@ type ProviderService interface {
 	ActivateCoupon(id int) (*entities.Coupons, error)
 	FindCouponByCode(code string) (*entities.Coupons, error)
 	AddSubStations(station *entities.SubStation) (*entities.SubStation, error)
+	PreviewSeatLayout(request *dto.SeatLayoutRequest, email string) (*dto.SeatLayoutPreview, error)
+	AddSeatLayout(request *dto.SeatLayoutRequest, email string) (*entities.BusSeatLayout, error)
+	FindSeatLayouts(email string) ([]*entities.BusSeatLayout, error)
+	FindSeatLayoutByID(id int, email string) (*entities.BusSeatLayout, error)
+	FindSeatLayoutVersions(id int, email string) ([]*entities.BusSeatLayout, error)
+	AssignSeatLayout(id int, code string, email string) (*entities.BusType, error)
+	AddBoardingPoint(request *dto.BoardingPointRequest, email string) (*dto.BoardingPointResponse, error)
+	FindBoardingPoints(busID int, email string) ([]*dto.BoardingPointResponse, error)
+	DeleteBoardingPoint(id int, email string) error
+	SetCancellationPolicy(request *dto.CancellationPolicyRequest, email string) (*dto.CancellationPolicyResponse, error)
+	FindCancellationPolicies(email string) ([]*dto.CancellationPolicyResponse, error)
+	ScanTicket(request *dto.BoardingScanRequest, email string) (*dto.BoardingScanResponse, error)
+	TripBoarding(busID int, date string, email string) (*dto.TripBoarding, error)
+	TripManifest(busID int, date string, email string) (*dto.TripManifest, error)
+	ExportManifest(busID int, date string, format string, email string) ([]byte, error)
+	WalletStatement(email string, page int, limit int) (*dto.WalletStatement, error)
+	ViewPayouts(email string) ([]*dto.Payout, error)
+	RequestPayout(email string) (*dto.Payout, error)
+	SettlementStatement(payoutID int, email string) (*dto.SettlementStatement, error)
+	ExportSettlement(payoutID int, format string, email string) ([]byte, error)
 }
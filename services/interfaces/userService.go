@@ -9,16 +9,32 @@ import (
 type UserService interface {
 	Login(login *dto.LoginRequest) (map[string]string, error)
 	RegisterUser(user *entities.User) (*entities.User, error)
-	FindBus(request *dto.BusRequest) ([]*entities.BusesResp, error)
+	FindBus(request *dto.BusRequest) ([]*dto.BusSearchResult, error)
 	AddPassenger(passenger *entities.PassengerInfo, email string) (*entities.PassengerInfo, error)
 	ViewAllPassengers(email string) ([]*entities.PassengerInfo, error)
 	BookSeat(bookreq *dto.BookingRequest, email string) (*entities.Booking, error)
+	BookItinerary(request *dto.ItineraryBookingRequest, email string) ([]*entities.Booking, error)
+	PlanRoutes(request *dto.RoutePlanRequest) ([]*dto.Itinerary, error)
 	FindCoupon() ([]*entities.Coupons, error)
 	ViewBookings(email string) ([]*entities.Booking, error)
-	CancelBooking(bookID int) (*entities.Booking, error)
+	CancelBooking(bookID int, refundTo string, email string) (*entities.Booking, error)
+	CancelSeats(bookID int, request *dto.SeatCancelRequest, email string) (*entities.Booking, error)
+	RefundQuote(bookID int, request *dto.SeatCancelRequest, email string) (*dto.RefundQuote, error)
+	RescheduleBooking(bookID int, request *dto.RescheduleRequest, email string) (*entities.Booking, error)
+	BookingTicket(bookID int, email string) (string, []byte, error)
 	SeatAvailabilityChecker(seatReq *dto.SeatAvailabilityRequest) (*dto.SeatAvailabilityResponse, error)
-	MakePayment(bookID int) (*dto.MakePaymentResp, error)
+	MakePayment(pnr string) (*dto.MakePaymentResp, error)
 	PaymentSuccess(razor *entities.RazorPay) error
+	WalletStatement(email string, page int, limit int) (*dto.WalletStatement, error)
+	TopupWallet(request *dto.TopupRequest, email string) (*dto.TopupResponse, error)
+	TopupSuccess(razor *entities.RazorPay) error
+	PaymentWebhook(eventID string, body []byte, signature string) error
+	SetRefundPreference(request *dto.RefundPreference, email string) (*entities.User, error)
+	ViewRefunds(email string) ([]*entities.Refund, error)
 	FindBookingByID(ID int) (*entities.Booking, error)
-	SubStationDetails(parent string) ([]string, error)
+	FindBookingByPNR(pnr string) (*entities.Booking, error)
+	LookupBooking(pnr string, contact string) (*entities.Booking, error)
+	AssignMissingPNRs() (int, error)
+	SubStationDetails(parent string, busID int) ([]*dto.BoardingPointResponse, error)
+	ReleaseExpiredHolds() (int, error)
 }
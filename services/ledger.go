@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"gobus/entities"
+	"gobus/ledger"
+	repository "gobus/repository/interfaces"
+	"log"
+	"strings"
+	"time"
+)
+
+// walletBalance function returns the wallet balance of the user in paisa, the wallet stays locked until the transaction ends.
+func walletBalance(tx repository.UserRepository, userID uint) (int64, error) {
+	balance, err := ledger.Balance(tx.Ledger(), ledger.UserAccount(userID))
+	if err != nil {
+		log.Println("Error fetching the wallet balance, in ledger file")
+		return 0, err
+	}
+	return balance, nil
+}
+
+// postEntry function is used to post the entry to the ledger within the transaction, an entry posted before is left as it is.
+func postEntry(tx repository.LedgerRepository, entry *ledger.Entry) error {
+	if _, err := ledger.Post(tx, entry); err != nil {
+		log.Println("Unable to post", entry.Key, "to the ledger, in ledger file")
+		return err
+	}
+	return nil
+}
+
+// tripEscrow function returns the escrow account holding the fares of the trip of the booking until the trip completes.
+func tripEscrow(booking *entities.Booking) (string, error) {
+	day, err := time.ParseInLocation("02 01 2006", booking.BookingDate, time.Local)
+	if err != nil {
+		log.Println("Error parsing the date, in ledger file")
+		return "", err
+	}
+	return ledger.EscrowAccount(booking.BusID, day), nil
+}
+
+// refundSource function returns the account a refund of the booking is paid from, the escrow of its trip while it still holds the amount, otherwise the wallet of the provider the fare was paid out to.
+func refundSource(lr repository.LedgerRepository, booking *entities.Booking, providerID uint, amount int64) (string, error) {
+	escrow, err := tripEscrow(booking)
+	if err != nil {
+		return "", err
+	}
+	held, err := lr.HeldFor(escrow, booking.BookingID)
+	if err != nil {
+		log.Println("Error fetching the escrow of the booking, in ledger file")
+		return "", err
+	}
+	if held >= amount {
+		return escrow, nil
+	}
+	return ledger.ProviderAccount(providerID), nil
+}
+
+// paymentKey function returns the ledger key of the payment of the booking.
+func paymentKey(bookingID uint) string {
+	return fmt.Sprintf("booking:%d:payment", bookingID)
+}
+
+// cancelKey function returns the ledger key of the refund of the cancelled items of the booking.
+func cancelKey(bookingID uint, items []*entities.BookingItem) string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, fmt.Sprint(item.ID))
+	}
+	return fmt.Sprintf("booking:%d:cancel:%s", bookingID, strings.Join(ids, ","))
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ticket"
+	"log"
+	"sort"
+	"strings"
+)
+
+// TripManifest implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) TripManifest(busID int, date string, email string) (*dto.TripManifest, error) {
+	bus, day, err := ps.providerTrip(busID, date, email)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := ps.repo.FindScheduleByID(int(bus.ScheduleID))
+	if err != nil {
+		log.Println("Error fetching the schedule, in manifest file")
+		return nil, err
+	}
+	stops, err := ps.tripStops(bus)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &dto.TripManifest{
+		BusID:      bus.BusID,
+		BusNumber:  bus.BusNumber,
+		Date:       day.Format("02 01 2006"),
+		Route:      schedule.DepartureStation + " to " + schedule.ArrivalStation,
+		Departure:  stopDeparture(day, stops, "").Format("02 01 2006 15:04"),
+		Passengers: []*dto.ManifestEntry{},
+	}
+	bookings, err := ps.repo.FindTripBookings(bus.BusID, manifest.Date)
+	if err != nil {
+		log.Println("Error fetching the bookings of the trip, in manifest file")
+		return nil, err
+	}
+	ids := make([]uint, 0, len(bookings))
+	for _, booking := range bookings {
+		ids = append(ids, booking.BookingID)
+	}
+	items, err := ps.repo.FindBookingItems(ids)
+	if err != nil {
+		log.Println("Error fetching the booking items, in manifest file")
+		return nil, err
+	}
+	byBooking := map[uint][]*entities.BookingItem{}
+	for _, item := range items {
+		byBooking[item.BookingID] = append(byBooking[item.BookingID], item)
+	}
+	var passengerIDs []uint
+	for _, booking := range bookings {
+		if len(byBooking[booking.BookingID]) == 0 {
+			// a booking made before items were kept is listed from its seats and passengers
+			byBooking[booking.BookingID] = newBookingItems(booking.PassengerID, booking.SeatReserved, nil, 0)
+		}
+		for _, item := range byBooking[booking.BookingID] {
+			passengerIDs = append(passengerIDs, item.PassengerID)
+		}
+	}
+	passengers, err := ps.repo.FindPassengers(passengerIDs)
+	if err != nil {
+		log.Println("Error fetching the passengers, in manifest file")
+		return nil, err
+	}
+	byID := map[uint]*entities.PassengerInfo{}
+	for _, passenger := range passengers {
+		byID[passenger.PassengerID] = passenger
+	}
+	for _, booking := range bookings {
+		for _, item := range byBooking[booking.BookingID] {
+			if item.Status != ItemBooked {
+				continue
+			}
+			entry := &dto.ManifestEntry{
+				Seat:          item.SeatID,
+				BookingID:     booking.BookingID,
+				PNR:           booking.PNR,
+				PassengerID:   item.PassengerID,
+				From:          booking.FromStation,
+				To:            booking.ToStation,
+				BoardingPoint: booking.BoardingPoint,
+				BoardingTime:  booking.BoardingTime,
+				DroppingPoint: booking.DroppingPoint,
+				BoardedAt:     item.BoardedAt,
+			}
+			if entry.From == "" {
+				entry.From = schedule.DepartureStation
+			}
+			if entry.To == "" {
+				entry.To = schedule.ArrivalStation
+			}
+			if passenger, ok := byID[item.PassengerID]; ok {
+				entry.Name, entry.Age, entry.Gender = passenger.Name, passenger.Age, passenger.Gender
+			}
+			manifest.Passengers = append(manifest.Passengers, entry)
+		}
+	}
+	sort.SliceStable(manifest.Passengers, func(i, j int) bool { return manifest.Passengers[i].Seat < manifest.Passengers[j].Seat })
+	return manifest, nil
+}
+
+// ExportManifest implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) ExportManifest(busID int, date string, format string, email string) ([]byte, error) {
+	format = strings.ToLower(format)
+	if format != "csv" && format != "pdf" {
+		return nil, errors.New("manifest can be exported as csv or pdf")
+	}
+	manifest, err := ps.TripManifest(busID, date, email)
+	if err != nil {
+		return nil, err
+	}
+	if format == "pdf" {
+		return manifestPDF(manifest)
+	}
+	return manifestCSV(manifest)
+}
+
+// manifestCSV function is used to write the manifest as CSV, one row for every booked seat.
+func manifestCSV(manifest *dto.TripManifest) ([]byte, error) {
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	records := [][]string{{"seat", "pnr", "passenger_name", "age", "gender", "from", "to", "boarding_point", "boarding_time", "dropping_point", "boarded_at"}}
+	for _, entry := range manifest.Passengers {
+		boardedAt := ""
+		if entry.BoardedAt != nil {
+			boardedAt = entry.BoardedAt.Format("02 01 2006 15:04")
+		}
+		records = append(records, []string{entry.Seat, entry.PNR, entry.Name, fmt.Sprint(entry.Age), entry.Gender, entry.From, entry.To, entry.BoardingPoint, entry.BoardingTime, entry.DroppingPoint, boardedAt})
+	}
+	if err := w.WriteAll(records); err != nil {
+		log.Println("Error writing the manifest, in manifest file")
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// manifestPDF function is used to print the manifest for the crew.
+func manifestPDF(manifest *dto.TripManifest) ([]byte, error) {
+	printed := &ticket.Manifest{BusNumber: manifest.BusNumber, Route: manifest.Route, Date: manifest.Date, Departure: manifest.Departure}
+	for _, entry := range manifest.Passengers {
+		printed.Rows = append(printed.Rows, ticket.ManifestRow{
+			Seat:          entry.Seat,
+			PNR:           entry.PNR,
+			Name:          entry.Name,
+			Age:           entry.Age,
+			Gender:        entry.Gender,
+			From:          entry.From,
+			To:            entry.To,
+			BoardingPoint: entry.BoardingPoint,
+			Boarded:       entry.BoardedAt != nil,
+		})
+	}
+	return ticket.RenderManifest(printed)
+}
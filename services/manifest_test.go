@@ -0,0 +1,74 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"gobus/entities"
+	"testing"
+	"time"
+)
+
+func Test_TripManifest(t *testing.T) {
+	date := "01 01 2030"
+	boardedAt := time.Date(2030, 1, 1, 21, 45, 0, 0, time.Local)
+	repo := &boardingRepo{
+		bus:   &entities.Buses{BusID: 1, BusNumber: "KL-13-1234", ProviderID: 1, ScheduleID: 1},
+		stops: []*entities.ScheduleStop{{StationName: "Kannur", DepartureTime: "22:00:00"}, {StationName: "Iritty", DepartureTime: "23:00:00"}, {StationName: "Bangalore", ArrivalTime: "06:00:00", DayOffset: 1}},
+		bookings: []*entities.Booking{
+			{BookingID: 1, PNR: "PNRONE2345", BusID: 1, BookingDate: date, Status: "Success", BoardingPoint: "Thavakkara"},
+			{BookingID: 2, PNR: "PNRTWO2345", BusID: 1, BookingDate: date, Status: "Cancelled by User"},
+			{BookingID: 3, PNR: "PNRTHREE23", BusID: 1, BookingDate: date, Status: "Success", FromStation: "Iritty", ToStation: "Bangalore", PassengerID: []int64{7}, SeatReserved: []string{"01B"}},
+		},
+		items: []*entities.BookingItem{
+			{ID: 1, BookingID: 1, PassengerID: 1, SeatID: "02A", Status: ItemBooked, BoardedAt: &boardedAt},
+			{ID: 2, BookingID: 1, PassengerID: 2, SeatID: "02B", Status: ItemCancelled},
+			{ID: 3, BookingID: 1, PassengerID: 3, SeatID: "01A", Status: ItemBooked},
+			{ID: 4, BookingID: 2, PassengerID: 4, SeatID: "03A", Status: ItemCancelled},
+		},
+	}
+	ps := &ProviderServiceImpl{repo: repo}
+
+	manifest, err := ps.TripManifest(1, date, "crew@gobus.com")
+	if err != nil {
+		t.Fatalf("services.TripManifest() error = %v", err)
+	}
+	if manifest.Route != "Kannur to Bangalore" || manifest.Departure != "01 01 2030 22:00" {
+		t.Errorf("services.TripManifest() trip = %s, %s", manifest.Route, manifest.Departure)
+	}
+	var seats []string
+	for _, entry := range manifest.Passengers {
+		seats = append(seats, entry.Seat+" "+entry.Name+" "+entry.From)
+	}
+	want := []string{"01A Passenger 3 Kannur", "01B Passenger 7 Iritty", "02A Passenger 1 Kannur"}
+	if len(seats) != len(want) {
+		t.Fatalf("services.TripManifest() = %v, want %v", seats, want)
+	}
+	for i := range want {
+		if seats[i] != want[i] {
+			t.Errorf("services.TripManifest() = %v, want %v", seats, want)
+			break
+		}
+	}
+
+	file, err := ps.ExportManifest(1, date, "csv", "crew@gobus.com")
+	if err != nil {
+		t.Fatalf("services.ExportManifest() error = %v", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(file)).ReadAll()
+	if err != nil {
+		t.Fatalf("services.ExportManifest() is not CSV: %v", err)
+	}
+	if len(records) != 4 || records[3][0] != "02A" || records[3][10] != "01 01 2030 21:45" || records[3][7] != "Thavakkara" {
+		t.Errorf("services.ExportManifest() csv = %v", records)
+	}
+	file, err = ps.ExportManifest(1, date, "PDF", "crew@gobus.com")
+	if err != nil || !bytes.HasPrefix(file, []byte("%PDF")) {
+		t.Errorf("services.ExportManifest() pdf error = %v", err)
+	}
+	if _, err := ps.ExportManifest(1, date, "xls", "crew@gobus.com"); err == nil {
+		t.Errorf("services.ExportManifest() exported an unknown format")
+	}
+	if _, err := ps.TripManifest(1, date, "other@gobus.com"); err == nil {
+		t.Errorf("services.TripManifest() listed the bus of another provider")
+	}
+}
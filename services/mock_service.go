@@ -50,6 +50,36 @@ func (mr *MockUserServiceMockRecorder) AddPassenger(passenger, email interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPassenger", reflect.TypeOf((*MockUserService)(nil).AddPassenger), passenger, email)
 }
 
+// AssignMissingPNRs mocks base method.
+func (m *MockUserService) AssignMissingPNRs() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignMissingPNRs")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignMissingPNRs indicates an expected call of AssignMissingPNRs.
+func (mr *MockUserServiceMockRecorder) AssignMissingPNRs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignMissingPNRs", reflect.TypeOf((*MockUserService)(nil).AssignMissingPNRs))
+}
+
+// BookItinerary mocks base method.
+func (m *MockUserService) BookItinerary(request *dto.ItineraryBookingRequest, email string) ([]*entities.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookItinerary", request, email)
+	ret0, _ := ret[0].([]*entities.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookItinerary indicates an expected call of BookItinerary.
+func (mr *MockUserServiceMockRecorder) BookItinerary(request, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookItinerary", reflect.TypeOf((*MockUserService)(nil).BookItinerary), request, email)
+}
+
 // BookSeat mocks base method.
 func (m *MockUserService) BookSeat(bookreq *dto.BookingRequest, email string) (*entities.Booking, error) {
 	m.ctrl.T.Helper()
@@ -65,19 +95,50 @@ func (mr *MockUserServiceMockRecorder) BookSeat(bookreq, email interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookSeat", reflect.TypeOf((*MockUserService)(nil).BookSeat), bookreq, email)
 }
 
+// BookingTicket mocks base method.
+func (m *MockUserService) BookingTicket(bookID int, email string) (string, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookingTicket", bookID, email)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BookingTicket indicates an expected call of BookingTicket.
+func (mr *MockUserServiceMockRecorder) BookingTicket(bookID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookingTicket", reflect.TypeOf((*MockUserService)(nil).BookingTicket), bookID, email)
+}
+
 // CancelBooking mocks base method.
-func (m *MockUserService) CancelBooking(bookID int) (*entities.Booking, error) {
+func (m *MockUserService) CancelBooking(bookID int, refundTo, email string) (*entities.Booking, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CancelBooking", bookID)
+	ret := m.ctrl.Call(m, "CancelBooking", bookID, refundTo, email)
 	ret0, _ := ret[0].(*entities.Booking)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CancelBooking indicates an expected call of CancelBooking.
-func (mr *MockUserServiceMockRecorder) CancelBooking(bookID interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) CancelBooking(bookID, refundTo, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockUserService)(nil).CancelBooking), bookID, refundTo, email)
+}
+
+// CancelSeats mocks base method.
+func (m *MockUserService) CancelSeats(bookID int, request *dto.SeatCancelRequest, email string) (*entities.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSeats", bookID, request, email)
+	ret0, _ := ret[0].(*entities.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSeats indicates an expected call of CancelSeats.
+func (mr *MockUserServiceMockRecorder) CancelSeats(bookID, request, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockUserService)(nil).CancelBooking), bookID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSeats", reflect.TypeOf((*MockUserService)(nil).CancelSeats), bookID, request, email)
 }
 
 // FindBookingByID mocks base method.
@@ -95,11 +156,26 @@ func (mr *MockUserServiceMockRecorder) FindBookingByID(ID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingByID", reflect.TypeOf((*MockUserService)(nil).FindBookingByID), ID)
 }
 
+// FindBookingByPNR mocks base method.
+func (m *MockUserService) FindBookingByPNR(pnr string) (*entities.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookingByPNR", pnr)
+	ret0, _ := ret[0].(*entities.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookingByPNR indicates an expected call of FindBookingByPNR.
+func (mr *MockUserServiceMockRecorder) FindBookingByPNR(pnr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingByPNR", reflect.TypeOf((*MockUserService)(nil).FindBookingByPNR), pnr)
+}
+
 // FindBus mocks base method.
-func (m *MockUserService) FindBus(request *dto.BusRequest) ([]*entities.BusesResp, error) {
+func (m *MockUserService) FindBus(request *dto.BusRequest) ([]*dto.BusSearchResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindBus", request)
-	ret0, _ := ret[0].([]*entities.BusesResp)
+	ret0, _ := ret[0].([]*dto.BusSearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -140,19 +216,34 @@ func (mr *MockUserServiceMockRecorder) Login(login interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserService)(nil).Login), login)
 }
 
+// LookupBooking mocks base method.
+func (m *MockUserService) LookupBooking(pnr, contact string) (*entities.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupBooking", pnr, contact)
+	ret0, _ := ret[0].(*entities.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupBooking indicates an expected call of LookupBooking.
+func (mr *MockUserServiceMockRecorder) LookupBooking(pnr, contact interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupBooking", reflect.TypeOf((*MockUserService)(nil).LookupBooking), pnr, contact)
+}
+
 // MakePayment mocks base method.
-func (m *MockUserService) MakePayment(bookID int) (*dto.MakePaymentResp, error) {
+func (m *MockUserService) MakePayment(pnr string) (*dto.MakePaymentResp, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MakePayment", bookID)
+	ret := m.ctrl.Call(m, "MakePayment", pnr)
 	ret0, _ := ret[0].(*dto.MakePaymentResp)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MakePayment indicates an expected call of MakePayment.
-func (mr *MockUserServiceMockRecorder) MakePayment(bookID interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) MakePayment(pnr interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakePayment", reflect.TypeOf((*MockUserService)(nil).MakePayment), bookID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakePayment", reflect.TypeOf((*MockUserService)(nil).MakePayment), pnr)
 }
 
 // PaymentSuccess mocks base method.
@@ -169,6 +260,50 @@ func (mr *MockUserServiceMockRecorder) PaymentSuccess(razor interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentSuccess", reflect.TypeOf((*MockUserService)(nil).PaymentSuccess), razor)
 }
 
+// PaymentWebhook mocks base method.
+func (m *MockUserService) PaymentWebhook(eventID string, body []byte, signature string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaymentWebhook", eventID, body, signature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PaymentWebhook indicates an expected call of PaymentWebhook.
+func (mr *MockUserServiceMockRecorder) PaymentWebhook(eventID, body, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentWebhook", reflect.TypeOf((*MockUserService)(nil).PaymentWebhook), eventID, body, signature)
+}
+
+// PlanRoutes mocks base method.
+func (m *MockUserService) PlanRoutes(request *dto.RoutePlanRequest) ([]*dto.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanRoutes", request)
+	ret0, _ := ret[0].([]*dto.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanRoutes indicates an expected call of PlanRoutes.
+func (mr *MockUserServiceMockRecorder) PlanRoutes(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanRoutes", reflect.TypeOf((*MockUserService)(nil).PlanRoutes), request)
+}
+
+// RefundQuote mocks base method.
+func (m *MockUserService) RefundQuote(bookID int, request *dto.SeatCancelRequest, email string) (*dto.RefundQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundQuote", bookID, request, email)
+	ret0, _ := ret[0].(*dto.RefundQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundQuote indicates an expected call of RefundQuote.
+func (mr *MockUserServiceMockRecorder) RefundQuote(bookID, request, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundQuote", reflect.TypeOf((*MockUserService)(nil).RefundQuote), bookID, request, email)
+}
+
 // RegisterUser mocks base method.
 func (m *MockUserService) RegisterUser(user *entities.User) (*entities.User, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +319,36 @@ func (mr *MockUserServiceMockRecorder) RegisterUser(user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserService)(nil).RegisterUser), user)
 }
 
+// ReleaseExpiredHolds mocks base method.
+func (m *MockUserService) ReleaseExpiredHolds() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpiredHolds")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpiredHolds indicates an expected call of ReleaseExpiredHolds.
+func (mr *MockUserServiceMockRecorder) ReleaseExpiredHolds() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpiredHolds", reflect.TypeOf((*MockUserService)(nil).ReleaseExpiredHolds))
+}
+
+// RescheduleBooking mocks base method.
+func (m *MockUserService) RescheduleBooking(bookID int, request *dto.RescheduleRequest, email string) (*entities.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleBooking", bookID, request, email)
+	ret0, _ := ret[0].(*entities.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RescheduleBooking indicates an expected call of RescheduleBooking.
+func (mr *MockUserServiceMockRecorder) RescheduleBooking(bookID, request, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleBooking", reflect.TypeOf((*MockUserService)(nil).RescheduleBooking), bookID, request, email)
+}
+
 // SeatAvailabilityChecker mocks base method.
 func (m *MockUserService) SeatAvailabilityChecker(seatReq *dto.SeatAvailabilityRequest) (*dto.SeatAvailabilityResponse, error) {
 	m.ctrl.T.Helper()
@@ -199,19 +364,63 @@ func (mr *MockUserServiceMockRecorder) SeatAvailabilityChecker(seatReq interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeatAvailabilityChecker", reflect.TypeOf((*MockUserService)(nil).SeatAvailabilityChecker), seatReq)
 }
 
+// SetRefundPreference mocks base method.
+func (m *MockUserService) SetRefundPreference(request *dto.RefundPreference, email string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRefundPreference", request, email)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRefundPreference indicates an expected call of SetRefundPreference.
+func (mr *MockUserServiceMockRecorder) SetRefundPreference(request, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRefundPreference", reflect.TypeOf((*MockUserService)(nil).SetRefundPreference), request, email)
+}
+
 // SubStationDetails mocks base method.
-func (m *MockUserService) SubStationDetails(parent string) ([]string, error) {
+func (m *MockUserService) SubStationDetails(parent string, busID int) ([]*dto.BoardingPointResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SubStationDetails", parent)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "SubStationDetails", parent, busID)
+	ret0, _ := ret[0].([]*dto.BoardingPointResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SubStationDetails indicates an expected call of SubStationDetails.
-func (mr *MockUserServiceMockRecorder) SubStationDetails(parent interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) SubStationDetails(parent, busID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubStationDetails", reflect.TypeOf((*MockUserService)(nil).SubStationDetails), parent, busID)
+}
+
+// TopupSuccess mocks base method.
+func (m *MockUserService) TopupSuccess(razor *entities.RazorPay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopupSuccess", razor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TopupSuccess indicates an expected call of TopupSuccess.
+func (mr *MockUserServiceMockRecorder) TopupSuccess(razor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopupSuccess", reflect.TypeOf((*MockUserService)(nil).TopupSuccess), razor)
+}
+
+// TopupWallet mocks base method.
+func (m *MockUserService) TopupWallet(request *dto.TopupRequest, email string) (*dto.TopupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopupWallet", request, email)
+	ret0, _ := ret[0].(*dto.TopupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopupWallet indicates an expected call of TopupWallet.
+func (mr *MockUserServiceMockRecorder) TopupWallet(request, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubStationDetails", reflect.TypeOf((*MockUserService)(nil).SubStationDetails), parent)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopupWallet", reflect.TypeOf((*MockUserService)(nil).TopupWallet), request, email)
 }
 
 // ViewAllPassengers mocks base method.
@@ -243,3 +452,33 @@ func (mr *MockUserServiceMockRecorder) ViewBookings(email interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewBookings", reflect.TypeOf((*MockUserService)(nil).ViewBookings), email)
 }
+
+// ViewRefunds mocks base method.
+func (m *MockUserService) ViewRefunds(email string) ([]*entities.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewRefunds", email)
+	ret0, _ := ret[0].([]*entities.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewRefunds indicates an expected call of ViewRefunds.
+func (mr *MockUserServiceMockRecorder) ViewRefunds(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewRefunds", reflect.TypeOf((*MockUserService)(nil).ViewRefunds), email)
+}
+
+// WalletStatement mocks base method.
+func (m *MockUserService) WalletStatement(email string, page, limit int) (*dto.WalletStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletStatement", email, page, limit)
+	ret0, _ := ret[0].(*dto.WalletStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletStatement indicates an expected call of WalletStatement.
+func (mr *MockUserServiceMockRecorder) WalletStatement(email, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletStatement", reflect.TypeOf((*MockUserService)(nil).WalletStatement), email, page, limit)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"errors"
+	"gobus/entities"
+	"gobus/payment"
+	"log"
+)
+
+// Reasons a payment callback is turned down and recorded as suspicious.
+const (
+	reasonSignature = "signature does not match the order and payment"
+	reasonReceipt   = "order was raised for another booking"
+	reasonAmount    = "amount or currency does not match the booking"
+	reasonUncapture = "payment was not captured for the order"
+	reasonReplayed  = "payment was already used"
+)
+
+var (
+	errPaymentRejected = errors.New("payment could not be verified")
+	errPaymentReplayed = errors.New("payment has already been used")
+)
+
+// verifyPayment function is used to check with the gateway that the payment was signed with the key secret and paid the amount in full for the order raised for the receipt, an empty receipt is not checked. It returns the reason a payment is turned down, none when only the gateway could not be reached.
+func (usi *UserServiceImpl) verifyPayment(razor *entities.RazorPay, receipt string, amount int64) (string, error) {
+	if err := usi.gateway.VerifyPayment(razor.RazorPayOrderID, razor.RazorPaymentID, razor.Signature); err != nil {
+		return reasonSignature, errPaymentRejected
+	}
+	order, err := usi.gateway.FetchStatus(razor.RazorPayOrderID)
+	if err != nil {
+		log.Println("Unable to fetch the order from the gateway, in payment file")
+		return "", err
+	}
+	if receipt != "" && order.Receipt != receipt {
+		return reasonReceipt, errPaymentRejected
+	}
+	if order.Amount != amount || order.Currency != "INR" {
+		return reasonAmount, errPaymentRejected
+	}
+	for _, paid := range order.Payments {
+		if paid.ID != razor.RazorPaymentID {
+			continue
+		}
+		if paid.Status != payment.PaymentCaptured && paid.Status != payment.PaymentAuthorized {
+			return reasonUncapture, errPaymentRejected
+		}
+		if paid.Amount != amount || paid.Currency != "INR" {
+			return reasonAmount, errPaymentRejected
+		}
+		return "", nil
+	}
+	return reasonUncapture, errPaymentRejected
+}
+
+// flagPayment function is used to record the payment callback that was turned down, a failure is only logged.
+func (usi *UserServiceImpl) flagPayment(razor *entities.RazorPay, bookingID uint, topupID uint, reason string) {
+	log.Println("Suspicious payment", razor.RazorPaymentID, "for the order", razor.RazorPayOrderID+":", reason)
+	attempt := &entities.SuspiciousPayment{
+		BookingID: bookingID,
+		TopupID:   topupID,
+		OrderID:   razor.RazorPayOrderID,
+		PaymentID: razor.RazorPaymentID,
+		Reason:    reason,
+	}
+	if err := usi.repo.AddSuspiciousPayment(attempt); err != nil {
+		log.Println("Unable to record the suspicious payment, in payment file")
+	}
+}
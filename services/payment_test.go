@@ -0,0 +1,266 @@
+package services
+
+import (
+	"errors"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/payment"
+	"gobus/repository"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_MakePayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	expired := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name       string
+		pnr        string
+		beforeTest func(userRepo *repository.MockUserRepository)
+		want       *dto.MakePaymentResp
+		wantErr    bool
+	}{
+		{
+			name: "success order raised and kept",
+			pnr:  "GB7K2M9Q",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("GB7K2M9Q").Return(&entities.Booking{BookingID: 1, UserID: 1, PNR: "GB7K2M9Q", FarePostDiscount: 500, Status: "Awaiting Payment"}, nil)
+				userRepo.EXPECT().GetUserInfo(1).Return(&entities.User{ID: 1, Email: "abc@gmail.com", PhoneNumber: "1234567890"}, nil)
+				userRepo.EXPECT().AddPaymentOrder(&entities.PaymentOrder{OrderID: "order_fake1", BookingID: 1, Amount: 50000}).Return(nil)
+			},
+			want:    &dto.MakePaymentResp{AmountInRupees: 500, AmountInPaisa: 50000, BookingID: 1, PNR: "GB7K2M9Q", Email: "abc@gmail.com", PhoneNumber: "1234567890", OrderID: "order_fake1"},
+			wantErr: false,
+		},
+		{
+			name: "success rescheduled booking pays what the credit leaves",
+			pnr:  "GB7K2M9Q",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("GB7K2M9Q").Return(&entities.Booking{BookingID: 1, UserID: 1, PNR: "GB7K2M9Q", FarePostDiscount: 500, RescheduleCredit: 300, Status: "Awaiting Payment"}, nil)
+				userRepo.EXPECT().GetUserInfo(1).Return(&entities.User{ID: 1, Email: "abc@gmail.com", PhoneNumber: "1234567890"}, nil)
+				userRepo.EXPECT().AddPaymentOrder(&entities.PaymentOrder{OrderID: "order_fake1", BookingID: 1, Amount: 20000}).Return(nil)
+			},
+			want:    &dto.MakePaymentResp{AmountInRupees: 200, AmountInPaisa: 20000, BookingID: 1, PNR: "GB7K2M9Q", Email: "abc@gmail.com", PhoneNumber: "1234567890", OrderID: "order_fake1"},
+			wantErr: false,
+		},
+		{
+			name: "success discounted fare keeps its paise",
+			pnr:  "GB7K2M9Q",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("GB7K2M9Q").Return(&entities.Booking{BookingID: 1, UserID: 1, PNR: "GB7K2M9Q", FarePostDiscount: 475.5, Status: "Awaiting Payment"}, nil)
+				userRepo.EXPECT().GetUserInfo(1).Return(&entities.User{ID: 1, Email: "abc@gmail.com", PhoneNumber: "1234567890"}, nil)
+				userRepo.EXPECT().AddPaymentOrder(&entities.PaymentOrder{OrderID: "order_fake1", BookingID: 1, Amount: 47550}).Return(nil)
+			},
+			want:    &dto.MakePaymentResp{AmountInRupees: 475.5, AmountInPaisa: 47550, BookingID: 1, PNR: "GB7K2M9Q", Email: "abc@gmail.com", PhoneNumber: "1234567890", OrderID: "order_fake1"},
+			wantErr: false,
+		},
+		{
+			name: "seat hold expired",
+			pnr:  "GB7K2M9Q",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("GB7K2M9Q").Return(&entities.Booking{BookingID: 1, Status: "Awaiting Payment", HoldExpiresAt: &expired}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "waitlisted booking",
+			pnr:  "GB7K2M9Q",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("GB7K2M9Q").Return(&entities.Booking{BookingID: 1, Status: "Waitlisted"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no booking",
+			pnr:  "GB0000000",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("GB0000000").Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			tt.beforeTest(mockRepo)
+			u := &UserServiceImpl{repo: mockRepo, gateway: payment.NewFakeGateway("")}
+			got, err := u.MakePayment(tt.pnr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.MakePayment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.MakePayment() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_PaymentSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	day, _ := time.Parse("02 01 2006", "01 01 2024")
+	awaiting := func() *entities.Booking {
+		return &entities.Booking{BookingID: 1, UserID: 1, BusID: 1, PNR: "GB7K2M9Q", BookingDate: "01 01 2024", FarePostDiscount: 500, Status: "Awaiting Payment"}
+	}
+	// flagged expects the payment to be recorded as suspicious for the reason
+	flagged := func(t *testing.T, userRepo *repository.MockUserRepository, reason string) {
+		userRepo.EXPECT().AddSuspiciousPayment(gomock.Any()).DoAndReturn(func(attempt *entities.SuspiciousPayment) error {
+			if attempt.Reason != reason || attempt.BookingID != 1 {
+				t.Errorf("services.PaymentSuccess() recorded %+v, want %s", attempt, reason)
+			}
+			return nil
+		})
+	}
+	type order struct {
+		amount  int64
+		receipt string
+	}
+	tests := []struct {
+		name       string
+		order      order
+		forged     bool
+		beforeTest func(t *testing.T, userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, paymentID string)
+		wantErr    error
+	}{
+		{
+			name:  "success fare held in escrow",
+			order: order{50000, "GB7K2M9Q"},
+			beforeTest: func(t *testing.T, userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, paymentID string) {
+				userRepo.EXPECT().FindBookingByID(1).Return(awaiting(), nil).Times(2)
+				userRepo.EXPECT().GetChartForUpdate(1, day).Return(&entities.BusSchedule{BusID: 1}, nil)
+				userRepo.EXPECT().FindPayment(paymentID).Return(nil, nil)
+				expectPost(ledgerRepo, "booking:1:payment", 50000)
+				userRepo.EXPECT().UpdateBooking(gomock.Any()).DoAndReturn(func(booking *entities.Booking) (*entities.Booking, error) {
+					if booking.Status != "Success" || booking.HoldExpiresAt != nil {
+						t.Errorf("services.PaymentSuccess() stored the booking %+v", booking)
+					}
+					return booking, nil
+				})
+				userRepo.EXPECT().PaymentSuccess(gomock.Any()).DoAndReturn(func(razor *entities.RazorPay) error {
+					if razor.Status != PaymentCaptured || razor.AmountPaid != 500 {
+						t.Errorf("services.PaymentSuccess() stored the payment %+v", razor)
+					}
+					return nil
+				})
+				// the e-ticket has tests of its own
+				userRepo.EXPECT().GetUserInfo(1).Return(nil, errors.New("record not found"))
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "callback after the webhook confirmed the booking",
+			order: order{50000, "GB7K2M9Q"},
+			beforeTest: func(t *testing.T, userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, paymentID string) {
+				confirmed := awaiting()
+				confirmed.Status = "Success"
+				userRepo.EXPECT().FindBookingByID(1).Return(awaiting(), nil)
+				userRepo.EXPECT().GetChartForUpdate(1, day).Return(&entities.BusSchedule{BusID: 1}, nil)
+				userRepo.EXPECT().FindBookingByID(1).Return(confirmed, nil)
+				userRepo.EXPECT().FindPayment(paymentID).Return(&entities.RazorPay{BookID: 1, RazorPaymentID: paymentID, Status: PaymentCaptured}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "booking paid another way has the payment credited to the wallet",
+			order: order{50000, "GB7K2M9Q"},
+			beforeTest: func(t *testing.T, userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, paymentID string) {
+				confirmed := awaiting()
+				confirmed.Status = "Success"
+				userRepo.EXPECT().FindBookingByID(1).Return(awaiting(), nil)
+				userRepo.EXPECT().GetChartForUpdate(1, day).Return(&entities.BusSchedule{BusID: 1}, nil)
+				userRepo.EXPECT().FindBookingByID(1).Return(confirmed, nil)
+				userRepo.EXPECT().FindPayment(paymentID).Return(nil, nil)
+				expectPost(ledgerRepo, "payment:"+paymentID+":unapplied", 50000)
+				userRepo.EXPECT().PaymentSuccess(gomock.Any()).Return(nil)
+			},
+			wantErr: errors.New("booking was already paid, the payment was credited to the wallet"),
+		},
+		{
+			name:   "forged signature",
+			order:  order{50000, "GB7K2M9Q"},
+			forged: true,
+			beforeTest: func(t *testing.T, userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, paymentID string) {
+				userRepo.EXPECT().FindBookingByID(1).Return(awaiting(), nil)
+				flagged(t, userRepo, reasonSignature)
+			},
+			wantErr: errPaymentRejected,
+		},
+		{
+			name:  "order of another booking",
+			order: order{50000, "GB4X8N2P"},
+			beforeTest: func(t *testing.T, userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, paymentID string) {
+				userRepo.EXPECT().FindBookingByID(1).Return(awaiting(), nil)
+				flagged(t, userRepo, reasonReceipt)
+			},
+			wantErr: errPaymentRejected,
+		},
+		{
+			name:  "short payment",
+			order: order{49900, "GB7K2M9Q"},
+			beforeTest: func(t *testing.T, userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, paymentID string) {
+				userRepo.EXPECT().FindBookingByID(1).Return(awaiting(), nil)
+				flagged(t, userRepo, reasonAmount)
+			},
+			wantErr: errPaymentRejected,
+		},
+		{
+			name:  "payment used by another booking",
+			order: order{50000, "GB7K2M9Q"},
+			beforeTest: func(t *testing.T, userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, paymentID string) {
+				userRepo.EXPECT().FindBookingByID(1).Return(awaiting(), nil).Times(2)
+				userRepo.EXPECT().GetChartForUpdate(1, day).Return(&entities.BusSchedule{BusID: 1}, nil)
+				userRepo.EXPECT().FindPayment(paymentID).Return(&entities.RazorPay{BookID: 2, RazorPaymentID: paymentID, Status: PaymentCaptured}, nil)
+				flagged(t, userRepo, reasonReplayed)
+			},
+			wantErr: errPaymentReplayed,
+		},
+		{
+			name:  "seat hold expired before the payment has it credited to the wallet",
+			order: order{50000, "GB7K2M9Q"},
+			beforeTest: func(t *testing.T, userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, paymentID string) {
+				expired := awaiting()
+				expired.Status = "Expired"
+				userRepo.EXPECT().FindBookingByID(1).Return(awaiting(), nil)
+				userRepo.EXPECT().GetChartForUpdate(1, day).Return(&entities.BusSchedule{BusID: 1}, nil)
+				userRepo.EXPECT().FindBookingByID(1).Return(expired, nil)
+				userRepo.EXPECT().FindPayment(paymentID).Return(nil, nil)
+				expectPost(ledgerRepo, "payment:"+paymentID+":unapplied", 50000)
+				userRepo.EXPECT().PaymentSuccess(gomock.Any()).DoAndReturn(func(razor *entities.RazorPay) error {
+					if razor.Status != PaymentCredited || razor.AmountPaid != 500 {
+						t.Errorf("services.PaymentSuccess() stored the payment %+v", razor)
+					}
+					return nil
+				})
+			},
+			wantErr: errors.New("seat hold expired, the payment was credited to the wallet"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := payment.NewFakeGateway("")
+			raised, _ := gateway.CreateOrder(tt.order.amount, tt.order.receipt)
+			paid, signature, err := gateway.Pay(raised.ID)
+			if err != nil {
+				t.Fatalf("payment.FakeGateway.Pay() error = %v", err)
+			}
+			if tt.forged {
+				signature = payment.Sign("guessed", raised.ID, paid.ID)
+			}
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			mockLedger := repository.NewMockLedgerRepository(ctrl)
+			expectTx(mockRepo)
+			expectLedger(mockLedger)
+			mockRepo.EXPECT().Ledger().Return(mockLedger).AnyTimes()
+			tt.beforeTest(t, mockRepo, mockLedger, paid.ID)
+			u := &UserServiceImpl{repo: mockRepo, hold: noHold{}, gateway: gateway}
+			err = u.PaymentSuccess(&entities.RazorPay{BookID: 1, RazorPaymentID: paid.ID, RazorPayOrderID: raised.ID, Signature: signature})
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && (err == nil || err.Error() != tt.wantErr.Error()) {
+				t.Errorf("services.PaymentSuccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"gobus/entities"
+	"log"
+	"math/big"
+	"strings"
+)
+
+// pnrAlphabet leaves out the letters and digits that are easily mixed up when read out, such as O and 0 or I and 1.
+const pnrAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// pnrLength is the number of characters of a PNR.
+const pnrLength = 10
+
+// newPNR function is used to generate the random, non sequential reference a booking is known by outside the app.
+func newPNR() string {
+	pnr := make([]byte, pnrLength)
+	max := big.NewInt(int64(len(pnrAlphabet)))
+	for i := range pnr {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			log.Println("Error reading random bytes for the PNR, in pnr file")
+			n = big.NewInt(0)
+		}
+		pnr[i] = pnrAlphabet[n.Int64()]
+	}
+	return string(pnr)
+}
+
+// normalizePNR function is used to accept a PNR typed in lower case or with spaces.
+func normalizePNR(pnr string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(pnr), " ", ""))
+}
+
+// FindBookingByPNR implements interfaces.UserService.
+func (usi *UserServiceImpl) FindBookingByPNR(pnr string) (*entities.Booking, error) {
+	pnr = normalizePNR(pnr)
+	if len(pnr) != pnrLength {
+		return nil, errors.New("invalid PNR")
+	}
+	booking, err := usi.repo.FindBookingByPNR(pnr)
+	if err != nil {
+		log.Println("Error fetching the booking by PNR, in pnr file")
+		return nil, errors.New("no booking found with this PNR")
+	}
+	return booking, nil
+}
+
+// LookupBooking implements interfaces.UserService.
+func (usi *UserServiceImpl) LookupBooking(pnr string, contact string) (*entities.Booking, error) {
+	booking, err := usi.FindBookingByPNR(pnr)
+	if err != nil {
+		return nil, err
+	}
+	user, err := usi.repo.GetUserInfo(int(booking.UserID))
+	if err != nil {
+		log.Println("Error fetching the user info, in pnr file")
+		return nil, errors.New("no booking found with this PNR")
+	}
+	contact = strings.TrimSpace(contact)
+	if contact == "" || (!strings.EqualFold(contact, user.Email) && contact != user.PhoneNumber) {
+		// the same answer as an unknown PNR, so a lookup tells nothing about which PNRs exist
+		log.Println("Contact does not match the booking, in pnr file")
+		return nil, errors.New("no booking found with this PNR")
+	}
+	setBookingItems(usi.repo, []*entities.Booking{booking})
+	return booking, nil
+}
+
+// AssignMissingPNRs implements interfaces.UserService.
+func (usi *UserServiceImpl) AssignMissingPNRs() (int, error) {
+	bookings, err := usi.repo.FindBookingsWithoutPNR()
+	if err != nil {
+		log.Println("Error fetching the bookings without PNR, in pnr file")
+		return 0, err
+	}
+	assigned := 0
+	for _, booking := range bookings {
+		if err := usi.repo.SetBookingPNR(booking.BookingID, newPNR()); err != nil {
+			log.Println("Could not assign the PNR, in pnr file")
+			continue
+		}
+		assigned++
+	}
+	return assigned, nil
+}
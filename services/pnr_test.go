@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"gobus/entities"
+	"gobus/repository"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// validPNR is a gomock matcher for a freshly generated PNR.
+type validPNR struct{}
+
+func (validPNR) Matches(x interface{}) bool {
+	pnr, ok := x.(string)
+	if !ok || len(pnr) != pnrLength {
+		return false
+	}
+	for _, c := range pnr {
+		if !strings.ContainsRune(pnrAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (validPNR) String() string {
+	return "is a PNR"
+}
+
+func Test_AssignMissingPNRs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		beforeTest func(userRepo *repository.MockUserRepository)
+		want       int
+		wantErr    bool
+	}{
+		{
+			name: "success every booking given a PNR",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingsWithoutPNR().Return([]*entities.Booking{{BookingID: 1}, {BookingID: 2}}, nil)
+				userRepo.EXPECT().SetBookingPNR(uint(1), validPNR{}).Return(nil)
+				userRepo.EXPECT().SetBookingPNR(uint(2), validPNR{}).Return(nil)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "success booking failing to store is left for the next run",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingsWithoutPNR().Return([]*entities.Booking{{BookingID: 1}, {BookingID: 2}}, nil)
+				userRepo.EXPECT().SetBookingPNR(uint(1), validPNR{}).Return(errors.New("Oops"))
+				userRepo.EXPECT().SetBookingPNR(uint(2), validPNR{}).Return(nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "bookings not found",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingsWithoutPNR().Return(nil, errors.New("Oops"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			tt.beforeTest(mockRepo)
+			u := &UserServiceImpl{repo: mockRepo}
+			got, err := u.AssignMissingPNRs()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.AssignMissingPNRs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("services.AssignMissingPNRs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
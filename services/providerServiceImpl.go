@@ -2,10 +2,12 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"gobus/dto"
 	"gobus/entities"
 	"gobus/middleware"
 	repository "gobus/repository/interfaces"
+	"gobus/seatmap"
 	"gobus/services/interfaces"
 	"gobus/utils"
 	"log"
@@ -264,6 +266,196 @@ func (ps *ProviderServiceImpl) RegisterProvider(provider *entities.ServiceProvid
 	return regProvider, err
 }
 
+// PreviewSeatLayout implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) PreviewSeatLayout(request *dto.SeatLayoutRequest, email string) (*dto.SeatLayoutPreview, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in providerServiceImpl file")
+		return nil, err
+	}
+	seatMap, err := ps.designSeatMap(request, provider)
+	if err != nil {
+		return nil, err
+	}
+	counts := seatMap.CountByClass()
+	return &dto.SeatLayoutPreview{
+		Decks:        seatMap.Grid(),
+		SleeperSeats: counts[seatmap.Sleeper],
+		SeaterSeats:  counts[seatmap.Seater],
+	}, nil
+}
+
+// AddSeatLayout implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) AddSeatLayout(request *dto.SeatLayoutRequest, email string) (*entities.BusSeatLayout, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in providerServiceImpl file")
+		return nil, err
+	}
+	seatMap, err := ps.designSeatMap(request, provider)
+	if err != nil {
+		return nil, err
+	}
+	layout := &entities.BusSeatLayout{}
+	layout.ProviderID = provider.ProviderID
+	layout.Name = request.Name
+	layout.Version = 1
+	if request.ParentID != 0 {
+		parent, err := ps.FindSeatLayoutByID(int(request.ParentID), email)
+		if err != nil {
+			return nil, err
+		}
+		layout.ParentID = parent.ID
+		if parent.ParentID != 0 {
+			layout.ParentID = parent.ParentID
+		}
+		versions, err := ps.repo.FindSeatLayoutVersions(layout.ParentID)
+		if err != nil {
+			log.Println("Error fetching the seat layout versions, in providerServiceImpl file")
+			return nil, err
+		}
+		for _, version := range versions {
+			if version.Version >= layout.Version {
+				layout.Version = version.Version + 1
+			}
+		}
+	}
+	layout.DeckOneRows, layout.DeckOneColumns = seatMap.Decks[0].Rows, seatMap.Decks[0].Columns
+	if len(seatMap.Decks) > 1 {
+		layout.DeckTwoRows, layout.DeckTwoColumns = seatMap.Decks[1].Rows, seatMap.Decks[1].Columns
+	}
+	layout.DeckOneLayout, layout.DeckTwoLayout, err = seatmap.NewChart(seatMap).Encode()
+	if err != nil {
+		log.Println("Error encoding the deck layouts, in providerServiceImpl file")
+		return nil, err
+	}
+	layout.SeatMap, err = seatMap.Encode()
+	if err != nil {
+		log.Println("Error encoding the seat map, in providerServiceImpl file")
+		return nil, err
+	}
+	layout, err = ps.repo.AddSeatLayout(layout)
+	if err != nil {
+		log.Println("Error Creating seat layout, in providerServiceImpl file")
+		return nil, err
+	}
+	return layout, nil
+}
+
+// FindSeatLayouts implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) FindSeatLayouts(email string) ([]*entities.BusSeatLayout, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in providerServiceImpl file")
+		return nil, err
+	}
+	layouts, err := ps.repo.FindSeatLayouts(provider.ProviderID)
+	if err != nil {
+		log.Println("Error fetching the seat layouts, in providerServiceImpl file")
+		return nil, err
+	}
+	return layouts, nil
+}
+
+// FindSeatLayoutByID implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) FindSeatLayoutByID(id int, email string) (*entities.BusSeatLayout, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in providerServiceImpl file")
+		return nil, err
+	}
+	layout, err := ps.repo.FindSeatLayoutByID(id)
+	if err != nil {
+		log.Println("Error finding the seat layout, in providerServiceImpl file")
+		return nil, err
+	}
+	if layout.ProviderID != provider.ProviderID {
+		log.Println("Seat layout belongs to another provider, in providerServiceImpl file")
+		return nil, errors.New("no seat layout found with this id")
+	}
+	return layout, nil
+}
+
+// FindSeatLayoutVersions implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) FindSeatLayoutVersions(id int, email string) ([]*entities.BusSeatLayout, error) {
+	layout, err := ps.FindSeatLayoutByID(id, email)
+	if err != nil {
+		return nil, err
+	}
+	parentID := layout.ID
+	if layout.ParentID != 0 {
+		parentID = layout.ParentID
+	}
+	versions, err := ps.repo.FindSeatLayoutVersions(parentID)
+	if err != nil {
+		log.Println("Error fetching the seat layout versions, in providerServiceImpl file")
+		return nil, err
+	}
+	return versions, nil
+}
+
+// AssignSeatLayout implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) AssignSeatLayout(id int, code string, email string) (*entities.BusType, error) {
+	layout, err := ps.FindSeatLayoutByID(id, email)
+	if err != nil {
+		return nil, err
+	}
+	seatMap, err := seatmap.FromLayout(layout, code)
+	if err != nil {
+		log.Println("Error decoding the seat map, in providerServiceImpl file")
+		return nil, err
+	}
+	buses, err := ps.repo.FindBusesByType(code, layout.ProviderID)
+	if err != nil {
+		log.Println("Error fetching the buses of the bus type, in providerServiceImpl file")
+		return nil, err
+	}
+	for _, bus := range buses {
+		if err := seatMap.CheckCapacity(bus.TotalSleeperSeats, bus.TotalPushBackSeats); err != nil {
+			log.Println("Seat layout does not match the bus, in providerServiceImpl file")
+			return nil, fmt.Errorf("bus %s: %v", bus.BusNumber, err)
+		}
+	}
+	busType, err := ps.repo.AssignSeatLayout(code, layout.ProviderID, layout.ID)
+	if err != nil {
+		log.Println("Error assigning the seat layout, in providerServiceImpl file")
+		return nil, err
+	}
+	return busType, nil
+}
+
+// designSeatMap function is used to build the seat map from the layout request and check it against the bus it is designed for.
+func (ps *ProviderServiceImpl) designSeatMap(request *dto.SeatLayoutRequest, provider *entities.ServiceProvider) (*seatmap.SeatMap, error) {
+	var seatMap *seatmap.SeatMap
+	if len(request.SeatMap) > 0 {
+		parsed, err := seatmap.Parse(request.SeatMap)
+		if err != nil {
+			log.Println("Invalid seat map, in providerServiceImpl file")
+			return nil, err
+		}
+		seatMap = parsed
+	} else {
+		classOne, classTwo := seatmap.DeckClasses(request.BusTypeCode)
+		seatMap = seatmap.Default(request.DeckOneRows, request.DeckOneColumns, classOne, request.DeckTwoRows, request.DeckTwoColumns, classTwo)
+		if len(seatMap.Decks) == 0 {
+			log.Println("Seat layout without decks, in providerServiceImpl file")
+			return nil, errors.New("seat layout needs at least one deck")
+		}
+	}
+	if request.BusID != 0 {
+		bus, err := ps.repo.FindBusByID(int(request.BusID))
+		if err != nil || bus.ProviderID != provider.ProviderID {
+			log.Println("Bus not found, in providerServiceImpl file")
+			return nil, errors.New("bus not found")
+		}
+		if err := seatMap.CheckCapacity(bus.TotalSleeperSeats, bus.TotalPushBackSeats); err != nil {
+			log.Println("Seat layout does not match the bus, in providerServiceImpl file")
+			return nil, err
+		}
+	}
+	return seatMap, nil
+}
+
 // NewProviderService function return ProviderServiceImpl of type ProviderService interface
 func NewProviderService(repo repository.ProviderRepository, jwt *middleware.JwtUtil) interfaces.ProviderService {
 	return &ProviderServiceImpl{
@@ -271,3 +463,117 @@ func NewProviderService(repo repository.ProviderRepository, jwt *middleware.JwtU
 		jwt:  jwt,
 	}
 }
+
+// AddBoardingPoint implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) AddBoardingPoint(request *dto.BoardingPointRequest, email string) (*dto.BoardingPointResponse, error) {
+	bus, stops, err := ps.providerBusRoute(int(request.BusID), email)
+	if err != nil {
+		return nil, err
+	}
+	station, err := ps.repo.FindSubStationByID(int(request.SubStationID))
+	if err != nil {
+		log.Println("Sub station not found, in providerServiceImpl file")
+		return nil, errors.New("no sub station found with this id")
+	}
+	point := &entities.BoardingPoint{
+		BusID:        bus.BusID,
+		SubStationID: station.ID,
+		StopName:     station.ParentLocation,
+		Name:         station.SubStation,
+		Address:      request.Address,
+		PointType:    request.PointType,
+		TimeOffset:   request.TimeOffset,
+	}
+	stop := stopOfPoint(stops, point)
+	if stop < 0 {
+		log.Println("Sub station is not on the route of the bus, in providerServiceImpl file")
+		return nil, errors.New("bus does not stop at " + station.ParentLocation)
+	}
+	if (point.PointType == BoardingPointType && stop == len(stops)-1) || (point.PointType == DroppingPointType && stop == 0) {
+		log.Println("Boarding point at the wrong end of the route, in providerServiceImpl file")
+		return nil, errors.New("no " + point.PointType + " at " + station.ParentLocation + " on this route")
+	}
+	point.StopName = stops[stop].StationName
+	point, err = ps.repo.AddBoardingPoint(point)
+	if err != nil {
+		log.Println("Error adding the boarding point, in providerServiceImpl file")
+		return nil, err
+	}
+	return boardingPointResponse(bus, stops, point), nil
+}
+
+// FindBoardingPoints implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) FindBoardingPoints(busID int, email string) ([]*dto.BoardingPointResponse, error) {
+	bus, stops, err := ps.providerBusRoute(busID, email)
+	if err != nil {
+		return nil, err
+	}
+	points, err := ps.repo.FindBoardingPoints(bus.BusID)
+	if err != nil {
+		log.Println("Error fetching the boarding points, in providerServiceImpl file")
+		return nil, err
+	}
+	responses := []*dto.BoardingPointResponse{}
+	for _, point := range points {
+		responses = append(responses, boardingPointResponse(bus, stops, point))
+	}
+	return responses, nil
+}
+
+// DeleteBoardingPoint implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) DeleteBoardingPoint(id int, email string) error {
+	point, err := ps.repo.FindBoardingPointByID(id)
+	if err != nil {
+		log.Println("Boarding point not found, in providerServiceImpl file")
+		return errors.New("no boarding point found with this id")
+	}
+	if _, _, err := ps.providerBusRoute(int(point.BusID), email); err != nil {
+		return err
+	}
+	return ps.repo.DeleteBoardingPoint(id)
+}
+
+// providerBusRoute function is used to fetch a bus of the provider along with the stops of the route it runs.
+func (ps *ProviderServiceImpl) providerBusRoute(busID int, email string) (*entities.Buses, []*entities.ScheduleStop, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in providerServiceImpl file")
+		return nil, nil, err
+	}
+	bus, err := ps.repo.FindBusByID(busID)
+	if err != nil || bus.ProviderID != provider.ProviderID {
+		log.Println("Bus not found for the provider, in providerServiceImpl file")
+		return nil, nil, errors.New("no bus found with this id")
+	}
+	stops, err := ps.repo.FindScheduleStops(bus.ScheduleID)
+	if err == nil && len(stops) >= 2 {
+		return bus, stops, nil
+	}
+	schedule, err := ps.repo.FindScheduleByID(int(bus.ScheduleID))
+	if err != nil {
+		log.Println("Schedule not found for the bus, in providerServiceImpl file")
+		return nil, nil, err
+	}
+	stops = []*entities.ScheduleStop{
+		{ScheduleID: schedule.ScheduleID, Sequence: 0, StationName: schedule.DepartureStation, DepartureTime: schedule.DepartureTime},
+		{ScheduleID: schedule.ScheduleID, Sequence: 1, StationName: schedule.ArrivalStation, ArrivalTime: schedule.ArrivalTime},
+	}
+	return bus, stops, nil
+}
+
+// boardingPointResponse function is used to build the response for a point, with the time worked out from its stop.
+func boardingPointResponse(bus *entities.Buses, stops []*entities.ScheduleStop, point *entities.BoardingPoint) *dto.BoardingPointResponse {
+	response := &dto.BoardingPointResponse{
+		ID:        point.ID,
+		BusID:     point.BusID,
+		BusNumber: bus.BusNumber,
+		PointType: point.PointType,
+		Name:      point.Name,
+		Address:   point.Address,
+		Stop:      point.StopName,
+	}
+	if stop := stopOfPoint(stops, point); stop >= 0 {
+		response.Time = pointTime(stops[stop], point)
+	}
+	return response
+}
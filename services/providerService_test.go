@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/repository"
+	"gobus/seatmap"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_AddSeatLayout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	provider := &entities.ServiceProvider{ProviderID: 1, Email: "abc@gmail.com"}
+	tests := []struct {
+		name       string
+		args       *dto.SeatLayoutRequest
+		beforeTest func(providerRepo *repository.MockProviderRepository)
+		want       *entities.BusSeatLayout
+		wantErr    bool
+	}{
+		{
+			name: "success first version",
+			args: &dto.SeatLayoutRequest{Name: "2x2", BusTypeCode: "SE", BusID: 1, DeckOneRows: 2, DeckOneColumns: 2},
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil)
+				providerRepo.EXPECT().FindBusByID(1).Return(&entities.Buses{BusID: 1, ProviderID: 1, TotalPushBackSeats: 4}, nil)
+				providerRepo.EXPECT().AddSeatLayout(gomock.Any()).DoAndReturn(func(layout *entities.BusSeatLayout) (*entities.BusSeatLayout, error) {
+					return layout, nil
+				})
+			},
+			want:    &entities.BusSeatLayout{ProviderID: 1, Name: "2x2", Version: 1, DeckOneRows: 2, DeckOneColumns: 2},
+			wantErr: false,
+		},
+		{
+			name: "success version numbered from the child layout",
+			args: &dto.SeatLayoutRequest{Name: "2x2 v3", BusTypeCode: "SE", ParentID: 5, DeckOneRows: 2, DeckOneColumns: 2},
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil).Times(2)
+				// layout 5 is the second version of layout 4, the new one joins the versions of 4
+				providerRepo.EXPECT().FindSeatLayoutByID(5).Return(&entities.BusSeatLayout{Model: gorm.Model{ID: 5}, ProviderID: 1, ParentID: 4, Version: 2}, nil)
+				providerRepo.EXPECT().FindSeatLayoutVersions(uint(4)).Return([]*entities.BusSeatLayout{
+					{Model: gorm.Model{ID: 4}, ProviderID: 1, Version: 1},
+					{Model: gorm.Model{ID: 5}, ProviderID: 1, ParentID: 4, Version: 2},
+				}, nil)
+				providerRepo.EXPECT().AddSeatLayout(gomock.Any()).DoAndReturn(func(layout *entities.BusSeatLayout) (*entities.BusSeatLayout, error) {
+					return layout, nil
+				})
+			},
+			want:    &entities.BusSeatLayout{ProviderID: 1, Name: "2x2 v3", Version: 3, ParentID: 4, DeckOneRows: 2, DeckOneColumns: 2},
+			wantErr: false,
+		},
+		{
+			name: "capacity does not match the bus",
+			args: &dto.SeatLayoutRequest{Name: "2x2", BusTypeCode: "SE", BusID: 1, DeckOneRows: 2, DeckOneColumns: 2},
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil)
+				providerRepo.EXPECT().FindBusByID(1).Return(&entities.Buses{BusID: 1, ProviderID: 1, TotalPushBackSeats: 40}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "bus of another provider",
+			args: &dto.SeatLayoutRequest{Name: "2x2", BusTypeCode: "SE", BusID: 1, DeckOneRows: 2, DeckOneColumns: 2},
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil)
+				providerRepo.EXPECT().FindBusByID(1).Return(&entities.Buses{BusID: 1, ProviderID: 2, TotalPushBackSeats: 4}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "parent layout of another provider",
+			args: &dto.SeatLayoutRequest{Name: "2x2 v2", BusTypeCode: "SE", ParentID: 4, DeckOneRows: 2, DeckOneColumns: 2},
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil).Times(2)
+				providerRepo.EXPECT().FindSeatLayoutByID(4).Return(&entities.BusSeatLayout{Model: gorm.Model{ID: 4}, ProviderID: 2, Version: 1}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "layout without decks",
+			args: &dto.SeatLayoutRequest{Name: "empty", BusTypeCode: "SE"},
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockProviderRepository(ctrl)
+			tt.beforeTest(mockRepo)
+			p := &ProviderServiceImpl{repo: mockRepo}
+			got, err := p.AddSeatLayout(tt.args, "abc@gmail.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.AddSeatLayout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.ProviderID != tt.want.ProviderID || got.Name != tt.want.Name || got.Version != tt.want.Version || got.ParentID != tt.want.ParentID ||
+				got.DeckOneRows != tt.want.DeckOneRows || got.DeckOneColumns != tt.want.DeckOneColumns || len(got.SeatMap) == 0 {
+				t.Errorf("services.AddSeatLayout() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_AssignSeatLayout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	provider := &entities.ServiceProvider{ProviderID: 1, Email: "abc@gmail.com"}
+	seatMap, _ := seatmap.Default(2, 2, seatmap.Seater, 0, 0, seatmap.Seater).Encode()
+	layout := func(providerID uint) *entities.BusSeatLayout {
+		return &entities.BusSeatLayout{Model: gorm.Model{ID: 4}, ProviderID: providerID, Version: 1, DeckOneRows: 2, DeckOneColumns: 2, SeatMap: seatMap}
+	}
+	tests := []struct {
+		name       string
+		beforeTest func(providerRepo *repository.MockProviderRepository)
+		want       *entities.BusType
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil)
+				providerRepo.EXPECT().FindSeatLayoutByID(4).Return(layout(1), nil)
+				providerRepo.EXPECT().FindBusesByType("SE", uint(1)).Return([]*entities.Buses{{BusID: 1, BusNumber: "KL01", TotalPushBackSeats: 4}}, nil)
+				providerRepo.EXPECT().AssignSeatLayout("SE", uint(1), uint(4)).Return(&entities.BusType{BusTypeCode: "SE", ProviderID: 1, SeatLayoutID: 4}, nil)
+			},
+			want:    &entities.BusType{BusTypeCode: "SE", ProviderID: 1, SeatLayoutID: 4},
+			wantErr: false,
+		},
+		{
+			name: "capacity does not match a bus of the type",
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil)
+				providerRepo.EXPECT().FindSeatLayoutByID(4).Return(layout(1), nil)
+				providerRepo.EXPECT().FindBusesByType("SE", uint(1)).Return([]*entities.Buses{
+					{BusID: 1, BusNumber: "KL01", TotalPushBackSeats: 4},
+					{BusID: 2, BusNumber: "KL02", TotalPushBackSeats: 40},
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "layout of another provider",
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil)
+				providerRepo.EXPECT().FindSeatLayoutByID(4).Return(layout(2), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "bus type not found",
+			beforeTest: func(providerRepo *repository.MockProviderRepository) {
+				providerRepo.EXPECT().FindProviderByEmail("abc@gmail.com").Return(provider, nil)
+				providerRepo.EXPECT().FindSeatLayoutByID(4).Return(layout(1), nil)
+				providerRepo.EXPECT().FindBusesByType("SE", uint(1)).Return(nil, nil)
+				providerRepo.EXPECT().AssignSeatLayout("SE", uint(1), uint(4)).Return(nil, errors.New("bus type not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockProviderRepository(ctrl)
+			tt.beforeTest(mockRepo)
+			p := &ProviderServiceImpl{repo: mockRepo}
+			got, err := p.AssignSeatLayout(4, "SE", "abc@gmail.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.AssignSeatLayout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && *got != *tt.want {
+				t.Errorf("services.AssignSeatLayout() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
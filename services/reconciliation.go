@@ -0,0 +1,339 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ledger"
+	"gobus/payment"
+	repository "gobus/repository/interfaces"
+	"log"
+	"strings"
+	"time"
+)
+
+// Kinds of disagreement found between the gateway and the bookings, payments and ledger.
+const (
+	IssueCapturedUnconfirmed = "Captured but unconfirmed"
+	IssueConfirmedUncaptured = "Confirmed but uncaptured"
+	IssueAmountMismatch      = "Amount mismatch"
+	IssueCreditedUnpaid      = "Credited without payment"
+)
+
+// Statuses of a reconciliation issue.
+const (
+	IssueOpen     = "Open"
+	IssueResolved = "Resolved"
+)
+
+// reconcileWindow is how far back the orders, bookings and ledger entries are checked, long enough for late captures to show up.
+const reconcileWindow = 72 * time.Hour
+
+// reconcileGrace is how old an order, booking or ledger entry has to be before it is checked.
+const reconcileGrace = time.Hour
+
+// ReconcilePayments implements interfaces.AdminService.
+func (as *AdminServiceImpl) ReconcilePayments() (*dto.PaymentReconciliation, error) {
+	rr := as.repo.Reconciliation()
+	orders, err := rr.FindPaymentOrders(time.Now().Add(-reconcileWindow))
+	if err != nil {
+		log.Println("Error fetching the payment orders, in reconciliation file")
+		return nil, err
+	}
+	bookingIDs := make([]uint, 0, len(orders))
+	orderIDs := make([]string, 0, len(orders))
+	for _, order := range orders {
+		bookingIDs = append(bookingIDs, order.BookingID)
+		orderIDs = append(orderIDs, order.OrderID)
+	}
+	bookings, err := rr.FindBookings(bookingIDs)
+	if err != nil {
+		log.Println("Error fetching the bookings, in reconciliation file")
+		return nil, err
+	}
+	statusOf := map[uint]string{}
+	for _, booking := range bookings {
+		statusOf[booking.BookingID] = booking.Status
+	}
+	payments, err := rr.FindOrderPayments(orderIDs)
+	if err != nil {
+		log.Println("Error fetching the payments, in reconciliation file")
+		return nil, err
+	}
+	stored := map[string][]*entities.RazorPay{}
+	for _, razor := range payments {
+		stored[razor.RazorPayOrderID] = append(stored[razor.RazorPayOrderID], razor)
+	}
+	report := &dto.PaymentReconciliation{Checked: len(orders), Issues: []*entities.ReconciliationIssue{}}
+	add := func(issues []*entities.ReconciliationIssue) error {
+		for _, issue := range issues {
+			added, err := rr.AddIssue(issue)
+			if err != nil {
+				return err
+			}
+			if added {
+				report.Issues = append(report.Issues, issue)
+			}
+		}
+		return nil
+	}
+	for _, order := range orders {
+		if time.Since(order.CreatedAt) < reconcileGrace {
+			// the payment of a fresh order may still be on its way
+			report.Checked--
+			continue
+		}
+		remote, err := as.gateway.FetchStatus(order.OrderID)
+		if err != nil {
+			log.Println("Unable to fetch the order", order.OrderID, "from the gateway, in reconciliation file")
+			report.Unreachable++
+			continue
+		}
+		if err := add(orderIssues(order, remote, stored[order.OrderID], statusOf[order.BookingID])); err != nil {
+			return nil, err
+		}
+	}
+	if err := as.reconcileBookings(rr, report, add); err != nil {
+		return nil, err
+	}
+	if err := as.reconcileLedger(rr, report, add); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// reconcileBookings method is used to check the bookings confirmed in the window have an order and a captured payment behind them.
+func (as *AdminServiceImpl) reconcileBookings(rr repository.ReconciliationRepository, report *dto.PaymentReconciliation, add func([]*entities.ReconciliationIssue) error) error {
+	bookings, err := rr.FindConfirmedBookings(time.Now().Add(-reconcileWindow))
+	if err != nil {
+		log.Println("Error fetching the confirmed bookings, in reconciliation file")
+		return err
+	}
+	bookingIDs := make([]uint, 0, len(bookings))
+	for _, booking := range bookings {
+		bookingIDs = append(bookingIDs, booking.BookingID)
+	}
+	orders, err := rr.FindBookingOrders(bookingIDs)
+	if err != nil {
+		log.Println("Error fetching the orders of the bookings, in reconciliation file")
+		return err
+	}
+	ordersOf := map[uint][]*entities.PaymentOrder{}
+	for _, order := range orders {
+		ordersOf[order.BookingID] = append(ordersOf[order.BookingID], order)
+	}
+	payments, err := rr.FindBookingPayments(bookingIDs)
+	if err != nil {
+		log.Println("Error fetching the payments of the bookings, in reconciliation file")
+		return err
+	}
+	paymentsOf := map[uint][]*entities.RazorPay{}
+	for _, razor := range payments {
+		paymentsOf[razor.BookID] = append(paymentsOf[razor.BookID], razor)
+	}
+	for _, booking := range bookings {
+		if time.Since(booking.CreatedAt) < reconcileGrace {
+			continue
+		}
+		report.Checked++
+		if err := add(bookingIssues(booking, ordersOf[booking.BookingID], paymentsOf[booking.BookingID])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileLedger method is used to check the ledger entries in the window that move money in from the gateway, to the wallets or escrows, have a payment behind them.
+func (as *AdminServiceImpl) reconcileLedger(rr repository.ReconciliationRepository, report *dto.PaymentReconciliation, add func([]*entities.ReconciliationIssue) error) error {
+	entries, err := rr.FindEntriesFrom(ledger.Razorpay, time.Now().Add(-reconcileWindow))
+	if err != nil {
+		log.Println("Error fetching the ledger entries, in reconciliation file")
+		return err
+	}
+	var bookingIDs, topupIDs []uint
+	for _, entry := range entries {
+		if entry.BookingID != 0 {
+			bookingIDs = append(bookingIDs, entry.BookingID)
+		}
+		var topupID uint
+		if _, err := fmt.Sscanf(entry.Key, "topup:%d", &topupID); err == nil {
+			topupIDs = append(topupIDs, topupID)
+		}
+	}
+	payments, err := rr.FindBookingPayments(bookingIDs)
+	if err != nil {
+		log.Println("Error fetching the payments of the bookings, in reconciliation file")
+		return err
+	}
+	paymentsOf := map[uint][]*entities.RazorPay{}
+	for _, razor := range payments {
+		paymentsOf[razor.BookID] = append(paymentsOf[razor.BookID], razor)
+	}
+	topups, err := rr.FindTopups(topupIDs)
+	if err != nil {
+		log.Println("Error fetching the wallet top-ups, in reconciliation file")
+		return err
+	}
+	topupOf := map[string]*entities.WalletTopup{}
+	for _, topup := range topups {
+		topupOf[fmt.Sprintf("topup:%d", topup.ID)] = topup
+	}
+	for _, entry := range entries {
+		if time.Since(entry.CreatedAt) < reconcileGrace {
+			continue
+		}
+		report.Checked++
+		if err := add(entryIssues(entry, paymentsOf[entry.BookingID], topupOf[entry.Key])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderIssues function is used to compare the order as the gateway has it with the order raised for the booking and the payments stored for it.
+func orderIssues(order *entities.PaymentOrder, remote *payment.Order, stored []*entities.RazorPay, bookingStatus string) []*entities.ReconciliationIssue {
+	var issues []*entities.ReconciliationIssue
+	issue := func(kind string, paymentID string, expected int64, actual int64, detail string) {
+		issues = append(issues, &entities.ReconciliationIssue{
+			Kind:      kind,
+			OrderID:   order.OrderID,
+			PaymentID: paymentID,
+			BookingID: order.BookingID,
+			Expected:  expected,
+			Actual:    actual,
+			Detail:    detail,
+			Status:    IssueOpen,
+		})
+	}
+	if remote.Amount != order.Amount {
+		issue(IssueAmountMismatch, "", order.Amount, remote.Amount, "gateway has another amount for the order")
+	}
+	byID := map[string]*entities.RazorPay{}
+	for _, razor := range stored {
+		byID[razor.RazorPaymentID] = razor
+	}
+	captured := map[string]bool{}
+	for _, paid := range remote.Payments {
+		// a payment refunded in full was captured before
+		if paid.Status != payment.PaymentCaptured && paid.Status != payment.PaymentRefunded {
+			continue
+		}
+		captured[paid.ID] = true
+		razor := byID[paid.ID]
+		switch {
+		case razor == nil || razor.Status == PaymentFailed:
+			issue(IssueCapturedUnconfirmed, paid.ID, order.Amount, paid.Amount, "booking is "+bookingStatus)
+		case ledger.Paisa(razor.AmountPaid) != paid.Amount:
+			issue(IssueAmountMismatch, paid.ID, ledger.Paisa(razor.AmountPaid), paid.Amount, "gateway captured another amount than was stored")
+		}
+	}
+	for _, razor := range stored {
+		if razor.Status == PaymentFailed || captured[razor.RazorPaymentID] {
+			continue
+		}
+		issue(IssueConfirmedUncaptured, razor.RazorPaymentID, ledger.Paisa(razor.AmountPaid), 0, "payment is stored as "+paymentStatus(razor)+", booking is "+bookingStatus)
+	}
+	return issues
+}
+
+// captures function reports whether the stored payment was captured by the gateway for its booking.
+func captures(razor *entities.RazorPay) bool {
+	switch paymentStatus(razor) {
+	case PaymentCaptured, PaymentPartiallyRefunded, PaymentRefunded:
+		return true
+	}
+	return false
+}
+
+// bookingIssues function is used to check the confirmed booking has an order raised for it and a captured payment, unless it was paid from the wallet or in full by the booking it was rescheduled from.
+func bookingIssues(booking *entities.Booking, orders []*entities.PaymentOrder, stored []*entities.RazorPay) []*entities.ReconciliationIssue {
+	expected := ledger.Paisa(booking.FarePostDiscount - booking.RescheduleCredit)
+	if booking.PaymentType == "Wallet" || expected <= 0 {
+		return nil
+	}
+	if len(orders) == 0 {
+		return []*entities.ReconciliationIssue{{Kind: IssueConfirmedUncaptured, BookingID: booking.BookingID, Expected: expected, Detail: "booking is " + booking.Status + " without a payment order", Status: IssueOpen}}
+	}
+	for _, razor := range stored {
+		if captures(razor) {
+			return nil
+		}
+	}
+	return []*entities.ReconciliationIssue{{Kind: IssueConfirmedUncaptured, OrderID: orders[len(orders)-1].OrderID, BookingID: booking.BookingID, Expected: expected, Detail: "booking is " + booking.Status + " without a captured payment", Status: IssueOpen}}
+}
+
+// entryIssues function is used to check the ledger entry moving money in from the gateway matches a payment, the captured payment of its booking for a fare, the payment it names for a payment the booking could not take and the credited top-up for a wallet top-up.
+func entryIssues(entry *entities.LedgerEntry, stored []*entities.RazorPay, topup *entities.WalletTopup) []*entities.ReconciliationIssue {
+	var amount int64
+	for _, line := range entry.Lines {
+		if line.Amount > 0 {
+			amount += line.Amount
+		}
+	}
+	issue := &entities.ReconciliationIssue{Kind: IssueCreditedUnpaid, BookingID: entry.BookingID, Expected: amount, Reference: entry.Key, Status: IssueOpen}
+	switch {
+	case entry.Kind == ledger.KindPayment:
+		for _, razor := range stored {
+			if captures(razor) {
+				return nil
+			}
+		}
+		issue.Detail = "fare entry without a captured payment of the booking"
+	case entry.Kind == ledger.KindRefund && strings.HasSuffix(entry.Key, ":unapplied"):
+		issue.PaymentID = strings.TrimSuffix(strings.TrimPrefix(entry.Key, "payment:"), ":unapplied")
+		for _, razor := range stored {
+			if razor.RazorPaymentID == issue.PaymentID && razor.Status != PaymentFailed {
+				return nil
+			}
+		}
+		issue.Detail = "wallet credited for a payment that is not stored"
+	case entry.Kind == ledger.KindTopup && topup != nil:
+		issue.OrderID, issue.PaymentID = topup.OrderID, topup.PaymentID
+		if topup.Status == TopupCredited && topup.PaymentID != "" {
+			return nil
+		}
+		issue.Detail = "wallet credited for a top-up that is " + topup.Status
+	default:
+		issue.Detail = entry.Kind + " entry without a payment"
+	}
+	return []*entities.ReconciliationIssue{issue}
+}
+
+// ViewReconciliationIssues implements interfaces.AdminService.
+func (as *AdminServiceImpl) ViewReconciliationIssues(status string) ([]*entities.ReconciliationIssue, error) {
+	if status != "" && status != IssueOpen && status != IssueResolved {
+		return nil, errors.New("status can only be Open or Resolved")
+	}
+	issues, err := as.repo.Reconciliation().FindIssues(status)
+	if err != nil {
+		log.Println("Error fetching the reconciliation issues, in reconciliation file")
+		return nil, err
+	}
+	return issues, nil
+}
+
+// ResolveReconciliationIssue implements interfaces.AdminService.
+func (as *AdminServiceImpl) ResolveReconciliationIssue(id int, resolution *dto.IssueResolution) (*entities.ReconciliationIssue, error) {
+	var issue *entities.ReconciliationIssue
+	err := as.repo.Reconciliation().WithTx(func(tx repository.ReconciliationRepository) error {
+		var err error
+		issue, err = tx.FindIssueForUpdate(uint(id))
+		if err != nil {
+			return errors.New("no reconciliation issue found with this id")
+		}
+		if issue.Status == IssueResolved {
+			return errors.New("issue is already resolved")
+		}
+		now := time.Now()
+		issue.Status = IssueResolved
+		issue.Remarks = resolution.Remarks
+		issue.ResolvedAt = &now
+		return tx.UpdateIssue(issue)
+	})
+	if err != nil {
+		log.Println("Unable to resolve the reconciliation issue, in reconciliation file")
+		return nil, err
+	}
+	return issue, nil
+}
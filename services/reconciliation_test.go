@@ -0,0 +1,456 @@
+package services
+
+import (
+	"errors"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ledger"
+	"gobus/payment"
+	"gobus/repository"
+	"gobus/repository/interfaces"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_orderIssues(t *testing.T) {
+	order := &entities.PaymentOrder{OrderID: "order_1", BookingID: 1, Amount: 50000}
+	captured := func(id string, amount int64) payment.Payment {
+		return payment.Payment{ID: id, OrderID: "order_1", Amount: amount, Currency: "INR", Status: payment.PaymentCaptured}
+	}
+	type args struct {
+		remote *payment.Order
+		stored []*entities.RazorPay
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*entities.ReconciliationIssue
+	}{
+		{
+			name: "agrees with the gateway",
+			args: args{
+				remote: &payment.Order{ID: "order_1", Amount: 50000, Payments: []payment.Payment{captured("pay_1", 50000)}},
+				stored: []*entities.RazorPay{{RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentCaptured}},
+			},
+			want: nil,
+		},
+		{
+			name: "refunded in full at the gateway",
+			args: args{
+				remote: &payment.Order{ID: "order_1", Amount: 50000, Payments: []payment.Payment{{ID: "pay_1", Amount: 50000, Status: payment.PaymentRefunded}}},
+				stored: []*entities.RazorPay{{RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentRefunded}},
+			},
+			want: nil,
+		},
+		{
+			name: "captured at the gateway, nothing stored",
+			args: args{
+				remote: &payment.Order{ID: "order_1", Amount: 50000, Payments: []payment.Payment{captured("pay_1", 50000)}},
+			},
+			want: []*entities.ReconciliationIssue{{Kind: IssueCapturedUnconfirmed, OrderID: "order_1", PaymentID: "pay_1", BookingID: 1, Expected: 50000, Actual: 50000, Detail: "booking is Expired", Status: IssueOpen}},
+		},
+		{
+			name: "captured at the gateway, stored as failed",
+			args: args{
+				remote: &payment.Order{ID: "order_1", Amount: 50000, Payments: []payment.Payment{captured("pay_1", 50000)}},
+				stored: []*entities.RazorPay{{RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentFailed}},
+			},
+			want: []*entities.ReconciliationIssue{{Kind: IssueCapturedUnconfirmed, OrderID: "order_1", PaymentID: "pay_1", BookingID: 1, Expected: 50000, Actual: 50000, Detail: "booking is Expired", Status: IssueOpen}},
+		},
+		{
+			name: "stored as captured, the gateway never captured it",
+			args: args{
+				remote: &payment.Order{ID: "order_1", Amount: 50000},
+				stored: []*entities.RazorPay{{RazorPaymentID: "pay_ghost", AmountPaid: 500, Status: PaymentCaptured}},
+			},
+			want: []*entities.ReconciliationIssue{{Kind: IssueConfirmedUncaptured, OrderID: "order_1", PaymentID: "pay_ghost", BookingID: 1, Expected: 50000, Actual: 0, Detail: "payment is stored as Captured, booking is Expired", Status: IssueOpen}},
+		},
+		{
+			name: "stored with less than was captured",
+			args: args{
+				remote: &payment.Order{ID: "order_1", Amount: 50000, Payments: []payment.Payment{captured("pay_1", 50000)}},
+				stored: []*entities.RazorPay{{RazorPaymentID: "pay_1", AmountPaid: 400, Status: PaymentCaptured}},
+			},
+			want: []*entities.ReconciliationIssue{{Kind: IssueAmountMismatch, OrderID: "order_1", PaymentID: "pay_1", BookingID: 1, Expected: 40000, Actual: 50000, Detail: "gateway captured another amount than was stored", Status: IssueOpen}},
+		},
+		{
+			name: "gateway has another amount for the order",
+			args: args{
+				remote: &payment.Order{ID: "order_1", Amount: 40000},
+			},
+			want: []*entities.ReconciliationIssue{{Kind: IssueAmountMismatch, OrderID: "order_1", BookingID: 1, Expected: 50000, Actual: 40000, Detail: "gateway has another amount for the order", Status: IssueOpen}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orderIssues(order, tt.args.remote, tt.args.stored, "Expired"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.orderIssues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_bookingIssues(t *testing.T) {
+	booking := &entities.Booking{BookingID: 1, FarePostDiscount: 500, Status: "Success", PaymentType: "Razorpay"}
+	orders := []*entities.PaymentOrder{{OrderID: "order_1", BookingID: 1, Amount: 50000}}
+	tests := []struct {
+		name    string
+		booking *entities.Booking
+		orders  []*entities.PaymentOrder
+		stored  []*entities.RazorPay
+		want    []*entities.ReconciliationIssue
+	}{
+		{
+			name:    "paid through the gateway",
+			booking: booking,
+			orders:  orders,
+			stored:  []*entities.RazorPay{{BookID: 1, RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentCaptured}},
+			want:    nil,
+		},
+		{
+			name:    "paid from the wallet",
+			booking: &entities.Booking{BookingID: 1, FarePostDiscount: 500, Status: "Success", PaymentType: "Wallet"},
+			want:    nil,
+		},
+		{
+			name:    "paid in full by the rescheduled booking",
+			booking: &entities.Booking{BookingID: 1, FarePostDiscount: 500, RescheduleCredit: 500, Status: "Success", PaymentType: "Razorpay"},
+			want:    nil,
+		},
+		{
+			name:    "confirmed without an order",
+			booking: booking,
+			want:    []*entities.ReconciliationIssue{{Kind: IssueConfirmedUncaptured, BookingID: 1, Expected: 50000, Detail: "booking is Success without a payment order", Status: IssueOpen}},
+		},
+		{
+			name:    "confirmed with a failed payment",
+			booking: booking,
+			orders:  orders,
+			stored:  []*entities.RazorPay{{BookID: 1, RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentFailed}},
+			want:    []*entities.ReconciliationIssue{{Kind: IssueConfirmedUncaptured, OrderID: "order_1", BookingID: 1, Expected: 50000, Detail: "booking is Success without a captured payment", Status: IssueOpen}},
+		},
+		{
+			name:    "confirmed with the payment credited to the wallet",
+			booking: booking,
+			orders:  orders,
+			stored:  []*entities.RazorPay{{BookID: 1, RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentCredited}},
+			want:    []*entities.ReconciliationIssue{{Kind: IssueConfirmedUncaptured, OrderID: "order_1", BookingID: 1, Expected: 50000, Detail: "booking is Success without a captured payment", Status: IssueOpen}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bookingIssues(tt.booking, tt.orders, tt.stored); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.bookingIssues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_entryIssues(t *testing.T) {
+	entry := func(key string, kind string, bookingID uint) *entities.LedgerEntry {
+		return &entities.LedgerEntry{Key: key, Kind: kind, BookingID: bookingID, Lines: []*entities.LedgerLine{{AccountID: 1, Amount: -50000}, {AccountID: 2, Amount: 50000}}}
+	}
+	tests := []struct {
+		name   string
+		entry  *entities.LedgerEntry
+		stored []*entities.RazorPay
+		topup  *entities.WalletTopup
+		want   []*entities.ReconciliationIssue
+	}{
+		{
+			name:   "fare paid with a captured payment",
+			entry:  entry("booking:1:payment", ledger.KindPayment, 1),
+			stored: []*entities.RazorPay{{BookID: 1, RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentCaptured}},
+			want:   nil,
+		},
+		{
+			name:  "fare paid without a payment",
+			entry: entry("booking:1:payment", ledger.KindPayment, 1),
+			want:  []*entities.ReconciliationIssue{{Kind: IssueCreditedUnpaid, BookingID: 1, Expected: 50000, Reference: "booking:1:payment", Detail: "fare entry without a captured payment of the booking", Status: IssueOpen}},
+		},
+		{
+			name:   "payment the booking could not take is stored",
+			entry:  entry("payment:pay_1:unapplied", ledger.KindRefund, 1),
+			stored: []*entities.RazorPay{{BookID: 1, RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentCredited}},
+			want:   nil,
+		},
+		{
+			name:   "payment the booking could not take is not stored",
+			entry:  entry("payment:pay_9:unapplied", ledger.KindRefund, 1),
+			stored: []*entities.RazorPay{{BookID: 1, RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentCredited}},
+			want:   []*entities.ReconciliationIssue{{Kind: IssueCreditedUnpaid, PaymentID: "pay_9", BookingID: 1, Expected: 50000, Reference: "payment:pay_9:unapplied", Detail: "wallet credited for a payment that is not stored", Status: IssueOpen}},
+		},
+		{
+			name:  "top-up credited with its payment",
+			entry: entry("topup:1", ledger.KindTopup, 0),
+			topup: &entities.WalletTopup{ID: 1, OrderID: "order_1", PaymentID: "pay_1", Status: TopupCredited},
+			want:  nil,
+		},
+		{
+			name:  "top-up credited before it was paid",
+			entry: entry("topup:1", ledger.KindTopup, 0),
+			topup: &entities.WalletTopup{ID: 1, OrderID: "order_1", Status: TopupCreated},
+			want:  []*entities.ReconciliationIssue{{Kind: IssueCreditedUnpaid, OrderID: "order_1", Expected: 50000, Reference: "topup:1", Detail: "wallet credited for a top-up that is Created", Status: IssueOpen}},
+		},
+		{
+			name:  "wallet credited by the gateway for anything else",
+			entry: entry("refund:1:wallet", ledger.KindRefund, 1),
+			want:  []*entities.ReconciliationIssue{{Kind: IssueCreditedUnpaid, BookingID: 1, Expected: 50000, Reference: "refund:1:wallet", Detail: "refund entry without a payment", Status: IssueOpen}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entryIssues(tt.entry, tt.stored, tt.topup); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.entryIssues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ReconcilePayments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	earlier := time.Now().Add(-2 * time.Hour)
+	expectNothingElse := func(reconRepo *repository.MockReconciliationRepository) {
+		reconRepo.EXPECT().FindConfirmedBookings(gomock.Any()).Return(nil, nil)
+		reconRepo.EXPECT().FindBookingOrders(gomock.Any()).Return(nil, nil)
+		reconRepo.EXPECT().FindBookingPayments(gomock.Any()).Return(nil, nil).Times(2)
+		reconRepo.EXPECT().FindEntriesFrom(gomock.Any(), gomock.Any()).Return(nil, nil)
+		reconRepo.EXPECT().FindTopups(gomock.Any()).Return(nil, nil)
+	}
+	tests := []struct {
+		name       string
+		beforeTest func(reconRepo *repository.MockReconciliationRepository, gateway *payment.FakeGateway)
+		checked    int
+		issues     int
+		wantErr    bool
+	}{
+		{
+			name: "success issues found for the first time are reported",
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository, gateway *payment.FakeGateway) {
+				unconfirmed, _ := gateway.CreateOrder(50000, "GB7K2M9Q")
+				gateway.Pay(unconfirmed.ID)
+				known, _ := gateway.CreateOrder(50000, "GB4X8N2P")
+				gateway.Pay(known.ID)
+				agreed, _ := gateway.CreateOrder(50000, "GB9R3T6W")
+				paid, _, _ := gateway.Pay(agreed.ID)
+				fresh, _ := gateway.CreateOrder(50000, "GB2H5J8L")
+				gateway.Pay(fresh.ID)
+				reconRepo.EXPECT().FindPaymentOrders(gomock.Any()).Return([]*entities.PaymentOrder{
+					{OrderID: unconfirmed.ID, BookingID: 1, Amount: 50000, CreatedAt: earlier},
+					{OrderID: known.ID, BookingID: 2, Amount: 50000, CreatedAt: earlier},
+					{OrderID: agreed.ID, BookingID: 3, Amount: 50000, CreatedAt: earlier},
+					{OrderID: fresh.ID, BookingID: 4, Amount: 50000, CreatedAt: time.Now()},
+				}, nil)
+				reconRepo.EXPECT().FindBookings([]uint{1, 2, 3, 4}).Return([]*entities.Booking{{BookingID: 1, Status: "Expired"}, {BookingID: 2, Status: "Expired"}, {BookingID: 3, Status: "Success"}}, nil)
+				reconRepo.EXPECT().FindOrderPayments([]string{unconfirmed.ID, known.ID, agreed.ID, fresh.ID}).Return([]*entities.RazorPay{
+					{BookID: 3, RazorPaymentID: paid.ID, RazorPayOrderID: agreed.ID, AmountPaid: 500, Status: PaymentCaptured},
+				}, nil)
+				reconRepo.EXPECT().AddIssue(gomock.Any()).DoAndReturn(func(issue *entities.ReconciliationIssue) (bool, error) {
+					if issue.Kind != IssueCapturedUnconfirmed || issue.BookingID != 1 {
+						t.Errorf("services.ReconcilePayments() added %+v", issue)
+					}
+					return true, nil
+				})
+				// found on an earlier run
+				reconRepo.EXPECT().AddIssue(gomock.Any()).Return(false, nil)
+				expectNothingElse(reconRepo)
+			},
+			checked: 3,
+			issues:  1,
+			wantErr: false,
+		},
+		{
+			name: "success order the gateway cannot find is counted",
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository, gateway *payment.FakeGateway) {
+				reconRepo.EXPECT().FindPaymentOrders(gomock.Any()).Return([]*entities.PaymentOrder{{OrderID: "order_lost", BookingID: 1, Amount: 50000, CreatedAt: earlier}}, nil)
+				reconRepo.EXPECT().FindBookings([]uint{1}).Return(nil, nil)
+				reconRepo.EXPECT().FindOrderPayments([]string{"order_lost"}).Return(nil, nil)
+				expectNothingElse(reconRepo)
+			},
+			checked: 1,
+			issues:  0,
+			wantErr: false,
+		},
+		{
+			name: "success confirmed bookings and ledger entries are checked",
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository, gateway *payment.FakeGateway) {
+				reconRepo.EXPECT().FindPaymentOrders(gomock.Any()).Return(nil, nil)
+				reconRepo.EXPECT().FindBookings([]uint{}).Return(nil, nil)
+				reconRepo.EXPECT().FindOrderPayments([]string{}).Return(nil, nil)
+				reconRepo.EXPECT().FindConfirmedBookings(gomock.Any()).Return([]*entities.Booking{
+					{BookingID: 1, FarePostDiscount: 500, Status: "Success", PaymentType: "Razorpay", CreatedAt: earlier},
+					{BookingID: 2, FarePostDiscount: 500, Status: "Success", PaymentType: "Razorpay", CreatedAt: earlier},
+					{BookingID: 3, FarePostDiscount: 500, Status: "Success", PaymentType: "Razorpay", CreatedAt: time.Now()},
+				}, nil)
+				reconRepo.EXPECT().FindBookingOrders([]uint{1, 2, 3}).Return([]*entities.PaymentOrder{{OrderID: "order_2", BookingID: 2, Amount: 50000}}, nil)
+				reconRepo.EXPECT().FindBookingPayments([]uint{1, 2, 3}).Return([]*entities.RazorPay{{BookID: 2, RazorPaymentID: "pay_2", RazorPayOrderID: "order_2", AmountPaid: 500, Status: PaymentCaptured}}, nil)
+				reconRepo.EXPECT().AddIssue(gomock.Any()).DoAndReturn(func(issue *entities.ReconciliationIssue) (bool, error) {
+					if issue.Kind != IssueConfirmedUncaptured || issue.BookingID != 1 {
+						t.Errorf("services.ReconcilePayments() added %+v", issue)
+					}
+					return true, nil
+				})
+				reconRepo.EXPECT().FindEntriesFrom(ledger.Razorpay, gomock.Any()).Return([]*entities.LedgerEntry{
+					{Key: "booking:2:payment", Kind: ledger.KindPayment, BookingID: 2, CreatedAt: earlier, Lines: []*entities.LedgerLine{{AccountID: 1, Amount: -50000}, {AccountID: 2, Amount: 50000}}},
+					{Key: "topup:7", Kind: ledger.KindTopup, CreatedAt: earlier, Lines: []*entities.LedgerLine{{AccountID: 1, Amount: -20000}, {AccountID: 3, Amount: 20000}}},
+				}, nil)
+				reconRepo.EXPECT().FindBookingPayments([]uint{2}).Return([]*entities.RazorPay{{BookID: 2, RazorPaymentID: "pay_2", RazorPayOrderID: "order_2", AmountPaid: 500, Status: PaymentCaptured}}, nil)
+				reconRepo.EXPECT().FindTopups([]uint{7}).Return([]*entities.WalletTopup{{ID: 7, OrderID: "order_7", Status: TopupCreated}}, nil)
+				reconRepo.EXPECT().AddIssue(gomock.Any()).DoAndReturn(func(issue *entities.ReconciliationIssue) (bool, error) {
+					if issue.Kind != IssueCreditedUnpaid || issue.Reference != "topup:7" {
+						t.Errorf("services.ReconcilePayments() added %+v", issue)
+					}
+					return true, nil
+				})
+			},
+			checked: 4,
+			issues:  2,
+			wantErr: false,
+		},
+		{
+			name: "ledger entries could not be listed",
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository, gateway *payment.FakeGateway) {
+				reconRepo.EXPECT().FindPaymentOrders(gomock.Any()).Return(nil, nil)
+				reconRepo.EXPECT().FindBookings([]uint{}).Return(nil, nil)
+				reconRepo.EXPECT().FindOrderPayments([]string{}).Return(nil, nil)
+				reconRepo.EXPECT().FindConfirmedBookings(gomock.Any()).Return(nil, nil)
+				reconRepo.EXPECT().FindBookingOrders([]uint{}).Return(nil, nil)
+				reconRepo.EXPECT().FindBookingPayments([]uint{}).Return(nil, nil)
+				reconRepo.EXPECT().FindEntriesFrom(ledger.Razorpay, gomock.Any()).Return(nil, errors.New("oops"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "orders could not be listed",
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository, gateway *payment.FakeGateway) {
+				reconRepo.EXPECT().FindPaymentOrders(gomock.Any()).Return(nil, errors.New("oops"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := payment.NewFakeGateway("")
+			mockAdmin := repository.NewMockAdminRepository(ctrl)
+			mockRecon := repository.NewMockReconciliationRepository(ctrl)
+			mockAdmin.EXPECT().Reconciliation().Return(mockRecon).AnyTimes()
+			tt.beforeTest(mockRecon, gateway)
+			a := &AdminServiceImpl{repo: mockAdmin, gateway: gateway}
+			got, err := a.ReconcilePayments()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.ReconcilePayments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.Checked != tt.checked || len(got.Issues) != tt.issues) {
+				t.Errorf("services.ReconcilePayments() checked %d with issues %+v, want %d and %d", got.Checked, got.Issues, tt.checked, tt.issues)
+			}
+		})
+	}
+}
+
+func Test_ResolveReconciliationIssue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		id         int
+		beforeTest func(reconRepo *repository.MockReconciliationRepository)
+		wantErr    bool
+	}{
+		{
+			name: "success issue resolved",
+			id:   1,
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository) {
+				reconRepo.EXPECT().FindIssueForUpdate(uint(1)).Return(&entities.ReconciliationIssue{ID: 1, Kind: IssueCapturedUnconfirmed, Status: IssueOpen}, nil)
+				reconRepo.EXPECT().UpdateIssue(gomock.Any()).DoAndReturn(func(issue *entities.ReconciliationIssue) error {
+					if issue.Status != IssueResolved || issue.Remarks != "refunded by hand" || issue.ResolvedAt == nil {
+						t.Errorf("services.ResolveReconciliationIssue() stored %+v", issue)
+					}
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "issue resolved before",
+			id:   1,
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository) {
+				reconRepo.EXPECT().FindIssueForUpdate(uint(1)).Return(&entities.ReconciliationIssue{ID: 1, Status: IssueResolved}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no issue",
+			id:   9,
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository) {
+				reconRepo.EXPECT().FindIssueForUpdate(uint(9)).Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAdmin := repository.NewMockAdminRepository(ctrl)
+			mockRecon := repository.NewMockReconciliationRepository(ctrl)
+			mockAdmin.EXPECT().Reconciliation().Return(mockRecon).AnyTimes()
+			mockRecon.EXPECT().WithTx(gomock.Any()).DoAndReturn(func(fn func(tx interfaces.ReconciliationRepository) error) error {
+				return fn(mockRecon)
+			}).AnyTimes()
+			tt.beforeTest(mockRecon)
+			a := &AdminServiceImpl{repo: mockAdmin}
+			if _, err := a.ResolveReconciliationIssue(tt.id, &dto.IssueResolution{Remarks: "refunded by hand"}); (err != nil) != tt.wantErr {
+				t.Errorf("services.ResolveReconciliationIssue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ViewReconciliationIssues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	open := []*entities.ReconciliationIssue{{ID: 2, Kind: IssueAmountMismatch, Status: IssueOpen}}
+	tests := []struct {
+		name       string
+		status     string
+		beforeTest func(reconRepo *repository.MockReconciliationRepository)
+		want       []*entities.ReconciliationIssue
+		wantErr    bool
+	}{
+		{
+			name:   "success open issues",
+			status: IssueOpen,
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository) {
+				reconRepo.EXPECT().FindIssues(IssueOpen).Return(open, nil)
+			},
+			want:    open,
+			wantErr: false,
+		},
+		{
+			name:       "unknown status",
+			status:     "Closed",
+			beforeTest: func(reconRepo *repository.MockReconciliationRepository) {},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAdmin := repository.NewMockAdminRepository(ctrl)
+			mockRecon := repository.NewMockReconciliationRepository(ctrl)
+			mockAdmin.EXPECT().Reconciliation().Return(mockRecon).AnyTimes()
+			tt.beforeTest(mockRecon)
+			a := &AdminServiceImpl{repo: mockAdmin}
+			got, err := a.ViewReconciliationIssues(tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.ViewReconciliationIssues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.ViewReconciliationIssues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
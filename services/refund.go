@@ -0,0 +1,217 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ledger"
+	"gobus/payment"
+	repository "gobus/repository/interfaces"
+	"log"
+	"time"
+)
+
+// Places a refund can be sent to.
+const (
+	RefundToWallet = "wallet"
+	RefundToSource = "source"
+)
+
+// Statuses of a refund sent back through the payment gateway.
+const (
+	RefundPending   = "Pending"
+	RefundSending   = "Sending"
+	RefundIssued    = "Issued"
+	RefundProcessed = "Processed"
+	RefundFailed    = "Failed"
+	RefundReview    = "Needs Review"
+)
+
+// maxRefundAttempts is how often a refund is tried through the gateway before it is left for an admin to review.
+const maxRefundAttempts = 5
+
+// refundDestination function returns where the refund of the user goes, the one asked for with the cancellation or else the preference of the user, the wallet unless told otherwise.
+func refundDestination(requested string, user *entities.User) (string, error) {
+	switch requested {
+	case RefundToWallet, RefundToSource:
+		return requested, nil
+	case "":
+	default:
+		return "", errors.New("refund can only go to the wallet or the source")
+	}
+	if user != nil && user.RefundTo == RefundToSource {
+		return RefundToSource, nil
+	}
+	return RefundToWallet, nil
+}
+
+// refundBooking function is used to move the refund of the booking out of the source account, back to the payment the booking was paid with through the gateway when it goes to the source and otherwise to the user wallet. What the payment cannot take back goes to the wallet. The key posts the refund once. It returns the refund left to issue through the gateway, if any.
+func refundBooking(rr repository.RefundRepository, booking *entities.Booking, source string, amount int64, key string, destination string) (*entities.Refund, error) {
+	var issued *entities.Refund
+	if destination == RefundToSource {
+		razor, err := rr.FindBookingPayment(booking.BookingID)
+		if err != nil {
+			return nil, err
+		}
+		refundable := int64(0)
+		if razor != nil {
+			refunded, err := rr.RefundedFrom(razor.RazorPaymentID)
+			if err != nil {
+				return nil, err
+			}
+			refundable = ledger.Paisa(razor.AmountPaid) - refunded
+		}
+		if refundable > 0 {
+			issued = &entities.Refund{
+				BookingID: booking.BookingID,
+				UserID:    booking.UserID,
+				PaymentID: razor.RazorPaymentID,
+				Key:       key,
+				Amount:    amount,
+				Status:    RefundPending,
+			}
+			if issued.Amount > refundable {
+				issued.Amount = refundable
+			}
+			// the refund leaves through the gateway once it takes it
+			if err := postEntry(rr.Ledger(), ledger.Transfer(key, ledger.KindRefund, booking.BookingID, "Refund to the payment", source, ledger.Razorpay, issued.Amount)); err != nil {
+				return nil, err
+			}
+			if err := rr.AddRefund(issued); err != nil {
+				return nil, err
+			}
+			amount -= issued.Amount
+			key += ":wallet"
+		}
+	}
+	if amount > 0 {
+		if err := postEntry(rr.Ledger(), ledger.Transfer(key, ledger.KindRefund, booking.BookingID, "Refund to the wallet", source, ledger.UserAccount(booking.UserID), amount)); err != nil {
+			return nil, err
+		}
+	}
+	return issued, nil
+}
+
+// issueRefund function is used to send the refund to the gateway. The try is recorded as sending before the gateway is called outside of the transaction, and a try cut short is looked up at the gateway by its receipt before the refund is sent again. A failure is kept for the next try and once the tries run out the refund is left for an admin to review. It returns the refund as it stands afterwards.
+func issueRefund(rr repository.RefundRepository, gateway payment.PaymentGateway, id uint) (*entities.Refund, error) {
+	var refund *entities.Refund
+	sending := false
+	err := rr.WithTx(func(tx repository.RefundRepository) error {
+		var err error
+		refund, err = tx.FindRefundForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if refund.Status != RefundPending && refund.Status != RefundFailed && refund.Status != RefundSending {
+			return nil
+		}
+		refund.Attempts++
+		refund.Status = RefundSending
+		sending = true
+		return tx.UpdateRefund(refund)
+	})
+	if err != nil {
+		log.Println("Unable to issue the refund, in refund file")
+		return nil, err
+	}
+	if !sending {
+		return refund, nil
+	}
+	receipt := fmt.Sprintf("refund-%d", refund.ID)
+	sent, sendErr := gateway.FindRefund(refund.PaymentID, receipt)
+	if sendErr == nil && sent == nil {
+		sent, sendErr = gateway.Refund(refund.PaymentID, refund.Amount, receipt)
+	}
+	err = rr.WithTx(func(tx repository.RefundRepository) error {
+		var err error
+		refund, err = tx.FindRefundForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if refund.Status != RefundSending {
+			return nil
+		}
+		switch {
+		case sendErr == nil:
+			refund.GatewayID = sent.ID
+			refund.Status = RefundIssued
+			refund.LastError = ""
+			if sent.Status == payment.RefundProcessed {
+				now := time.Now()
+				refund.Status = RefundProcessed
+				refund.ProcessedAt = &now
+			}
+		case refund.Attempts < maxRefundAttempts:
+			log.Println("Gateway turned down the refund", refund.ID, "in refund file:", sendErr)
+			refund.Status = RefundFailed
+			refund.LastError = sendErr.Error()
+		default:
+			log.Println("Refund", refund.ID, "ran out of tries, leaving it for review, in refund file")
+			refund.Status = RefundReview
+			refund.LastError = sendErr.Error()
+		}
+		return tx.UpdateRefund(refund)
+	})
+	if err != nil {
+		log.Println("Unable to record the refund, in refund file")
+		return nil, err
+	}
+	return refund, nil
+}
+
+// refundNotice function returns the SMS telling the user where the refund of the booking went.
+func refundNotice(pnr string, issued *entities.Refund) string {
+	if issued != nil {
+		return fmt.Sprintf("The booking %s has been cancelled successfully and Rs %.2f is being refunded to the payment it was made with.", pnr, rupees(issued.Amount))
+	}
+	return fmt.Sprintf("The booking %s has been cancelled successfully and the refund has been transferred to your wallet.", pnr)
+}
+
+// SetRefundPreference implements interfaces.UserService.
+func (usi *UserServiceImpl) SetRefundPreference(request *dto.RefundPreference, email string) (*entities.User, error) {
+	user, err := usi.repo.FindUserByEmail(email)
+	if err != nil {
+		log.Println("No USER EXISTS, in refund file")
+		return nil, err
+	}
+	if user.RefundTo, err = refundDestination(request.RefundTo, nil); err != nil {
+		return nil, err
+	}
+	if err := usi.repo.SetRefundTo(user.ID, user.RefundTo); err != nil {
+		log.Println("Unable to save the refund preference, in refund file")
+		return nil, err
+	}
+	return user, nil
+}
+
+// ViewRefunds implements interfaces.UserService.
+func (usi *UserServiceImpl) ViewRefunds(email string) ([]*entities.Refund, error) {
+	user, err := usi.repo.FindUserByEmail(email)
+	if err != nil {
+		log.Println("No USER EXISTS, in refund file")
+		return nil, err
+	}
+	return usi.repo.Refunds().FindUserRefunds(user.ID)
+}
+
+// RetryRefunds implements interfaces.AdminService.
+func (as *AdminServiceImpl) RetryRefunds() (int, error) {
+	rr := as.repo.Refunds()
+	refunds, err := rr.FindUnissuedRefunds()
+	if err != nil {
+		log.Println("Error fetching the refunds to issue, in refund file")
+		return 0, err
+	}
+	issued := 0
+	for _, refund := range refunds {
+		refund, err := issueRefund(rr, as.gateway, refund.ID)
+		if err != nil {
+			return issued, err
+		}
+		if refund.Status == RefundIssued || refund.Status == RefundProcessed {
+			issued++
+		}
+	}
+	return issued, nil
+}
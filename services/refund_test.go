@@ -0,0 +1,331 @@
+package services
+
+import (
+	"errors"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/payment"
+	"gobus/repository"
+	"gobus/repository/interfaces"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_refundDestination(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		user      *entities.User
+		want      string
+		wantErr   bool
+	}{
+		{name: "asked for the source", requested: RefundToSource, user: &entities.User{}, want: RefundToSource},
+		{name: "asked for the wallet over the preference", requested: RefundToWallet, user: &entities.User{RefundTo: RefundToSource}, want: RefundToWallet},
+		{name: "preference of the user", requested: "", user: &entities.User{RefundTo: RefundToSource}, want: RefundToSource},
+		{name: "wallet unless told otherwise", requested: "", user: &entities.User{}, want: RefundToWallet},
+		{name: "unknown destination", requested: "bank", user: &entities.User{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := refundDestination(tt.requested, tt.user)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.refundDestination() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("services.refundDestination() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_refundBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	booking := &entities.Booking{BookingID: 1, UserID: 1, BusID: 1, BookingDate: "01 01 2024"}
+	tests := []struct {
+		name        string
+		destination string
+		beforeTest  func(refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository)
+		want        *entities.Refund
+		wantErr     bool
+	}{
+		{
+			name:        "success refund to the wallet",
+			destination: RefundToWallet,
+			beforeTest: func(refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				expectPost(ledgerRepo, "booking:1:cancel:1", 45000)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:        "success refund to the payment",
+			destination: RefundToSource,
+			beforeTest: func(refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				refundRepo.EXPECT().FindBookingPayment(uint(1)).Return(&entities.RazorPay{BookID: 1, RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentCaptured}, nil)
+				refundRepo.EXPECT().RefundedFrom("pay_1").Return(int64(0), nil)
+				expectPost(ledgerRepo, "booking:1:cancel:1", 45000)
+				refundRepo.EXPECT().AddRefund(gomock.Any()).Return(nil)
+			},
+			want:    &entities.Refund{BookingID: 1, UserID: 1, PaymentID: "pay_1", Key: "booking:1:cancel:1", Amount: 45000, Status: RefundPending},
+			wantErr: false,
+		},
+		{
+			name:        "success what the payment cannot take back goes to the wallet",
+			destination: RefundToSource,
+			beforeTest: func(refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				refundRepo.EXPECT().FindBookingPayment(uint(1)).Return(&entities.RazorPay{BookID: 1, RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentPartiallyRefunded}, nil)
+				refundRepo.EXPECT().RefundedFrom("pay_1").Return(int64(20000), nil)
+				expectPost(ledgerRepo, "booking:1:cancel:1", 30000)
+				refundRepo.EXPECT().AddRefund(gomock.Any()).Return(nil)
+				expectPost(ledgerRepo, "booking:1:cancel:1:wallet", 15000)
+			},
+			want:    &entities.Refund{BookingID: 1, UserID: 1, PaymentID: "pay_1", Key: "booking:1:cancel:1", Amount: 30000, Status: RefundPending},
+			wantErr: false,
+		},
+		{
+			name:        "success booking paid from the wallet",
+			destination: RefundToSource,
+			beforeTest: func(refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				refundRepo.EXPECT().FindBookingPayment(uint(1)).Return(nil, nil)
+				expectPost(ledgerRepo, "booking:1:cancel:1", 45000)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:        "payment lookup failed",
+			destination: RefundToSource,
+			beforeTest: func(refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				refundRepo.EXPECT().FindBookingPayment(uint(1)).Return(nil, errors.New("oops"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRefunds := repository.NewMockRefundRepository(ctrl)
+			mockLedger := repository.NewMockLedgerRepository(ctrl)
+			expectLedger(mockLedger)
+			mockRefunds.EXPECT().Ledger().Return(mockLedger).AnyTimes()
+			tt.beforeTest(mockRefunds, mockLedger)
+			got, err := refundBooking(mockRefunds, booking, "escrow:1:20240101", 45000, "booking:1:cancel:1", tt.destination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.refundBooking() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.refundBooking() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_RetryRefunds(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	failed := func(paymentID string, attempts int) *entities.Refund {
+		return &entities.Refund{ID: 1, BookingID: 1, UserID: 1, PaymentID: paymentID, Amount: 45000, Status: RefundFailed, Attempts: attempts}
+	}
+	expectSend := func(t *testing.T, refundRepo *repository.MockRefundRepository, from *entities.Refund, stored func(refund *entities.Refund) bool) {
+		sending := *from
+		sending.Status, sending.Attempts = RefundSending, from.Attempts+1
+		gomock.InOrder(
+			refundRepo.EXPECT().FindRefundForUpdate(uint(1)).Return(from, nil),
+			refundRepo.EXPECT().UpdateRefund(gomock.Any()).DoAndReturn(func(refund *entities.Refund) error {
+				if refund.Status != RefundSending || refund.Attempts != sending.Attempts {
+					t.Errorf("services.RetryRefunds() sent the refund as %+v", refund)
+				}
+				return nil
+			}),
+			refundRepo.EXPECT().FindRefundForUpdate(uint(1)).Return(&sending, nil),
+			refundRepo.EXPECT().UpdateRefund(gomock.Any()).DoAndReturn(func(refund *entities.Refund) error {
+				if !stored(refund) {
+					t.Errorf("services.RetryRefunds() stored the refund %+v", refund)
+				}
+				return nil
+			}),
+		)
+	}
+	tests := []struct {
+		name        string
+		failRefunds bool
+		sentBefore  bool
+		beforeTest  func(t *testing.T, adminRepo *repository.MockAdminRepository, refundRepo *repository.MockRefundRepository, paymentID string)
+		want        int
+		wantErr     bool
+	}{
+		{
+			name: "success gateway takes the refund",
+			beforeTest: func(t *testing.T, adminRepo *repository.MockAdminRepository, refundRepo *repository.MockRefundRepository, paymentID string) {
+				refundRepo.EXPECT().FindUnissuedRefunds().Return([]*entities.Refund{failed(paymentID, 1)}, nil)
+				expectSend(t, refundRepo, failed(paymentID, 1), func(refund *entities.Refund) bool {
+					return refund.Status == RefundProcessed && refund.GatewayID != "" && refund.Attempts == 2 && refund.LastError == ""
+				})
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:       "refund sent by a try cut short is not sent again",
+			sentBefore: true,
+			beforeTest: func(t *testing.T, adminRepo *repository.MockAdminRepository, refundRepo *repository.MockRefundRepository, paymentID string) {
+				cut := failed(paymentID, 1)
+				cut.Status = RefundSending
+				refundRepo.EXPECT().FindUnissuedRefunds().Return([]*entities.Refund{cut}, nil)
+				expectSend(t, refundRepo, cut, func(refund *entities.Refund) bool {
+					return refund.Status == RefundProcessed && refund.GatewayID == "rfnd_fake3" && refund.Attempts == 2
+				})
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:        "gateway turns the refund down again",
+			failRefunds: true,
+			beforeTest: func(t *testing.T, adminRepo *repository.MockAdminRepository, refundRepo *repository.MockRefundRepository, paymentID string) {
+				refundRepo.EXPECT().FindUnissuedRefunds().Return([]*entities.Refund{failed(paymentID, 1)}, nil)
+				expectSend(t, refundRepo, failed(paymentID, 1), func(refund *entities.Refund) bool {
+					return refund.Status == RefundFailed && refund.Attempts == 2 && refund.LastError != ""
+				})
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:        "refund out of tries is left for review",
+			failRefunds: true,
+			beforeTest: func(t *testing.T, adminRepo *repository.MockAdminRepository, refundRepo *repository.MockRefundRepository, paymentID string) {
+				refundRepo.EXPECT().FindUnissuedRefunds().Return([]*entities.Refund{failed(paymentID, maxRefundAttempts-1)}, nil)
+				expectSend(t, refundRepo, failed(paymentID, maxRefundAttempts-1), func(refund *entities.Refund) bool {
+					return refund.Status == RefundReview && refund.Attempts == maxRefundAttempts && refund.LastError != ""
+				})
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "refund could not be marked as sending",
+			beforeTest: func(t *testing.T, adminRepo *repository.MockAdminRepository, refundRepo *repository.MockRefundRepository, paymentID string) {
+				refundRepo.EXPECT().FindUnissuedRefunds().Return([]*entities.Refund{failed(paymentID, 1)}, nil)
+				refundRepo.EXPECT().FindRefundForUpdate(uint(1)).Return(failed(paymentID, 1), nil)
+				refundRepo.EXPECT().UpdateRefund(gomock.Any()).Return(errors.New("oops"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "refund issued meanwhile is left as it is",
+			beforeTest: func(t *testing.T, adminRepo *repository.MockAdminRepository, refundRepo *repository.MockRefundRepository, paymentID string) {
+				issued := failed(paymentID, 1)
+				issued.Status = RefundIssued
+				refundRepo.EXPECT().FindUnissuedRefunds().Return([]*entities.Refund{failed(paymentID, 1)}, nil)
+				refundRepo.EXPECT().FindRefundForUpdate(uint(1)).Return(issued, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "refunds could not be listed",
+			beforeTest: func(t *testing.T, adminRepo *repository.MockAdminRepository, refundRepo *repository.MockRefundRepository, paymentID string) {
+				refundRepo.EXPECT().FindUnissuedRefunds().Return(nil, errors.New("oops"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := payment.NewFakeGateway("")
+			order, _ := gateway.CreateOrder(50000, "GB7K2M9Q")
+			paid, _, _ := gateway.Pay(order.ID)
+			if tt.sentBefore {
+				gateway.Refund(paid.ID, 45000, "refund-1")
+			}
+			gateway.FailRefunds = tt.failRefunds
+			mockAdmin := repository.NewMockAdminRepository(ctrl)
+			mockRefunds := repository.NewMockRefundRepository(ctrl)
+			mockAdmin.EXPECT().Refunds().Return(mockRefunds).AnyTimes()
+			mockRefunds.EXPECT().WithTx(gomock.Any()).DoAndReturn(func(fn func(tx interfaces.RefundRepository) error) error {
+				return fn(mockRefunds)
+			}).AnyTimes()
+			tt.beforeTest(t, mockAdmin, mockRefunds, paid.ID)
+			a := &AdminServiceImpl{repo: mockAdmin, gateway: gateway}
+			got, err := a.RetryRefunds()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.RetryRefunds() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("services.RetryRefunds() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SetRefundPreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	tests := []struct {
+		name       string
+		request    *dto.RefundPreference
+		beforeTest func(userRepo *repository.MockUserRepository)
+		want       *entities.User
+		wantErr    bool
+	}{
+		{
+			name:    "success refunds to the payment",
+			request: &dto.RefundPreference{RefundTo: RefundToSource},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1, Email: "abc@gmail.com"}, nil)
+				userRepo.EXPECT().SetRefundTo(uint(1), RefundToSource).Return(nil)
+			},
+			want:    &entities.User{ID: 1, Email: "abc@gmail.com", RefundTo: RefundToSource},
+			wantErr: false,
+		},
+		{
+			name:    "preference could not be saved",
+			request: &dto.RefundPreference{RefundTo: RefundToWallet},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1, Email: "abc@gmail.com"}, nil)
+				userRepo.EXPECT().SetRefundTo(uint(1), RefundToWallet).Return(errors.New("oops"))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown destination",
+			request: &dto.RefundPreference{RefundTo: "bank"},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1, Email: "abc@gmail.com"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no user",
+			request: &dto.RefundPreference{RefundTo: RefundToWallet},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			tt.beforeTest(mockRepo)
+			u := &UserServiceImpl{repo: mockRepo}
+			got, err := u.SetRefundPreference(tt.request, "abc@gmail.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.SetRefundPreference() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.SetRefundPreference() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ledger"
+	repository "gobus/repository/interfaces"
+	"gobus/seathold"
+	"log"
+	"sort"
+	"time"
+)
+
+// ItemRescheduled is the status of a booking item moved to another booking.
+const ItemRescheduled = "Rescheduled"
+
+// RescheduleBooking implements interfaces.UserService.
+func (usi *UserServiceImpl) RescheduleBooking(bookID int, request *dto.RescheduleRequest, email string) (*entities.Booking, error) {
+	user, err := usi.repo.FindUserByEmail(email)
+	if err != nil {
+		log.Println("Error finding user, in reschedule file")
+		return nil, err
+	}
+	original, err := usi.repo.FindBookingByID(bookID)
+	if err != nil || original.UserID != user.ID {
+		log.Println("Booking not found for the user, in reschedule file")
+		return nil, errors.New("no booking found with this id")
+	}
+	if original.Status != "Success" && original.Status != "Awaiting Payment" {
+		log.Println("Booking is not in a reschedulable state, in reschedule file")
+		return nil, errors.New("booking cannot be rescheduled")
+	}
+	items, err := usi.repo.FindBookingItems([]uint{original.BookingID})
+	if err != nil {
+		log.Println("Error fetching the booking items, in reschedule file")
+		return nil, err
+	}
+	passengerIDs := original.PassengerID
+	if len(items) > 0 {
+		passengerIDs = nil
+		for _, item := range items {
+			if item.Status == ItemBooked {
+				passengerIDs = append(passengerIDs, int64(item.PassengerID))
+			}
+		}
+	}
+	busID := request.BusID
+	if busID == 0 {
+		busID = original.BusID
+	}
+	if busID == original.BusID && request.BookingDate == original.BookingDate {
+		return nil, errors.New("pick another date or bus to reschedule to")
+	}
+	// the discount of the original booking is kept, the coupon may have expired since
+	draft, err := usi.draftTrip(&dto.BookingRequest{
+		UsedCouponID:         original.UsedCouponID,
+		BusID:                busID,
+		PassengerID:          passengerIDs,
+		SeatsReserved:        request.SeatsReserved,
+		BookingDate:          request.BookingDate,
+		PreferredPaymentType: request.PreferredPaymentType,
+		FromStation:          original.FromStation,
+		ToStation:            original.ToStation,
+		BoardingPointID:      request.BoardingPointID,
+		DroppingPointID:      request.DroppingPointID,
+	}, user, email)
+	if err != nil {
+		return nil, err
+	}
+	draft.booking.UsedCouponID = original.UsedCouponID
+	draft.discount = bookingDiscount(original)
+	originalDay, err := time.Parse("02 01 2006", original.BookingDate)
+	if err != nil {
+		log.Println("Error parsing the date, in reschedule file")
+		return nil, err
+	}
+	holdDuration := seathold.HoldDuration()
+	var rescheduled *entities.Booking
+	var promoted []*entities.Booking
+	var issued *entities.Refund
+	err = usi.repo.WithTx(func(tx repository.UserRepository) error {
+		//Both charts are locked in the same order every caller uses so a reschedule cannot deadlock with a booking.
+		charts := []struct {
+			busID uint
+			day   time.Time
+		}{{original.BusID, originalDay}, {draft.bus.BusID, draft.day}}
+		sort.Slice(charts, func(i, j int) bool {
+			if charts[i].busID != charts[j].busID {
+				return charts[i].busID < charts[j].busID
+			}
+			return charts[i].day.Before(charts[j].day)
+		})
+		for _, chart := range charts {
+			if _, err := tx.GetChartForUpdate(int(chart.busID), chart.day); err != nil {
+				log.Println("Error fetching bus schedule, in reschedule file")
+				return err
+			}
+		}
+		booking, err := tx.FindBookingByID(bookID)
+		if err != nil {
+			return err
+		}
+		if booking.Status != "Success" && booking.Status != "Awaiting Payment" {
+			return errors.New("booking cannot be rescheduled")
+		}
+		terms, err := cancellationTerms(tx, booking)
+		if err != nil {
+			return err
+		}
+		if terms.quote.Departed {
+			return errors.New("the bus has already left, the booking cannot be rescheduled")
+		}
+		items, err := bookingItems(tx, booking)
+		if err != nil {
+			return err
+		}
+		seats := bookedSeats(items)
+		if len(seats) != len(draft.request.SeatsReserved) {
+			return errors.New("seat-passenger count mismatch")
+		}
+		chart, err := tx.GetChartForUpdate(int(booking.BusID), originalDay)
+		if err != nil {
+			return err
+		}
+		if err := releaseSeats(tx, chart, booking, seats); err != nil {
+			return err
+		}
+		if _, err := tx.UpdateChart(chart); err != nil {
+			log.Println("Could not update the chart, in reschedule file")
+			return err
+		}
+		if err := reserveDraft(tx, draft); err != nil {
+			return err
+		}
+		if draft.booking.Status == "Waitlisted" {
+			return errors.New("not enough seats left on the new bus")
+		}
+		credited, err := settleReschedule(tx, booking, draft)
+		if err != nil {
+			return err
+		}
+		newBooking := *draft.booking
+		newBooking.RescheduledFrom = booking.BookingID
+		if newBooking.Status == "Awaiting Payment" {
+			holdExpiry := time.Now().Add(holdDuration)
+			newBooking.HoldExpiresAt = &holdExpiry
+		}
+		rescheduled, err = tx.MakeBooking(&newBooking)
+		if err != nil {
+			log.Println("Unable to make the booking, in reschedule file")
+			return err
+		}
+		issued, err = moveRescheduledFare(tx, booking, rescheduled, credited, request.RefundTo)
+		if err != nil {
+			return err
+		}
+		for _, item := range draft.items {
+			item.BookingID = rescheduled.BookingID
+		}
+		if err := tx.AddBookingItems(draft.items); err != nil {
+			log.Println("Unable to store the booking items, in reschedule file")
+			return err
+		}
+		now := time.Now()
+		for _, item := range items {
+			if item.Status != ItemBooked {
+				continue
+			}
+			item.Status = ItemRescheduled
+			item.CancelledAt = &now
+			if err := tx.UpdateBookingItem(item); err != nil {
+				log.Println("Could not move the booking item, in reschedule file")
+				return err
+			}
+		}
+		booking.Status = "Rescheduled"
+		booking.HoldExpiresAt = nil
+		booking.RescheduledTo = rescheduled.BookingID
+		if _, err := tx.UpdateBooking(booking); err != nil {
+			log.Println("Could not update the original booking, in reschedule file")
+			return err
+		}
+		// the chart is read again as the new seats may be on the same one
+		chart, err = tx.GetChartForUpdate(int(booking.BusID), originalDay)
+		if err != nil {
+			return err
+		}
+		promoted, err = promoteWaitlist(tx, chart, booking.BookingDate)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	usi.notifyPromoted(promoted)
+	if err := usi.hold.Release(original.BookingID); err != nil {
+		log.Println("Error releasing the seat hold, in reschedule file")
+	}
+	if rescheduled.Status == "Awaiting Payment" {
+		if err := usi.hold.Hold(rescheduled.BookingID, holdDuration); err != nil {
+			log.Println("Unable to place the seat hold, in reschedule file")
+		}
+	}
+	if rescheduled.Status == "Success" {
+		usi.mailTicket(rescheduled)
+	}
+	message := fmt.Sprintf("The booking %s has been moved to the seats %s of the bus %d for the day %s, PNR %s.", original.PNR, rescheduled.SeatReserved[:], rescheduled.BusID, rescheduled.BookingDate, rescheduled.PNR)
+	if issued != nil {
+		if sent, err := issueRefund(usi.repo.Refunds(), usi.gateway, issued.ID); err == nil {
+			issued = sent
+		}
+		message += fmt.Sprintf(" Rs %.2f of the fare is being refunded to the payment it was made with.", rupees(issued.Amount))
+	}
+	smsNotifier(message, user.PhoneNumber)
+	return rescheduled, nil
+}
+
+// settleReschedule function is used to decide how the new booking is paid, the fare paid on the original booking is moved over to it and the difference is refunded, taken from the wallet, or left for Razorpay. It returns the part of the new fare paid so far.
+func settleReschedule(tx repository.UserRepository, original *entities.Booking, draft *bookingDraft) (float64, error) {
+	paid := original.FarePostDiscount * paidShare(original)
+	fare := draft.booking.FarePostDiscount
+	balance, err := walletBalance(tx, original.UserID)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case fare <= paid:
+		draft.booking.Status = "Success"
+	case draft.request.PreferredPaymentType == "Wallet" && balance >= ledger.Paisa(fare)-ledger.Paisa(paid):
+		draft.booking.Status = "Success"
+	default:
+		draft.booking.RescheduleCredit = paid
+		draft.booking.Status = "Awaiting Payment"
+		return paid, nil
+	}
+	return fare, nil
+}
+
+// moveRescheduledFare function is used to move what was paid on the original booking over to the rescheduled one once it has its id. The credited part is paid to the new booking through the wallet and what the new fare does not take is refunded the way a cancellation is, to the wallet or the payment as asked. It returns the refund left to issue through the gateway, if any.
+func moveRescheduledFare(tx repository.UserRepository, original *entities.Booking, rescheduled *entities.Booking, credited float64, refundTo string) (*entities.Refund, error) {
+	paid := ledger.Paisa(original.FarePostDiscount * paidShare(original))
+	moved := ledger.Paisa(credited)
+	if moved > paid {
+		moved = paid
+	}
+	var issued *entities.Refund
+	if paid > moved {
+		var err error
+		_, issued, err = refundCancelled(tx, original, rupees(paid-moved), fmt.Sprintf("booking:%d:reschedule:refund", original.BookingID), refundTo)
+		if err != nil {
+			return nil, err
+		}
+	}
+	originalBus, err := tx.GetBusInfo(int(original.BusID))
+	if err != nil {
+		log.Println("Error fetching bus details, in reschedule file")
+		return nil, err
+	}
+	source, err := refundSource(tx.Ledger(), original, originalBus.ProviderID, moved)
+	if err != nil {
+		return nil, err
+	}
+	refund := ledger.Transfer(fmt.Sprintf("booking:%d:reschedule", original.BookingID), ledger.KindReschedule, original.BookingID, "Fare moved to the rescheduled booking", source, ledger.UserAccount(original.UserID), moved)
+	if err := postEntry(tx.Ledger(), refund); err != nil {
+		return nil, err
+	}
+	escrow, err := tripEscrow(rescheduled)
+	if err != nil {
+		return nil, err
+	}
+	payment := ledger.Transfer(fmt.Sprintf("booking:%d:credit", rescheduled.BookingID), ledger.KindReschedule, rescheduled.BookingID, "Fare moved from the original booking", ledger.UserAccount(original.UserID), escrow, ledger.Paisa(credited))
+	return issued, postEntry(tx.Ledger(), payment)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"errors"
+	"gobus/entities"
+	repository "gobus/repository/interfaces"
+	"gobus/seatmap"
+	"log"
+	"strings"
+	"time"
+)
+
+// seatInventory struct bundles the seat map of a bus, its chart for the day and the stops of the route it runs.
+type seatInventory struct {
+	seatMap *seatmap.SeatMap
+	chart   *seatmap.Chart
+	stops   []*entities.ScheduleStop
+}
+
+// loadInventory function is used to load everything needed to sell the seats of a bus on the given chart.
+func loadInventory(repo repository.UserRepository, bus *entities.Buses, schedule *entities.BusSchedule) (*seatInventory, error) {
+	stops, err := routeStops(repo, bus.ScheduleID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newInventory(repo, bus, schedule, stops)
+}
+
+// newInventory function is used to build the inventory when the stops of the route are already known.
+func newInventory(repo repository.UserRepository, bus *entities.Buses, schedule *entities.BusSchedule, stops []*entities.ScheduleStop) (*seatInventory, error) {
+	seatMap, chart, err := loadSeatChart(repo, bus, schedule)
+	if err != nil {
+		return nil, err
+	}
+	if err := chart.LoadSegments(schedule.SegmentOccupancy, len(stops)-1); err != nil {
+		log.Println("Error decoding the segment occupancy, in seatChart file")
+		return nil, err
+	}
+	return &seatInventory{seatMap: seatMap, chart: chart, stops: stops}, nil
+}
+
+// routeStops function returns the ordered stops of a schedule, a schedule without stops runs straight from its departure to its arrival station at the base fare.
+func routeStops(repo repository.UserRepository, scheduleID uint, schedule *entities.Schedule) ([]*entities.ScheduleStop, error) {
+	stops, err := repo.GetScheduleStops(scheduleID)
+	if err == nil && len(stops) >= 2 {
+		return stops, nil
+	}
+	if schedule == nil {
+		schedule, err = repo.GetSchedule(int(scheduleID))
+		if err != nil {
+			log.Println("Error fetching the schedule, in seatChart file")
+			return nil, err
+		}
+	}
+	baseFare, err := repo.GetBaseFare(int(scheduleID))
+	if err != nil {
+		log.Println("Error fetching base fare, in seatChart file")
+		return nil, err
+	}
+	origin := &entities.ScheduleStop{ScheduleID: scheduleID, Sequence: 0, StationName: schedule.DepartureStation, DepartureTime: schedule.DepartureTime}
+	destination := &entities.ScheduleStop{ScheduleID: scheduleID, Sequence: 1, StationName: schedule.ArrivalStation, ArrivalTime: schedule.ArrivalTime, SegmentFare: baseFare.BaseFare}
+	if clockMinutes(schedule.ArrivalTime) < clockMinutes(schedule.DepartureTime) {
+		destination.DayOffset = 1
+	}
+	return []*entities.ScheduleStop{origin, destination}, nil
+}
+
+// stopIndex function returns the position of the station on the route, an empty name stands for the given default position.
+func stopIndex(stops []*entities.ScheduleStop, station string, fallback int) (int, error) {
+	if station == "" {
+		return fallback, nil
+	}
+	for i, stop := range stops {
+		if strings.EqualFold(stop.StationName, station) {
+			return i, nil
+		}
+	}
+	return 0, errors.New("bus does not stop at " + station)
+}
+
+// segment function is used to find the part of the route between the two stations, empty names stand for the first and the last stop.
+func (inv *seatInventory) segment(from string, to string) (seatmap.Segment, error) {
+	full := inv.chart.FullRoute()
+	fromIndex, err := stopIndex(inv.stops, from, full.From)
+	if err != nil {
+		return full, err
+	}
+	toIndex, err := stopIndex(inv.stops, to, full.To)
+	if err != nil {
+		return full, err
+	}
+	segment := seatmap.Segment{From: fromIndex, To: toIndex}
+	return segment, inv.chart.CheckSegment(segment)
+}
+
+// segmentFare function returns the base fare of one seat for the segment, that is the sum of the fares of its hops.
+func (inv *seatInventory) segmentFare(segment seatmap.Segment) float64 {
+	fare := 0.0
+	for i := segment.From + 1; i <= segment.To && i < len(inv.stops); i++ {
+		fare += float64(inv.stops[i].SegmentFare)
+	}
+	return fare
+}
+
+// store function is used to write the chart and the segment occupancy back onto the schedule.
+func (inv *seatInventory) store(schedule *entities.BusSchedule) error {
+	if err := storeSeatChart(schedule, inv.chart); err != nil {
+		return err
+	}
+	segments, err := inv.chart.EncodeSegments()
+	if err != nil {
+		log.Println("Error encoding the segment occupancy, in seatChart file")
+		return err
+	}
+	schedule.SegmentOccupancy = segments
+	return nil
+}
+
+// loadSeatChart function is used to decode the chart of a schedule together with the seat map of the bus running it.
+func loadSeatChart(repo repository.UserRepository, bus *entities.Buses, schedule *entities.BusSchedule) (*seatmap.SeatMap, *seatmap.Chart, error) {
+	chart, err := seatmap.LoadChart(schedule.DeckOneSeatLayout, schedule.DeckTwoSeatLayout)
+	if err != nil {
+		log.Println("Error decoding the chart, in seatChart file")
+		return nil, nil, err
+	}
+	busType, err := repo.GetBusTypeForProvider(bus.BusTypeCode, bus.ProviderID)
+	if err == nil && busType.SeatLayoutID != 0 {
+		layout, err := repo.GetSeatLayout(int(busType.SeatLayoutID))
+		if err == nil {
+			seatMap, err := seatmap.FromLayout(layout, bus.BusTypeCode)
+			if err != nil {
+				log.Println("Error decoding the seat map, in seatChart file")
+				return nil, nil, err
+			}
+			return seatMap, chart, nil
+		}
+	}
+	seatMap, err := seatmap.FromChart(chart, bus.BusTypeCode)
+	if err != nil {
+		log.Println("Error building the seat map from the chart, in seatChart file")
+		return nil, nil, err
+	}
+	return seatMap, chart, nil
+}
+
+// storeSeatChart function is used to write the chart back onto the schedule.
+func storeSeatChart(schedule *entities.BusSchedule, chart *seatmap.Chart) error {
+	deckOne, deckTwo, err := chart.Encode()
+	if err != nil {
+		log.Println("Error encoding the chart, in seatChart file")
+		return err
+	}
+	schedule.DeckOneSeatLayout = deckOne
+	schedule.DeckTwoSeatLayout = deckTwo
+	return nil
+}
+
+// releaseSeats function is used to mark the given seats of the booking as free again on its part of the route.
+func releaseSeats(repo repository.UserRepository, schedule *entities.BusSchedule, booking *entities.Booking, seats []string) error {
+	bus, err := repo.GetBusInfo(int(schedule.BusID))
+	if err != nil {
+		log.Println("Error fetching bus details, in seatChart file")
+		return err
+	}
+	inventory, err := loadInventory(repo, bus, schedule)
+	if err != nil {
+		return err
+	}
+	segment, err := inventory.segment(booking.FromStation, booking.ToStation)
+	if err != nil {
+		log.Println("Booking stops are no longer on the route, releasing the whole route, in seatChart file")
+		segment = inventory.chart.FullRoute()
+	}
+	inventory.chart.ReleaseSegment(inventory.seatMap, seats, segment)
+	return inventory.store(schedule)
+}
+
+// seatFare function is used to get the fare of one seat of the given class.
+func seatFare(baseFare float64, busTypeCode string, class string) float64 {
+	fare := baseFare
+	if strings.HasPrefix(busTypeCode, "AC") {
+		fare = fare * 1.3
+	}
+	if class == seatmap.Sleeper {
+		fare = fare * 1.2
+	}
+	return fare
+}
+
+// clockMinutes function returns the minutes since midnight of a "15:04:05" time.
+func clockMinutes(clock string) int {
+	parsed, err := time.Parse("15:04:05", clock)
+	if err != nil {
+		return 0
+	}
+	return parsed.Hour()*60 + parsed.Minute()
+}
+
+// stopMinutes function returns the minutes since midnight of the day the bus leaves its origin, used to time a stop reached on a later day.
+func stopMinutes(clock string, dayOffset int) int {
+	return dayOffset*24*60 + clockMinutes(clock)
+}
@@ -0,0 +1,462 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ledger"
+	repository "gobus/repository/interfaces"
+	"gobus/ticket"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Statuses of a payout.
+const (
+	PayoutPending  = "Pending"
+	PayoutApproved = "Approved"
+	PayoutRejected = "Rejected"
+)
+
+// defaultCommission function returns the commission in percent kept from a provider without one of its own, set through PLATFORM_COMMISSION_PERCENT and ten percent otherwise.
+func defaultCommission() float64 {
+	percent, err := strconv.ParseFloat(os.Getenv("PLATFORM_COMMISSION_PERCENT"), 64)
+	if err != nil || percent < 0 || percent > 100 {
+		return 10
+	}
+	return percent
+}
+
+// commissionOf function returns the commission in percent kept from the fares of the provider.
+func commissionOf(tx repository.SettlementRepository, providerID uint) (float64, error) {
+	commission, err := tx.FindCommission(providerID)
+	if err != nil {
+		log.Println("Error fetching the commission, in settlement file")
+		return 0, err
+	}
+	if commission == nil {
+		return defaultCommission(), nil
+	}
+	return commission.Percent, nil
+}
+
+// SetCommission implements interfaces.AdminService.
+func (as *AdminServiceImpl) SetCommission(providerID int, request *dto.CommissionRequest) (*entities.ProviderCommission, error) {
+	if _, err := as.repo.FindProviderByID(providerID); err != nil {
+		log.Println("Provider not found, in settlement file")
+		return nil, errors.New("no provider found with this id")
+	}
+	commission := &entities.ProviderCommission{ProviderID: uint(providerID), Percent: *request.Percent}
+	if err := as.repo.Settlements().SaveCommission(commission); err != nil {
+		return nil, err
+	}
+	return commission, nil
+}
+
+// tripArrival function returns when the bus reaches the last stop of its route on the day, a schedule without stops arrives on its own arrival time, the next day if that is before it left.
+func (as *AdminServiceImpl) tripArrival(bus *entities.Buses, day time.Time) (time.Time, error) {
+	stops, err := as.repo.FindScheduleStops(bus.ScheduleID)
+	if err == nil && len(stops) > 0 {
+		last := stops[len(stops)-1]
+		clock := last.ArrivalTime
+		if clock == "" {
+			clock = last.DepartureTime
+		}
+		return day.Add(time.Duration(stopMinutes(clock, last.DayOffset)) * time.Minute), nil
+	}
+	schedule, err := as.repo.GetRouteByBus(int(bus.ScheduleID))
+	if err != nil {
+		log.Println("Error fetching the schedule, in settlement file")
+		return time.Time{}, err
+	}
+	minutes := clockMinutes(schedule.ArrivalTime)
+	if minutes < clockMinutes(schedule.DepartureTime) {
+		minutes += 24 * 60
+	}
+	return day.Add(time.Duration(minutes) * time.Minute), nil
+}
+
+// SettleTrips implements interfaces.AdminService, the fares held for every trip that has arrived are split between the commission and the wallet of the provider, booking by booking. It returns the number of bookings settled.
+func (as *AdminServiceImpl) SettleTrips() (int, error) {
+	accounts, err := as.repo.Ledger().FindOpenAccounts(ledger.OwnerEscrow)
+	if err != nil {
+		log.Println("Error fetching the escrow accounts, in settlement file")
+		return 0, err
+	}
+	settled := 0
+	now := time.Now()
+	for _, account := range accounts {
+		busID, day, err := ledger.ParseEscrow(account.Code)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		bus, err := as.repo.GetBusInfo(int(busID))
+		if err != nil {
+			log.Println("Error fetching bus details, in settlement file")
+			continue
+		}
+		arrival, err := as.tripArrival(bus, day)
+		if err != nil || now.Before(arrival) {
+			continue
+		}
+		count, err := settleTrip(as.repo.Settlements(), account, bus, day)
+		if err != nil {
+			log.Println("Unable to settle the trip", account.Code, "in settlement file")
+			continue
+		}
+		settled += count
+	}
+	return settled, nil
+}
+
+// settleTrip function is used to release the fares held in the escrow of the trip, a booking settled before holds nothing and is left out. The settlement is keyed by the escrow so a booking moved to another trip is settled again there.
+func settleTrip(repo repository.SettlementRepository, account *entities.LedgerAccount, bus *entities.Buses, day time.Time) (int, error) {
+	settled := 0
+	err := repo.WithTx(func(tx repository.SettlementRepository) error {
+		settled = 0
+		totals, err := tx.Ledger().BookingTotals(account.ID)
+		if err != nil {
+			log.Println("Error fetching the fares held for the trip, in settlement file")
+			return err
+		}
+		ids := make([]uint, 0, len(totals))
+		for id, held := range totals {
+			if id != 0 && held > 0 {
+				ids = append(ids, id)
+			}
+		}
+		bookings, err := tx.FindBookings(ids)
+		if err != nil {
+			return err
+		}
+		percent, err := commissionOf(tx, bus.ProviderID)
+		if err != nil {
+			return err
+		}
+		for _, booking := range bookings {
+			gross := totals[booking.BookingID]
+			commission := int64(math.Round(float64(gross) * percent / 100))
+			entry := &ledger.Entry{
+				Key:       fmt.Sprintf("booking:%d:settlement:%s", booking.BookingID, account.Code),
+				Kind:      ledger.KindSettlement,
+				BookingID: booking.BookingID,
+				Memo:      fmt.Sprintf("Fare of %s settled after the trip", booking.PNR),
+				Lines: []ledger.Line{
+					{Account: account.Code, Amount: -gross},
+					{Account: ledger.Commission, Amount: commission},
+					{Account: ledger.ProviderAccount(bus.ProviderID), Amount: gross - commission},
+				},
+			}
+			posted, err := ledger.Post(tx.Ledger(), entry)
+			if err != nil {
+				log.Println("Unable to post", entry.Key, "to the ledger, in settlement file")
+				return err
+			}
+			if !posted {
+				continue
+			}
+			err = tx.AddSettlement(&entities.Settlement{
+				ProviderID: bus.ProviderID,
+				BookingID:  booking.BookingID,
+				PNR:        booking.PNR,
+				BusID:      bus.BusID,
+				TripDate:   day.Format("02 01 2006"),
+				Gross:      gross,
+				Percent:    percent,
+				Commission: commission,
+				Net:        gross - commission,
+				SettledAt:  time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+			settled++
+		}
+		return nil
+	})
+	return settled, err
+}
+
+// batchPayout function is used to gather the settlements of the provider not paid out yet into a pending payout, a provider with none gives a nil payout.
+func batchPayout(tx repository.SettlementRepository, providerID uint, requested bool) (*entities.Payout, error) {
+	settlements, err := tx.FindUnbatched(providerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(settlements) == 0 {
+		return nil, nil
+	}
+	payout := &entities.Payout{ProviderID: providerID, Settlements: len(settlements), Status: PayoutPending, Requested: requested}
+	ids := make([]uint, 0, len(settlements))
+	for _, settlement := range settlements {
+		payout.Amount += settlement.Net
+		ids = append(ids, settlement.ID)
+	}
+	if err := tx.AddPayout(payout); err != nil {
+		return nil, err
+	}
+	if err := tx.AssignPayout(ids, payout.ID); err != nil {
+		return nil, err
+	}
+	return payout, nil
+}
+
+// BatchPayouts implements interfaces.AdminService, it returns the number of payouts raised.
+func (as *AdminServiceImpl) BatchPayouts() (int, error) {
+	providers, err := as.repo.Settlements().UnbatchedProviders()
+	if err != nil {
+		return 0, err
+	}
+	batched := 0
+	for _, providerID := range providers {
+		err := as.repo.Settlements().WithTx(func(tx repository.SettlementRepository) error {
+			payout, err := batchPayout(tx, providerID, false)
+			if payout != nil {
+				batched++
+			}
+			return err
+		})
+		if err != nil {
+			log.Println("Unable to batch the payout of the provider", providerID, "in settlement file")
+		}
+	}
+	return batched, nil
+}
+
+// ViewPayouts implements interfaces.AdminService.
+func (as *AdminServiceImpl) ViewPayouts(status string) ([]*dto.Payout, error) {
+	payouts, err := as.repo.Settlements().FindPayouts(0, status)
+	if err != nil {
+		return nil, err
+	}
+	return payoutsOf(payouts), nil
+}
+
+// ApprovePayout implements interfaces.AdminService, the amount leaves the wallet of the provider once the payout is approved.
+func (as *AdminServiceImpl) ApprovePayout(id int, decision *dto.PayoutDecision) (*dto.Payout, error) {
+	var payout *entities.Payout
+	err := as.repo.Settlements().WithTx(func(tx repository.SettlementRepository) error {
+		var err error
+		payout, err = tx.FindPayoutForUpdate(uint(id))
+		if err != nil {
+			return errors.New("no payout found with this id")
+		}
+		if payout.Status != PayoutPending {
+			return errors.New("payout is already " + strings.ToLower(payout.Status))
+		}
+		balance, err := ledger.Balance(tx.Ledger(), ledger.ProviderAccount(payout.ProviderID))
+		if err != nil {
+			return err
+		}
+		if balance < payout.Amount {
+			return errors.New("provider wallet does not hold the payout")
+		}
+		entry := ledger.Transfer(fmt.Sprintf("payout:%d", payout.ID), ledger.KindPayout, 0, fmt.Sprintf("Payout %d", payout.ID), ledger.ProviderAccount(payout.ProviderID), ledger.Payouts, payout.Amount)
+		if err := postEntry(tx.Ledger(), entry); err != nil {
+			return err
+		}
+		now := time.Now()
+		payout.Status = PayoutApproved
+		payout.Reference = decision.Reference
+		payout.Remarks = decision.Remarks
+		payout.DecidedAt = &now
+		return tx.UpdatePayout(payout)
+	})
+	if err != nil {
+		log.Println("Unable to approve the payout, in settlement file")
+		return nil, err
+	}
+	return payoutOf(payout), nil
+}
+
+// RejectPayout implements interfaces.AdminService, the settlements of a rejected payout go into the next batch.
+func (as *AdminServiceImpl) RejectPayout(id int, decision *dto.PayoutDecision) (*dto.Payout, error) {
+	var payout *entities.Payout
+	err := as.repo.Settlements().WithTx(func(tx repository.SettlementRepository) error {
+		var err error
+		payout, err = tx.FindPayoutForUpdate(uint(id))
+		if err != nil {
+			return errors.New("no payout found with this id")
+		}
+		if payout.Status != PayoutPending {
+			return errors.New("payout is already " + strings.ToLower(payout.Status))
+		}
+		if err := tx.ReleaseSettlements(payout.ID); err != nil {
+			return err
+		}
+		now := time.Now()
+		payout.Status = PayoutRejected
+		payout.Reference = decision.Reference
+		payout.Remarks = decision.Remarks
+		payout.DecidedAt = &now
+		return tx.UpdatePayout(payout)
+	})
+	if err != nil {
+		log.Println("Unable to reject the payout, in settlement file")
+		return nil, err
+	}
+	return payoutOf(payout), nil
+}
+
+// ViewPayouts implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) ViewPayouts(email string) ([]*dto.Payout, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in settlement file")
+		return nil, err
+	}
+	payouts, err := ps.repo.Settlements().FindPayouts(provider.ProviderID, "")
+	if err != nil {
+		return nil, err
+	}
+	return payoutsOf(payouts), nil
+}
+
+// RequestPayout implements interfaces.ProviderService, the settled fares not paid out yet are batched without waiting for the cron job.
+func (ps *ProviderServiceImpl) RequestPayout(email string) (*dto.Payout, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in settlement file")
+		return nil, err
+	}
+	var payout *entities.Payout
+	err = ps.repo.Settlements().WithTx(func(tx repository.SettlementRepository) error {
+		payout, err = batchPayout(tx, provider.ProviderID, true)
+		return err
+	})
+	if err != nil {
+		log.Println("Unable to request the payout, in settlement file")
+		return nil, err
+	}
+	if payout == nil {
+		return nil, errors.New("no settled fares left to pay out")
+	}
+	return payoutOf(payout), nil
+}
+
+// SettlementStatement implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) SettlementStatement(payoutID int, email string) (*dto.SettlementStatement, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in settlement file")
+		return nil, err
+	}
+	payout, err := ps.repo.Settlements().FindPayout(uint(payoutID))
+	if err != nil || payout.ProviderID != provider.ProviderID {
+		return nil, errors.New("no payout found with this id")
+	}
+	settlements, err := ps.repo.Settlements().FindPayoutSettlements(payout.ID)
+	if err != nil {
+		return nil, err
+	}
+	statement := &dto.SettlementStatement{Payout: payoutOf(payout), Provider: provider.CompanyName, Bookings: []*dto.SettlementLine{}}
+	var gross, commission, net int64
+	for _, settlement := range settlements {
+		gross += settlement.Gross
+		commission += settlement.Commission
+		net += settlement.Net
+		statement.Bookings = append(statement.Bookings, &dto.SettlementLine{
+			BookingID:  settlement.BookingID,
+			PNR:        settlement.PNR,
+			BusID:      settlement.BusID,
+			TripDate:   settlement.TripDate,
+			Gross:      rupees(settlement.Gross),
+			Percent:    settlement.Percent,
+			Commission: rupees(settlement.Commission),
+			Net:        rupees(settlement.Net),
+			SettledAt:  settlement.SettledAt,
+		})
+	}
+	statement.Gross, statement.Commission, statement.Net = rupees(gross), rupees(commission), rupees(net)
+	return statement, nil
+}
+
+// ExportSettlement implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) ExportSettlement(payoutID int, format string, email string) ([]byte, error) {
+	format = strings.ToLower(format)
+	if format != "csv" && format != "pdf" {
+		return nil, errors.New("settlement statement can be exported as csv or pdf")
+	}
+	statement, err := ps.SettlementStatement(payoutID, email)
+	if err != nil {
+		return nil, err
+	}
+	if format == "pdf" {
+		return settlementPDF(statement)
+	}
+	return settlementCSV(statement)
+}
+
+// settlementCSV function is used to write the settlement statement as CSV, one row for every booking.
+func settlementCSV(statement *dto.SettlementStatement) ([]byte, error) {
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	records := [][]string{{"booking_id", "pnr", "bus_id", "trip_date", "fare", "commission_percent", "commission", "net"}}
+	for _, line := range statement.Bookings {
+		records = append(records, []string{fmt.Sprint(line.BookingID), line.PNR, fmt.Sprint(line.BusID), line.TripDate, fmt.Sprintf("%.2f", line.Gross), fmt.Sprintf("%.2f", line.Percent), fmt.Sprintf("%.2f", line.Commission), fmt.Sprintf("%.2f", line.Net)})
+	}
+	if err := w.WriteAll(records); err != nil {
+		log.Println("Error writing the settlement statement, in settlement file")
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// settlementPDF function is used to print the settlement statement for the provider.
+func settlementPDF(statement *dto.SettlementStatement) ([]byte, error) {
+	printed := &ticket.Settlement{
+		PayoutID:   statement.Payout.ID,
+		Provider:   statement.Provider,
+		Status:     statement.Payout.Status,
+		Created:    statement.Payout.CreatedAt.Format("02 01 2006"),
+		Gross:      statement.Gross,
+		Commission: statement.Commission,
+		Net:        statement.Net,
+	}
+	for _, line := range statement.Bookings {
+		printed.Rows = append(printed.Rows, ticket.SettlementRow{
+			BookingID:  line.BookingID,
+			PNR:        line.PNR,
+			BusID:      line.BusID,
+			TripDate:   line.TripDate,
+			Gross:      line.Gross,
+			Percent:    line.Percent,
+			Commission: line.Commission,
+			Net:        line.Net,
+		})
+	}
+	return ticket.RenderSettlement(printed)
+}
+
+// payoutOf function converts the payout to rupees.
+func payoutOf(payout *entities.Payout) *dto.Payout {
+	return &dto.Payout{
+		ID:          payout.ID,
+		ProviderID:  payout.ProviderID,
+		Amount:      rupees(payout.Amount),
+		Settlements: payout.Settlements,
+		Status:      payout.Status,
+		Requested:   payout.Requested,
+		Reference:   payout.Reference,
+		Remarks:     payout.Remarks,
+		CreatedAt:   payout.CreatedAt,
+		DecidedAt:   payout.DecidedAt,
+	}
+}
+
+// payoutsOf function converts the payouts to rupees.
+func payoutsOf(payouts []*entities.Payout) []*dto.Payout {
+	result := make([]*dto.Payout, 0, len(payouts))
+	for _, payout := range payouts {
+		result = append(result, payoutOf(payout))
+	}
+	return result
+}
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ledger"
+	"gobus/repository"
+	"gobus/repository/interfaces"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// settlementRepo keeps the settlements and payouts in memory, its ledger is the one of a lockingUserRepo.
+type settlementRepo struct {
+	interfaces.SettlementRepository
+	books       *lockingUserRepo
+	percent     *float64
+	settlements []*entities.Settlement
+	payouts     []*entities.Payout
+}
+
+func (s *settlementRepo) WithTx(fn func(tx interfaces.SettlementRepository) error) error {
+	return fn(s)
+}
+
+func (s *settlementRepo) Ledger() interfaces.LedgerRepository {
+	return s.books.Ledger()
+}
+
+func (s *settlementRepo) FindCommission(providerID uint) (*entities.ProviderCommission, error) {
+	if s.percent == nil {
+		return nil, nil
+	}
+	return &entities.ProviderCommission{ProviderID: providerID, Percent: *s.percent}, nil
+}
+
+func (s *settlementRepo) SaveCommission(commission *entities.ProviderCommission) error {
+	s.percent = &commission.Percent
+	return nil
+}
+
+func (s *settlementRepo) FindBookings(ids []uint) ([]*entities.Booking, error) {
+	var bookings []*entities.Booking
+	for _, booking := range s.books.bookings {
+		for _, id := range ids {
+			if booking.BookingID == id {
+				bookings = append(bookings, booking)
+			}
+		}
+	}
+	return bookings, nil
+}
+
+func (s *settlementRepo) AddSettlement(settlement *entities.Settlement) error {
+	settlement.ID = uint(len(s.settlements) + 1)
+	s.settlements = append(s.settlements, settlement)
+	return nil
+}
+
+func (s *settlementRepo) FindUnbatched(providerID uint) ([]*entities.Settlement, error) {
+	var settlements []*entities.Settlement
+	for _, settlement := range s.settlements {
+		if settlement.ProviderID == providerID && settlement.PayoutID == nil {
+			settlements = append(settlements, settlement)
+		}
+	}
+	return settlements, nil
+}
+
+func (s *settlementRepo) UnbatchedProviders() ([]uint, error) {
+	var ids []uint
+	for _, settlement := range s.settlements {
+		if settlement.PayoutID == nil && (len(ids) == 0 || ids[len(ids)-1] != settlement.ProviderID) {
+			ids = append(ids, settlement.ProviderID)
+		}
+	}
+	return ids, nil
+}
+
+func (s *settlementRepo) AddPayout(payout *entities.Payout) error {
+	payout.ID = uint(len(s.payouts) + 1)
+	payout.CreatedAt = time.Now()
+	copied := *payout
+	s.payouts = append(s.payouts, &copied)
+	return nil
+}
+
+func (s *settlementRepo) AssignPayout(settlementIDs []uint, payoutID uint) error {
+	for _, id := range settlementIDs {
+		assigned := payoutID
+		s.settlements[id-1].PayoutID = &assigned
+	}
+	return nil
+}
+
+func (s *settlementRepo) ReleaseSettlements(payoutID uint) error {
+	for _, settlement := range s.settlements {
+		if settlement.PayoutID != nil && *settlement.PayoutID == payoutID {
+			settlement.PayoutID = nil
+		}
+	}
+	return nil
+}
+
+func (s *settlementRepo) FindPayout(id uint) (*entities.Payout, error) {
+	if id == 0 || int(id) > len(s.payouts) {
+		return nil, errors.New("record not found")
+	}
+	copied := *s.payouts[id-1]
+	return &copied, nil
+}
+
+func (s *settlementRepo) FindPayoutForUpdate(id uint) (*entities.Payout, error) {
+	return s.FindPayout(id)
+}
+
+func (s *settlementRepo) UpdatePayout(payout *entities.Payout) error {
+	copied := *payout
+	s.payouts[payout.ID-1] = &copied
+	return nil
+}
+
+func (s *settlementRepo) FindPayoutSettlements(payoutID uint) ([]*entities.Settlement, error) {
+	var settlements []*entities.Settlement
+	for _, settlement := range s.settlements {
+		if settlement.PayoutID != nil && *settlement.PayoutID == payoutID {
+			settlements = append(settlements, settlement)
+		}
+	}
+	return settlements, nil
+}
+
+type settlementAdminRepo struct {
+	interfaces.AdminRepository
+	books *lockingUserRepo
+	s     *settlementRepo
+	bus   *entities.Buses
+}
+
+func (a *settlementAdminRepo) Ledger() interfaces.LedgerRepository { return a.books.Ledger() }
+
+func (a *settlementAdminRepo) Settlements() interfaces.SettlementRepository { return a.s }
+
+func (a *settlementAdminRepo) FindProviderByID(id int) (*entities.ServiceProvider, error) {
+	if uint(id) != a.books.provider.ProviderID {
+		return nil, errors.New("record not found")
+	}
+	return a.books.provider, nil
+}
+
+func (a *settlementAdminRepo) GetBusInfo(id int) (*entities.Buses, error) { return a.bus, nil }
+
+func (a *settlementAdminRepo) FindScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error) {
+	return a.books.stops, nil
+}
+
+type settlementProviderRepo struct {
+	interfaces.ProviderRepository
+	books *lockingUserRepo
+	s     *settlementRepo
+}
+
+func (p *settlementProviderRepo) FindProviderByEmail(email string) (*entities.ServiceProvider, error) {
+	return p.books.provider, nil
+}
+
+func (p *settlementProviderRepo) Settlements() interfaces.SettlementRepository { return p.s }
+
+func Test_SettleTripsAndPayouts(t *testing.T) {
+	today := time.Now()
+	done := time.Date(today.Year(), today.Month(), today.Day()-2, 0, 0, 0, 0, time.Local)
+	coming := done.AddDate(0, 0, 4)
+	books := &lockingUserRepo{
+		chart:    &entities.BusSchedule{},
+		user:     &entities.User{ID: 1, UserWallet: 1000},
+		provider: &entities.ServiceProvider{ProviderID: 3, CompanyName: "Kallada"},
+		bookings: []*entities.Booking{
+			{BookingID: 1, PNR: "GB1", BusID: 7, BookingDate: done.Format("02 01 2006")},
+			{BookingID: 2, PNR: "GB2", BusID: 7, BookingDate: done.Format("02 01 2006")},
+			{BookingID: 3, PNR: "GB3", BusID: 7, BookingDate: coming.Format("02 01 2006")},
+		},
+		stops: []*entities.ScheduleStop{
+			{StationName: "Kochi", DepartureTime: "21:00:00"},
+			{StationName: "Bangalore", ArrivalTime: "06:00:00", DayOffset: 1},
+		},
+	}
+	for _, booking := range books.bookings {
+		escrow, _ := tripEscrow(booking)
+		entry := ledger.Transfer(paymentKey(booking.BookingID), ledger.KindPayment, booking.BookingID, "", ledger.UserAccount(1), escrow, int64(booking.BookingID)*10000)
+		if _, err := ledger.Post(books.Ledger(), entry); err != nil {
+			t.Fatalf("ledger.Post() error = %v", err)
+		}
+	}
+	s := &settlementRepo{books: books}
+	a := &AdminServiceImpl{repo: &settlementAdminRepo{books: books, s: s, bus: &entities.Buses{BusID: 7, ProviderID: 3}}}
+	p := &ProviderServiceImpl{repo: &settlementProviderRepo{books: books, s: s}}
+	percent := 12.5
+	if _, err := a.SetCommission(9, &dto.CommissionRequest{Percent: &percent}); err == nil {
+		t.Errorf("services.SetCommission() set the commission of a provider it could not find")
+	}
+	if _, err := a.SetCommission(3, &dto.CommissionRequest{Percent: &percent}); err != nil || *s.percent != 12.5 {
+		t.Fatalf("services.SetCommission() error = %v", err)
+	}
+
+	// the trip of the day before yesterday has arrived, the one coming is still held
+	for i, want := range []int{2, 0} {
+		settled, err := a.SettleTrips()
+		if err != nil || settled != want {
+			t.Fatalf("services.SettleTrips() run %d = %d, %v, want %d", i, settled, err, want)
+		}
+	}
+	if wallet, _ := ledger.Balance(books.Ledger(), ledger.ProviderAccount(3)); wallet != 26250 || escrowed(books) != 30000 {
+		t.Errorf("services.SettleTrips() provider wallet = %d, escrowed = %d", wallet, escrowed(books))
+	}
+	if commission, _ := ledger.Balance(books.Ledger(), ledger.Commission); commission != 3750 {
+		t.Errorf("services.SettleTrips() commission = %d, want 3750", commission)
+	}
+
+	payout, err := p.RequestPayout("provider@gmail.com")
+	if err != nil || payout.Amount != 262.5 || payout.Settlements != 2 || !payout.Requested {
+		t.Fatalf("services.RequestPayout() = %+v, %v", payout, err)
+	}
+	if _, err := p.RequestPayout("provider@gmail.com"); err == nil {
+		t.Errorf("services.RequestPayout() paid out the same fares twice")
+	}
+	statement, err := p.SettlementStatement(1, "provider@gmail.com")
+	if err != nil || len(statement.Bookings) != 2 || statement.Gross != 300 || statement.Commission != 37.5 || statement.Net != 262.5 {
+		t.Fatalf("services.SettlementStatement() = %+v, %v", statement, err)
+	}
+	file, err := p.ExportSettlement(1, "csv", "provider@gmail.com")
+	if err != nil || !strings.Contains(string(file), "GB2,7,"+done.Format("02 01 2006")+",200.00,12.50,25.00,175.00") {
+		t.Errorf("services.ExportSettlement() csv = %q, %v", file, err)
+	}
+	if file, err := p.ExportSettlement(1, "pdf", "provider@gmail.com"); err != nil || !bytes.HasPrefix(file, []byte("%PDF")) {
+		t.Errorf("services.ExportSettlement() pdf error = %v", err)
+	}
+
+	// a rejected payout goes back into the next batch
+	if _, err := a.RejectPayout(1, &dto.PayoutDecision{Remarks: "bank details missing"}); err != nil {
+		t.Fatalf("services.RejectPayout() error = %v", err)
+	}
+	if batched, err := a.BatchPayouts(); err != nil || batched != 1 {
+		t.Fatalf("services.BatchPayouts() = %d, %v", batched, err)
+	}
+	approved, err := a.ApprovePayout(2, &dto.PayoutDecision{Reference: "UTR123"})
+	if err != nil || approved.Status != PayoutApproved || approved.Reference != "UTR123" {
+		t.Fatalf("services.ApprovePayout() = %+v, %v", approved, err)
+	}
+	if _, err := a.ApprovePayout(2, &dto.PayoutDecision{}); err == nil {
+		t.Errorf("services.ApprovePayout() paid out the payout twice")
+	}
+	if _, err := a.ApprovePayout(1, &dto.PayoutDecision{}); err == nil {
+		t.Errorf("services.ApprovePayout() approved a rejected payout")
+	}
+	if balance, _ := ledger.Balance(books.Ledger(), ledger.ProviderAccount(3)); balance != 0 {
+		t.Errorf("services.ApprovePayout() left %d in the provider wallet", balance)
+	}
+	balancedBooks(t, books)
+}
+
+func Test_settleTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	bus := &entities.Buses{BusID: 2, ProviderID: 1}
+	day := time.Date(2030, 1, 3, 0, 0, 0, 0, time.Local)
+	escrow := &entities.LedgerAccount{ID: 9, Code: ledger.EscrowAccount(2, day)}
+	// held expects the escrow to hold the fare of booking 1 at the commission of provider 1
+	held := func(settlementRepo *repository.MockSettlementRepository, ledgerRepo *repository.MockLedgerRepository) {
+		ledgerRepo.EXPECT().BookingTotals(uint(9)).Return(map[uint]int64{1: 50000}, nil)
+		settlementRepo.EXPECT().FindBookings([]uint{1}).Return([]*entities.Booking{{BookingID: 1, PNR: "GB7K2M9Q"}}, nil)
+		settlementRepo.EXPECT().FindCommission(uint(1)).Return(&entities.ProviderCommission{ProviderID: 1, Percent: 10}, nil)
+	}
+	tests := []struct {
+		name       string
+		beforeTest func(settlementRepo *repository.MockSettlementRepository, ledgerRepo *repository.MockLedgerRepository)
+		want       int
+		wantErr    bool
+	}{
+		{
+			name: "success booking rescheduled onto the trip settled under its escrow",
+			beforeTest: func(settlementRepo *repository.MockSettlementRepository, ledgerRepo *repository.MockLedgerRepository) {
+				held(settlementRepo, ledgerRepo)
+				expectPost(ledgerRepo, "booking:1:settlement:escrow:2:20300103", 45000)
+				settlementRepo.EXPECT().AddSettlement(gomock.Any()).DoAndReturn(func(settlement *entities.Settlement) error {
+					if settlement.BookingID != 1 || settlement.BusID != 2 || settlement.TripDate != "03 01 2030" || settlement.Gross != 50000 || settlement.Commission != 5000 || settlement.Net != 45000 {
+						t.Errorf("services.settleTrip() added the settlement %+v", settlement)
+					}
+					return nil
+				})
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "success fare of the trip settled before",
+			beforeTest: func(settlementRepo *repository.MockSettlementRepository, ledgerRepo *repository.MockLedgerRepository) {
+				held(settlementRepo, ledgerRepo)
+				ledgerRepo.EXPECT().FindEntryByKey("booking:1:settlement:escrow:2:20300103").Return(&entities.LedgerEntry{ID: 3, Key: "booking:1:settlement:escrow:2:20300103"}, nil)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "commission not found",
+			beforeTest: func(settlementRepo *repository.MockSettlementRepository, ledgerRepo *repository.MockLedgerRepository) {
+				ledgerRepo.EXPECT().BookingTotals(uint(9)).Return(map[uint]int64{1: 50000}, nil)
+				settlementRepo.EXPECT().FindBookings([]uint{1}).Return([]*entities.Booking{{BookingID: 1, PNR: "GB7K2M9Q"}}, nil)
+				settlementRepo.EXPECT().FindCommission(uint(1)).Return(nil, errors.New("Oops"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockSettlementRepository(ctrl)
+			mockLedger := repository.NewMockLedgerRepository(ctrl)
+			mockRepo.EXPECT().WithTx(gomock.Any()).DoAndReturn(func(fn func(tx interfaces.SettlementRepository) error) error {
+				return fn(mockRepo)
+			}).AnyTimes()
+			mockRepo.EXPECT().Ledger().Return(mockLedger).AnyTimes()
+			expectLedger(mockLedger)
+			tt.beforeTest(mockRepo, mockLedger)
+			got, err := settleTrip(mockRepo, escrow, bus, day)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.settleTrip() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("services.settleTrip() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gobus/entities"
+	"gobus/ticket"
+	"io"
+	"log"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+// ticketMailer is the mailer used to send the e-tickets, it is swapped out in the tests.
+var ticketMailer = sendTicketEmail
+
+func sendTicketEmail(recipientEmail string, subject string, body string, filename string, pdf []byte) error {
+	m := gomail.NewMessage()
+	m.SetHeader("From", "gobusaswin@gmail.com")
+	m.SetHeader("To", recipientEmail)
+	m.SetHeader("Subject", subject)
+
+	m.SetBody("text/plain", body)
+	m.Attach(filename, gomail.SetCopyFunc(func(w io.Writer) error {
+		_, err := w.Write(pdf)
+		return err
+	}), gomail.SetHeader(map[string][]string{"Content-Type": {"application/pdf"}}))
+
+	d := gomail.NewDialer("smtp.gmail.com", 587, "gobusaswin@gmail.com", "zfej mjdj hhzq lxve")
+
+	if err := d.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BookingTicket implements interfaces.UserService.
+func (usi *UserServiceImpl) BookingTicket(bookID int, email string) (string, []byte, error) {
+	user, err := usi.repo.FindUserByEmail(email)
+	if err != nil {
+		log.Println("Error finding user, in ticket file")
+		return "", nil, err
+	}
+	booking, err := usi.repo.FindBookingByID(bookID)
+	if err != nil || booking.UserID != user.ID {
+		log.Println("Booking not found for the user, in ticket file")
+		return "", nil, errors.New("no booking found with this id")
+	}
+	if booking.Status != "Success" {
+		return "", nil, errors.New("a ticket is only issued for a confirmed booking")
+	}
+	pdf, err := usi.renderTicket(booking, user)
+	if err != nil {
+		return "", nil, err
+	}
+	return booking.PNR, pdf, nil
+}
+
+// mailTicket function is used to email the e-ticket of a confirmed booking, a failure is only logged as the booking itself is done.
+func (usi *UserServiceImpl) mailTicket(booking *entities.Booking) {
+	user, err := usi.repo.GetUserInfo(int(booking.UserID))
+	if err != nil {
+		log.Println("Error fetching the user info, in ticket file")
+		return
+	}
+	pdf, err := usi.renderTicket(booking, user)
+	if err != nil {
+		log.Println("Error rendering the ticket, in ticket file")
+		return
+	}
+	subject := "GoBus: Booking confirmed, PNR " + booking.PNR
+	body := fmt.Sprintf("Your booking for the day %s is confirmed. Your e-ticket is attached, PNR %s.", booking.BookingDate, booking.PNR)
+	go func() {
+		if err := ticketMailer(user.Email, subject, body, "ticket-"+booking.PNR+".pdf", pdf); err != nil {
+			log.Println("Error emailing the ticket, in ticket file")
+		}
+	}()
+}
+
+// renderTicket function is used to gather the bus, route, passengers and fare of the booking and render its e-ticket.
+func (usi *UserServiceImpl) renderTicket(booking *entities.Booking, user *entities.User) ([]byte, error) {
+	bus, err := usi.repo.GetBusInfo(int(booking.BusID))
+	if err != nil {
+		log.Println("Error fetching bus details, in ticket file")
+		return nil, err
+	}
+	schedule, err := usi.repo.GetSchedule(int(bus.ScheduleID))
+	if err != nil {
+		log.Println("Error fetching the schedule, in ticket file")
+		return nil, err
+	}
+	stops, err := routeStops(usi.repo, bus.ScheduleID, schedule)
+	if err != nil {
+		return nil, err
+	}
+	day, err := time.Parse("02 01 2006", booking.BookingDate)
+	if err != nil {
+		log.Println("Error parsing the date, in ticket file")
+		return nil, err
+	}
+	from, err := stopIndex(stops, booking.FromStation, 0)
+	if err != nil {
+		from = 0
+	}
+	to, err := stopIndex(stops, booking.ToStation, len(stops)-1)
+	if err != nil {
+		to = len(stops) - 1
+	}
+	t := &ticket.Ticket{
+		PNR:           booking.PNR,
+		Status:        booking.Status,
+		BusNumber:     bus.BusNumber,
+		BusType:       bus.BusTypeCode,
+		Route:         schedule.DepartureStation + " to " + schedule.ArrivalStation,
+		From:          stops[from].StationName,
+		To:            stops[to].StationName,
+		Date:          booking.BookingDate,
+		Departure:     stopClock(day, stops[from].DepartureTime, stops[from].ArrivalTime, stops[from].DayOffset),
+		Arrival:       stopClock(day, stops[to].ArrivalTime, stops[to].DepartureTime, stops[to].DayOffset),
+		BoardingPoint: booking.BoardingPoint,
+		BoardingTime:  booking.BoardingTime,
+		DroppingPoint: booking.DroppingPoint,
+		DroppingTime:  booking.DroppingTime,
+		Fare: ticket.Fare{
+			BaseFare:         booking.ActualFare,
+			Discount:         booking.ActualFare - booking.FarePostDiscount,
+			Payable:          booking.FarePostDiscount,
+			RescheduleCredit: booking.RescheduleCredit,
+			Refunded:         booking.RefundAmount,
+		},
+	}
+	passengers, _ := usi.repo.ViewAllPassengers(user.Email)
+	byID := map[uint]*entities.PassengerInfo{}
+	for _, passenger := range passengers {
+		byID[passenger.PassengerID] = passenger
+	}
+	// the items of a booking made before items were kept are stored here, the crew boards the ticket by them
+	items, err := bookingItems(usi.repo, booking)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status == "Success" {
+		if t.Pass, err = boardingPass(booking, items); err != nil {
+			log.Println("Error signing the boarding pass, the ticket is issued without it, in ticket file")
+		}
+	}
+	for _, item := range items {
+		line := ticket.Passenger{Seat: item.SeatID, Fare: item.FarePostDiscount, Status: item.Status}
+		if passenger, ok := byID[item.PassengerID]; ok {
+			line.Name, line.Age, line.Gender = passenger.Name, passenger.Age, passenger.Gender
+		}
+		t.Passengers = append(t.Passengers, line)
+	}
+	// cancelled seats are listed on the ticket but their fares are no longer part of the booking
+	for _, item := range items {
+		if item.Status != ItemBooked {
+			t.Fare.BaseFare += item.Fare
+			t.Fare.Discount += item.Fare - item.FarePostDiscount
+			t.Fare.Payable += item.FarePostDiscount
+		}
+	}
+	return ticket.Render(t)
+}
+
+// stopClock function returns the date and time the bus is at a stop, the other clock is used when the stop has no time of that kind.
+func stopClock(day time.Time, clock string, other string, dayOffset int) string {
+	if clock == "" {
+		clock = other
+	}
+	if clock == "" {
+		return ""
+	}
+	at := day.Add(time.Duration(stopMinutes(clock, dayOffset)) * time.Minute)
+	return at.Format("02 01 2006 15:04")
+}
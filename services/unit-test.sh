@@ -1 +1,2 @@
 mockgen -source=userServiceImpl.go -destination=mock_service.go -package=services
+mockgen -source=interfaces/adminService.go -destination=mock_admin_service.go -package=services
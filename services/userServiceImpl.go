@@ -1,58 +1,180 @@
 package services
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"gobus/dto"
 	"gobus/entities"
+	"gobus/ledger"
 	"gobus/middleware"
+	"gobus/payment"
 	repository "gobus/repository/interfaces"
+	"gobus/routeplanner"
+	"gobus/seathold"
+	"gobus/seatmap"
 	"gobus/utils"
 	"log"
-	"os"
-	"strconv"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/mohae/deepcopy"
-	"github.com/razorpay/razorpay-go"
-
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserService interface {
 	Login(login *dto.LoginRequest) (map[string]string, error)
 	RegisterUser(user *entities.User) (*entities.User, error)
-	FindBus(request *dto.BusRequest) ([]*entities.BusesResp, error)
+	FindBus(request *dto.BusRequest) ([]*dto.BusSearchResult, error)
 	AddPassenger(passenger *entities.PassengerInfo, email string) (*entities.PassengerInfo, error)
 	ViewAllPassengers(email string) ([]*entities.PassengerInfo, error)
 	BookSeat(bookreq *dto.BookingRequest, email string) (*entities.Booking, error)
+	BookItinerary(request *dto.ItineraryBookingRequest, email string) ([]*entities.Booking, error)
+	PlanRoutes(request *dto.RoutePlanRequest) ([]*dto.Itinerary, error)
 	FindCoupon() ([]*entities.Coupons, error)
 	ViewBookings(email string) ([]*entities.Booking, error)
-	CancelBooking(bookID int) (*entities.Booking, error)
+	CancelBooking(bookID int, refundTo string, email string) (*entities.Booking, error)
+	CancelSeats(bookID int, request *dto.SeatCancelRequest, email string) (*entities.Booking, error)
+	RefundQuote(bookID int, request *dto.SeatCancelRequest, email string) (*dto.RefundQuote, error)
+	RescheduleBooking(bookID int, request *dto.RescheduleRequest, email string) (*entities.Booking, error)
+	BookingTicket(bookID int, email string) (string, []byte, error)
 	SeatAvailabilityChecker(seatReq *dto.SeatAvailabilityRequest) (*dto.SeatAvailabilityResponse, error)
-	MakePayment(bookID int) (*dto.MakePaymentResp, error)
+	MakePayment(pnr string) (*dto.MakePaymentResp, error)
 	PaymentSuccess(razor *entities.RazorPay) error
+	WalletStatement(email string, page int, limit int) (*dto.WalletStatement, error)
+	TopupWallet(request *dto.TopupRequest, email string) (*dto.TopupResponse, error)
+	TopupSuccess(razor *entities.RazorPay) error
+	PaymentWebhook(eventID string, body []byte, signature string) error
+	SetRefundPreference(request *dto.RefundPreference, email string) (*entities.User, error)
+	ViewRefunds(email string) ([]*entities.Refund, error)
 	FindBookingByID(ID int) (*entities.Booking, error)
-	SubStationDetails(parent string) ([]string, error)
+	FindBookingByPNR(pnr string) (*entities.Booking, error)
+	LookupBooking(pnr string, contact string) (*entities.Booking, error)
+	AssignMissingPNRs() (int, error)
+	SubStationDetails(parent string, busID int) ([]*dto.BoardingPointResponse, error)
+	ReleaseExpiredHolds() (int, error)
 }
 
+// smsNotifier is the notifier used by the user service, it is swapped out in the tests.
+var smsNotifier = WhatsappNotifier
+
 // UserServiceImpl struct is used to Implement the UserService.
 type UserServiceImpl struct {
-	repo repository.UserRepository
-	jwt  *middleware.JwtUtil
+	repo    repository.UserRepository
+	jwt     *middleware.JwtUtil
+	hold    seathold.SeatHold
+	gateway payment.PaymentGateway
+}
+
+// ReleaseExpiredHolds implements interfaces.UserService. A booking that cannot be released is left for the next sweep, the errors of all of them are returned along with the count released.
+func (usi *UserServiceImpl) ReleaseExpiredHolds() (int, error) {
+	bookings, err := usi.repo.FindExpiredHolds(time.Now())
+	if err != nil {
+		log.Println("Error fetching the expired holds, in userServiceImpl file")
+		return 0, err
+	}
+	released := 0
+	var failed []error
+	for _, booking := range bookings {
+		if held, err := usi.hold.IsHeld(booking.BookingID); err == nil && held {
+			continue
+		}
+		parsedDate, err := time.Parse("02 01 2006", booking.BookingDate)
+		if err != nil {
+			log.Println("Error parsing the date of the booking", booking.BookingID, "in userServiceImpl file")
+			failed = append(failed, fmt.Errorf("booking %d: %w", booking.BookingID, err))
+			continue
+		}
+		bookingID := int(booking.BookingID)
+		var promoted []*entities.Booking
+		expired := false
+		err = usi.repo.WithTx(func(tx repository.UserRepository) error {
+			chart, err := tx.GetChartForUpdate(int(booking.BusID), parsedDate)
+			if err != nil {
+				log.Println("Error fetching bus schedule, in userServiceImpl file")
+				return err
+			}
+			booking, err := tx.FindBookingByID(bookingID)
+			if err != nil {
+				return err
+			}
+			if booking.Status != "Awaiting Payment" {
+				// the payment came in during the sweep
+				return nil
+			}
+			items, err := bookingItems(tx, booking)
+			if err != nil {
+				return err
+			}
+			if err := releaseSeats(tx, chart, booking, bookedSeats(items)); err != nil {
+				return err
+			}
+			if _, err := tx.UpdateChart(chart); err != nil {
+				log.Println("Could not update the chart, in userService file")
+				return err
+			}
+			if booking.RescheduleCredit > 0 {
+				if _, err := refundToWallet(tx, booking, booking.RescheduleCredit, fmt.Sprintf("booking:%d:expired", booking.BookingID)); err != nil {
+					return err
+				}
+			}
+			booking.Status = "Expired"
+			if _, err := tx.UpdateBooking(booking); err != nil {
+				log.Println("Could not expire the booking, in userServiceImpl file")
+				return err
+			}
+			expired = true
+			promoted, err = promoteWaitlist(tx, chart, booking.BookingDate)
+			return err
+		})
+		if err != nil {
+			log.Println("Unable to release the hold of the booking", booking.BookingID, "in userServiceImpl file:", err)
+			failed = append(failed, fmt.Errorf("booking %d: %w", booking.BookingID, err))
+			continue
+		}
+		if !expired {
+			continue
+		}
+		usi.notifyPromoted(promoted)
+		released++
+	}
+	return released, errors.Join(failed...)
 }
 
 // SubStationDetails implements interfaces.UserService.
-func (usi *UserServiceImpl) SubStationDetails(parent string) ([]string, error) {
-	stations, err := usi.repo.GetSubStationDetails(parent)
+func (usi *UserServiceImpl) SubStationDetails(parent string, busID int) ([]*dto.BoardingPointResponse, error) {
+	points, err := usi.repo.GetBoardingPointsAt(parent, uint(busID))
 	if err != nil {
 		log.Println("Error fetching the stations, in userServiceImpl file")
 		return nil, err
 	}
-	var substations []string
-	for _, student := range stations {
-		substations = append(substations, student.SubStation)
+	buses := map[uint]*entities.Buses{}
+	stopsByBus := map[uint][]*entities.ScheduleStop{}
+	substations := []*dto.BoardingPointResponse{}
+	for _, point := range points {
+		bus, ok := buses[point.BusID]
+		if !ok {
+			bus, err = usi.repo.GetBusInfo(int(point.BusID))
+			if err != nil {
+				continue
+			}
+			buses[point.BusID] = bus
+			stopsByBus[point.BusID], _ = routeStops(usi.repo, bus.ScheduleID, nil)
+		}
+		stops := stopsByBus[point.BusID]
+		stop := stopOfPoint(stops, point)
+		if stop < 0 {
+			continue
+		}
+		substations = append(substations, &dto.BoardingPointResponse{
+			ID:        point.ID,
+			BusID:     point.BusID,
+			BusNumber: bus.BusNumber,
+			PointType: point.PointType,
+			Name:      point.Name,
+			Address:   point.Address,
+			Stop:      stops[stop].StationName,
+			Time:      pointTime(stops[stop], point),
+		})
 	}
 	return substations, nil
 }
@@ -75,15 +197,56 @@ func (usi *UserServiceImpl) PaymentSuccess(razor *entities.RazorPay) error {
 		log.Println("Error fetching the booking, in userServiceImpl file")
 		return err
 	}
-	book.Status = "Success"
-	if _, err := usi.repo.UpdateBooking(book); err != nil {
-		log.Println("Error fetching the booking, in userServiceImpl file")
+	parsedDate, err := time.Parse("02 01 2006", book.BookingDate)
+	if err != nil {
+		log.Println("Error parsing the date, in userServiceImpl file")
+		return err
+	}
+	// a rescheduled booking only pays what its credit did not cover
+	due := ledger.Paisa(book.FarePostDiscount - book.RescheduleCredit)
+	if reason, err := usi.verifyPayment(razor, book.PNR, due); err != nil {
+		if reason != "" {
+			usi.flagPayment(razor, book.BookingID, 0, reason)
+		}
+		return err
+	}
+	razor.AmountPaid = rupees(due)
+	status := ""
+	err = usi.repo.WithTx(func(tx repository.UserRepository) error {
+		if _, err := tx.GetChartForUpdate(int(book.BusID), parsedDate); err != nil {
+			log.Println("Error fetching bus schedule, in userServiceImpl file")
+			return err
+		}
+		book, err = tx.FindBookingByID(int(bookingID))
+		if err != nil {
+			log.Println("Error fetching the booking, in userServiceImpl file")
+			return err
+		}
+		// a booking that expired while the payment went through has the payment credited to the wallet
+		status, err = confirmBooking(tx, book, razor, due)
 		return err
+	})
+	if errors.Is(err, errPaymentReplayed) {
+		usi.flagPayment(razor, book.BookingID, 0, reasonReplayed)
 	}
-	if err := usi.repo.PaymentSuccess(razor); err != nil {
-		log.Println("Error updating Payment Info, in userServiceImpl file")
+	if err != nil {
 		return err
 	}
+	switch status {
+	case "":
+		// the webhook of the payment confirmed the booking already
+		return nil
+	case PaymentCredited:
+		if book.Status == "Expired" {
+			log.Println("Seat hold expired before the payment, in userServiceImpl file")
+			return errors.New("seat hold expired, the payment was credited to the wallet")
+		}
+		return errors.New("booking was already paid, the payment was credited to the wallet")
+	}
+	if err := usi.hold.Release(bookingID); err != nil {
+		log.Println("Error releasing the seat hold, in userServiceImpl file")
+	}
+	usi.mailTicket(book)
 	return nil
 }
 
@@ -92,60 +255,51 @@ type pageVariables struct {
 }
 
 // MakePayment implements interfaces.UserService.
-func (usi *UserServiceImpl) MakePayment(bookID int) (*dto.MakePaymentResp, error) {
-	booking, err := usi.repo.FindBookingByID(bookID)
+func (usi *UserServiceImpl) MakePayment(pnr string) (*dto.MakePaymentResp, error) {
+	booking, err := usi.repo.FindBookingByPNR(pnr)
 	if err != nil {
 		log.Println("Error fetching the booking, in userServiceImpl file")
 		return nil, err
 	}
+	if booking.Status == "Expired" || (booking.HoldExpiresAt != nil && booking.HoldExpiresAt.Before(time.Now())) {
+		log.Println("Seat hold expired for the booking, in userServiceImpl file")
+		return nil, errors.New("seat hold expired")
+	}
+	if booking.Status == "Waitlisted" {
+		log.Println("Payment asked for a waitlisted booking, in userServiceImpl file")
+		return nil, errors.New("booking is still on the waitlist")
+	}
 	user, err := usi.repo.GetUserInfo(int(booking.UserID))
 	if err != nil {
 		log.Println("Error fetching the user info, in userServiceImpl file")
 		return nil, err
 	}
-	amount := booking.FarePostDiscount * 100
-	razorKey := os.Getenv("RAZOR_KEY_ID")
-	razorSecret := os.Getenv("RAZOR_SECRET")
-	client := razorpay.NewClient(razorKey, razorSecret)
-
-	data := map[string]interface{}{
-		"amount":   amount,
-		"currency": "INR",
-		"receipt":  strconv.Itoa(int(booking.BookingID)),
-	}
-	body, err := client.Order.Create(data, nil)
+	// a rescheduled booking only pays what its credit does not cover
+	due := booking.FarePostDiscount - booking.RescheduleCredit
+	order, err := usi.gateway.CreateOrder(ledger.Paisa(due), booking.PNR)
 	if err != nil {
-		fmt.Printf("Problem getting repositorys information: %v\n", err)
+		log.Println("Unable to raise the order of the booking, in userServiceImpl file")
+		return nil, err
+	}
+	if err := usi.repo.AddPaymentOrder(&entities.PaymentOrder{OrderID: order.ID, BookingID: booking.BookingID, Amount: order.Amount}); err != nil {
+		log.Println("Unable to keep the order of the booking, in userServiceImpl file")
 		return nil, err
 	}
-
-	value := body["id"]
-	str := value.(string)
 
 	homepageVariables := pageVariables{
-		OrderID: str,
+		OrderID: order.ID,
 	}
 	paymentResp := &dto.MakePaymentResp{}
-	paymentResp.AmountInRupees = int(booking.FarePostDiscount)
+	paymentResp.AmountInRupees = rupees(order.Amount)
+	paymentResp.AmountInPaisa = order.Amount
 	paymentResp.BookingID = int(booking.BookingID)
+	paymentResp.PNR = booking.PNR
 	paymentResp.Email = user.Email
 	paymentResp.PhoneNumber = user.PhoneNumber
 	paymentResp.OrderID = homepageVariables.OrderID
 	return paymentResp, nil
 }
 
-// SeatNameDecoder function is used to decode the seat name from rows and columns
-func SeatNameDecoder(row int, column int) string {
-	seatName := ""
-	if row < 9 {
-		seatName = fmt.Sprintf("0%d%c", row+1, 'A'+column)
-	} else {
-		seatName = fmt.Sprintf("%d%c", row+1, 'A'+column)
-
-	}
-	return seatName
-}
-
 // SeatAvailabilityChecker implements interfaces.UserService.
 func (usi *UserServiceImpl) SeatAvailabilityChecker(seatReq *dto.SeatAvailabilityRequest) (*dto.SeatAvailabilityResponse, error) {
 	busID := seatReq.BusID
@@ -160,137 +314,42 @@ func (usi *UserServiceImpl) SeatAvailabilityChecker(seatReq *dto.SeatAvailabilit
 		log.Println("Error fetching the bus Info, in userServiceImpl file")
 		return nil, err
 	}
-	busTypeCode := bus.BusTypeCode
-	totalSleeper := bus.TotalSleeperSeats
-	totalSeater := bus.TotalPushBackSeats
+	inventory, err := loadInventory(usi.repo, bus, chart)
+	if err != nil {
+		return nil, err
+	}
+	segment, err := inventory.segment(seatReq.FromStation, seatReq.ToStation)
+	if err != nil {
+		log.Println("Invalid stops for the bus, in userServiceImpl file")
+		return nil, err
+	}
 	seatStatus := &dto.SeatAvailabilityResponse{}
 	seatStatus.BusID = seatReq.BusID
 	seatStatus.Date = seatReq.Date
 	seatStatus.BusStatus = chart.Status
-	type DeckOneLayoutstr struct {
-		DeckLayout [][]bool `json:"deckOneLayout"`
-	}
-	type DeckTwoLayoutstr struct {
-		DeckLayout [][]bool `json:"deckTwoLayout"`
-	}
-	deckOneCount := 0
-	deckTwoCount := 0
-	var deckOneSlots []string
-	var deckTwoSlots []string
-	var unmarshaledLayoutOne DeckOneLayoutstr
-	var unmarshaledLayoutTwo DeckTwoLayoutstr
-	json.Unmarshal(chart.DeckOneSeatLayout, &unmarshaledLayoutOne)
-	json.Unmarshal(chart.DeckTwoSeatLayout, &unmarshaledLayoutTwo)
-	for i := 0; i < len(unmarshaledLayoutOne.DeckLayout); i++ {
-		// var seatCode string
-		for j := 0; j < len(unmarshaledLayoutOne.DeckLayout[i]); j++ {
-			if unmarshaledLayoutOne.DeckLayout[i][j] {
-				deckOneCount++
-			} else {
-				deckOneSlots = append(deckOneSlots, SeatNameDecoder(i, j))
-			}
-		}
-	}
-	for i := 0; i < len(unmarshaledLayoutTwo.DeckLayout); i++ {
-		for j := 0; j < len(unmarshaledLayoutTwo.DeckLayout[i]); j++ {
-			if unmarshaledLayoutTwo.DeckLayout[i][j] {
-				deckOneCount++
-			} else {
-				deckTwoSlots = append(deckTwoSlots, SeatNameDecoder(i, j+3))
-			}
+	seatStatus.FromStation = inventory.stops[segment.From].StationName
+	seatStatus.ToStation = inventory.stops[segment.To].StationName
+	for _, seat := range inventory.chart.AvailableFor(inventory.seatMap, segment) {
+		if seat.Class == seatmap.Sleeper {
+			seatStatus.AvailableSleeperSlots = append(seatStatus.AvailableSleeperSlots, seat.ID)
+		} else {
+			seatStatus.AvailableSeaterSlots = append(seatStatus.AvailableSeaterSlots, seat.ID)
 		}
 	}
-	if busTypeCode == "AC_SL" || busTypeCode == "SL" {
-		totalSleeper = totalSleeper - uint(deckOneCount+deckTwoCount)
-		seatStatus.AvailableSleeperSlots = append(seatStatus.AvailableSleeperSlots, deckOneSlots...)
-		seatStatus.AvailableSleeperSlots = append(seatStatus.AvailableSleeperSlots, deckTwoSlots...)
-	} else if busTypeCode == "AC_SE" || busTypeCode == "SE" {
-		totalSeater = totalSeater - uint(deckOneCount+deckTwoCount)
-		seatStatus.AvailableSeaterSlots = append(seatStatus.AvailableSeaterSlots, deckOneSlots...)
-		seatStatus.AvailableSeaterSlots = append(seatStatus.AvailableSeaterSlots, deckTwoSlots...)
-	} else {
-		totalSleeper = totalSleeper - uint(deckTwoCount)
-		totalSeater = totalSeater - uint(deckOneCount)
-		seatStatus.AvailableSeaterSlots = append(seatStatus.AvailableSeaterSlots, deckOneSlots...)
-		seatStatus.AvailableSleeperSlots = append(seatStatus.AvailableSleeperSlots, deckTwoSlots...)
-	}
-	seatStatus.SleeperSlotsLeft = int(totalSleeper)
-	seatStatus.SeaterSlotsLeft = int(totalSeater)
-	seatStatus.BusType = busTypeCode
+	seatStatus.SleeperSlotsLeft = len(seatStatus.AvailableSleeperSlots)
+	seatStatus.SeaterSlotsLeft = len(seatStatus.AvailableSeaterSlots)
+	seatStatus.BusType = bus.BusTypeCode
 	return seatStatus, nil
 }
 
 // CancelBooking implements interfaces.UserService.
-func (usi *UserServiceImpl) CancelBooking(bookID int) (*entities.Booking, error) {
-	booking, err := usi.repo.FindBookingByID(bookID)
-	if err != nil {
-		log.Println("Error finding booking that has to be cancelled, in userServiceImpl file")
-		return nil, err
-	}
-	parsedDate, err := time.Parse("02 01 2006", booking.BookingDate)
-	if err != nil {
-		log.Println("Error parsing the date, in userServiceImpl file")
-		return nil, err
-	}
-	//Getting bus chart
-	chart, _ := usi.repo.GetChart(int(booking.BusID), parsedDate)
-	type DeckOneLayoutstr struct {
-		DeckLayout [][]bool `json:"deckOneLayout"`
-	}
-	type DeckTwoLayoutstr struct {
-		DeckLayout [][]bool `json:"deckTwoLayout"`
-	}
-	var unmarshaledLayoutOne DeckOneLayoutstr
-	var unmarshaledLayoutTwo DeckTwoLayoutstr
-	for i := 0; i < len(booking.SeatReserved); i++ {
-		k := booking.SeatReserved[i][2]
-		num, _ := strconv.Atoi(booking.SeatReserved[i][:2])
-		if k == 'A' || k == 'B' || k == 'C' {
-			json.Unmarshal(chart.DeckOneSeatLayout, &unmarshaledLayoutOne)
-			if k == 'A' && unmarshaledLayoutOne.DeckLayout[num-1][0] {
-				unmarshaledLayoutOne.DeckLayout[num-1][0] = false
-			} else if k == 'B' && unmarshaledLayoutOne.DeckLayout[num-1][1] {
-				unmarshaledLayoutOne.DeckLayout[num-1][1] = false
-			} else if k == 'C' && unmarshaledLayoutOne.DeckLayout[num-1][2] {
-				unmarshaledLayoutOne.DeckLayout[num-1][2] = false
-			}
-			Layout, _ := json.Marshal(&unmarshaledLayoutOne)
-			chart.DeckOneSeatLayout = Layout
-		} else if k == 'D' || k == 'E' || k == 'F' {
-			json.Unmarshal(chart.DeckTwoSeatLayout, &unmarshaledLayoutTwo)
-			if k == 'D' && unmarshaledLayoutTwo.DeckLayout[num-1][0] {
-				unmarshaledLayoutTwo.DeckLayout[num-1][0] = false
-			} else if k == 'E' && unmarshaledLayoutTwo.DeckLayout[num-1][1] {
-				unmarshaledLayoutTwo.DeckLayout[num-1][1] = false
-			} else if k == 'F' && unmarshaledLayoutTwo.DeckLayout[num-1][2] {
-				unmarshaledLayoutTwo.DeckLayout[num-1][2] = false
-			}
-			Layout, _ := json.Marshal(&unmarshaledLayoutTwo)
-			chart.DeckTwoSeatLayout = Layout
-		}
-		_, err := usi.repo.UpdateChart(chart)
-		if err != nil {
-			log.Println("Could not update the chart, in userService file")
-			return nil, err
-		}
-	}
-	refundPostCancellationCharge := booking.FarePostDiscount * 0.9
-	user, _ := usi.repo.GetUserInfo(int(booking.UserID))
-	user.UserWallet += int(refundPostCancellationCharge)
-	busID := booking.BusID
-	bus, _ := usi.repo.GetBusInfo(int(busID))
-	provider, _ := usi.repo.GetProviderInfo(int(bus.ProviderID))
-	provider.ProviderWallet -= int(refundPostCancellationCharge)
-	usi.repo.UpdateUser(user)
-	usi.repo.UpdateProvider(provider)
-	booking.Status = "Cancelled by User"
-	cancelledBooking, err := usi.repo.CancelBooking(booking)
+func (usi *UserServiceImpl) CancelBooking(bookID int, refundTo string, email string) (*entities.Booking, error) {
+	user, err := usi.repo.FindUserByEmail(email)
 	if err != nil {
-		log.Println("unable to cancel the booking, in userServiceImpl file")
+		log.Println("Error finding user, in userServiceImpl file")
 		return nil, err
 	}
-	WhatsappNotifier("The booking has been cancelled successfully and the refund has been transferred to your wallet.", user.PhoneNumber)
-	return cancelledBooking, nil
+	return usi.cancelItems(bookID, user.ID, nil, nil, refundTo)
 }
 
 // ViewBookings implements interfaces.UserService.
@@ -300,6 +359,8 @@ func (usi *UserServiceImpl) ViewBookings(email string) ([]*entities.Booking, err
 		// log.Println("Error finding bookings, in userServiceImpl file")
 		return nil, err
 	}
+	setWaitlistPositions(usi.repo, bookings)
+	setBookingItems(usi.repo, bookings)
 	return bookings, err
 }
 
@@ -315,276 +376,169 @@ func (usi *UserServiceImpl) FindCoupon() ([]*entities.Coupons, error) {
 
 // BookSeat implements interfaces.UserService.
 func (usi *UserServiceImpl) BookSeat(bookreq *dto.BookingRequest, email string) (*entities.Booking, error) {
-	if len(bookreq.PassengerID) != len(bookreq.SeatsReserved) {
-		log.Println("Error seat-passenger mismatch, in userServiceImpl file")
-		return nil, errors.New("seat-passenger count mismatch")
-	}
-	booking := &entities.Booking{}
-	booking.BookingDate = bookreq.BookingDate
-	booking.SeatReserved = bookreq.SeatsReserved
-	booking.BusID = bookreq.BusID
 	user, err := usi.repo.FindUserByEmail(email)
 	if err != nil {
 		log.Println("Error finding user, in userServiceImpl file")
 		return nil, err
 	}
-	userBalance := uint(user.UserWallet)
-	booking.UserID = user.ID
-	passengers, _ := usi.repo.ViewAllPassengers(email)
-	var passengerIdlist []int
-	for i := 0; i < len(passengers); i++ {
-		passengerIdlist = append(passengerIdlist, int(passengers[i].PassengerID))
-	}
-	// fmt.Println(passengerIdlist)
-	// fmt.Println(bookreq.PassengerId)
-	for i := 0; i < len(bookreq.PassengerID); i++ {
-		count := 0
-		for j := 0; j < len(passengerIdlist); j++ {
-			if bookreq.PassengerID[i] == int64(passengerIdlist[j]) {
-				count++
-				break
-			}
-		}
-		fmt.Println(count)
-		if count != 1 {
-			log.Println("Passenger Id not valid, in userServiceImpl file")
-			return nil, errors.New("unknown passenger Id provided")
-		}
-	}
-	booking.PassengerID = bookreq.PassengerID
-	//Getting bus info
-	bus, err := usi.repo.GetBusInfo(int(bookreq.BusID))
+	draft, err := usi.draftBooking(bookreq, user, email)
 	if err != nil {
-		log.Println("Error fetching bus details, in userServiceImpl file")
 		return nil, err
 	}
-	//Getting provider info
-	provider, _ := usi.repo.GetProviderInfo(int(bus.ProviderID))
-	providerBalance := provider.ProviderWallet
-	scheduleID := int(bus.ScheduleID)
-	//Getting bus type
-	// busType, err := usi.repo.GetBusTypeDetails(bus.BusTypeCode)
-	// if err != nil {
-	// 	log.Println("Error fetching bus type details, in userServiceImpl file")
-	// 	return nil, err
-	// }
-	//Getting bus seat layout
-	// seatLayout, err := usi.repo.GetSeatLayout(int(busType.SeatLayoutId))
-	// if err != nil {
-	// 	log.Println("Error fetching seat layout details, in userServiceImpl file")
-	// 	return nil, err
-	// }
-	parsedDate, err := time.Parse("02 01 2006", bookreq.BookingDate)
+	//Reserving the seats, debiting the wallets and making the booking has to commit together.
+	booked, err := usi.commitDrafts(user, []*bookingDraft{draft}, bookreq.PreferredPaymentType == "Wallet")
 	if err != nil {
-		log.Println("Error parsing the date, in userServiceImpl file")
 		return nil, err
 	}
-	//Getting bus chart
-	chart, err := usi.repo.GetChart(int(bookreq.BusID), parsedDate)
+	return booked[0], nil
+}
+
+// BookItinerary implements interfaces.UserService.
+func (usi *UserServiceImpl) BookItinerary(request *dto.ItineraryBookingRequest, email string) ([]*entities.Booking, error) {
+	if len(request.Legs) == 0 || len(request.Legs) > routeplanner.MaxLegsAllowed {
+		log.Println("Invalid number of legs, in userServiceImpl file")
+		return nil, fmt.Errorf("an itinerary needs between 1 and %d legs", routeplanner.MaxLegsAllowed)
+	}
+	user, err := usi.repo.FindUserByEmail(email)
 	if err != nil {
-		log.Println("Error fetching bus schedule, in userServiceImpl file")
+		log.Println("Error finding user, in userServiceImpl file")
 		return nil, err
 	}
-	if chart.Status != "Active" {
-		log.Println("Bus Schedule seems to be cancelled or Inactive, in userServiceImpl file")
-		return nil, errors.New("schedule not in active state")
+	ref := newItineraryRef()
+	var drafts []*bookingDraft
+	seen := map[string]bool{}
+	for _, leg := range request.Legs {
+		if leg.Waitlist {
+			log.Println("Waitlist asked for an itinerary leg, in userServiceImpl file")
+			return nil, errors.New("itinerary legs cannot be waitlisted")
+		}
+		key := fmt.Sprintf("%d %s", leg.BusID, leg.BookingDate)
+		if seen[key] {
+			log.Println("Same bus booked twice in the itinerary, in userServiceImpl file")
+			return nil, errors.New("an itinerary cannot take the same bus twice on one day")
+		}
+		seen[key] = true
+		draft, err := usi.draftBooking(leg, user, email)
+		if err != nil {
+			return nil, err
+		}
+		draft.booking.ItineraryRef = ref
+		drafts = append(drafts, draft)
+	}
+	return usi.commitDrafts(user, drafts, request.PreferredPaymentType == "Wallet")
+}
+
+// PlanRoutes implements interfaces.UserService.
+func (usi *UserServiceImpl) PlanRoutes(request *dto.RoutePlanRequest) ([]*dto.Itinerary, error) {
+	if request.Date == "" {
+		request.Date = time.Now().Format("02 01 2006")
 	}
-	//Fetching the fare
-	bFare, err := usi.repo.GetBaseFare(scheduleID)
+	parsedDate, err := time.Parse("02 01 2006", request.Date)
 	if err != nil {
-		log.Println("Error fetching base fare, in userServiceImpl file")
-		return nil, err
+		log.Println("Error parsing the date, in userServiceImpl file")
+		return nil, errors.New("invalid travel date")
 	}
-	booking.ActualFare = float64(bFare.BaseFare)
-	ACchecker := bus.BusTypeCode[:2]
-	// fmt.Println(ACchecker)
-	if ACchecker == "AC" {
-		booking.ActualFare = booking.ActualFare * 1.3
-		// fmt.Println("Amount updated for AC")
-	}
-	totalFare := 0.0
-	if bus.BusTypeCode == "AC_SL" || bus.BusTypeCode == "SL" {
-		totalFare = float64(len(bookreq.PassengerID)) * booking.ActualFare * 1.2
-	} else if bus.BusTypeCode == "AC_SL_SE" || bus.BusTypeCode == "SL_SE" {
-		c1 := 0
-		c2 := 0
-		for i := 0; i < len(bookreq.SeatsReserved); i++ {
-			k := bookreq.SeatsReserved[i][2]
-			// num, _ := strconv.Atoi(bookreq.SeatsReserved[i][:2])
-			if k == 'A' || k == 'B' || k == 'C' {
-				c1++
-			} else if k == 'D' || k == 'E' || k == 'F' {
-				c2++
-			}
-		}
-		totalFare = (float64(c1) * booking.ActualFare) + (float64(c2) * booking.ActualFare * 1.2)
-	} else {
-		totalFare = float64(len(bookreq.PassengerID)) * booking.ActualFare
+	if request.Passengers <= 0 {
+		request.Passengers = 1
+	}
+	depart := request.DepartureStation
+	if station, err := usi.repo.GetParentLocation(depart); err == nil {
+		depart = station.ParentLocation
+	}
+	arrival := request.ArrivalStation
+	if station, err := usi.repo.GetParentLocation(arrival); err == nil {
+		arrival = station.ParentLocation
 	}
-	booking.ActualFare = totalFare
-	discount := 0
-	coupon, err := usi.repo.FindCouponByID(int(bookreq.UsedCouponID))
+	runs, err := usi.repo.FindConnections()
 	if err != nil {
-		log.Println("Error finding coupon, in userServiceImpl file")
+		log.Println("Error fetching the bus schedules, in userServiceImpl file")
 		return nil, err
 	}
-	if coupon.IsActive {
-		booking.UsedCouponID = bookreq.UsedCouponID
-		discount = int(coupon.Discount)
-	} else {
-		log.Println("Coupon not active or valid, in userServiceImpl file")
-		return nil, errors.New("coupon not active or valid")
-	}
-	booking.Status = "Awaiting Payment"
-	booking.FarePostDiscount = booking.ActualFare * float64((100-float64(discount))/100)
-	if bookreq.PreferredPaymentType == "Wallet" && booking.FarePostDiscount <= float64(userBalance) {
-		userBalance = userBalance - uint(booking.FarePostDiscount)
-		providerBalance = providerBalance + int(booking.FarePostDiscount)
-		user.UserWallet = int(userBalance)
-		provider.ProviderWallet = providerBalance
-		booking.Status = "Success"
-	} else if bookreq.PreferredPaymentType == "Wallet" && booking.FarePostDiscount > float64(userBalance) {
-		log.Println("Insuffucient fund to make the booking using wallet,Redirecting to RazorPay.")
-	}
-	// fmt.Println(chart)
-	// fmt.Print(chart.DeckOneSeatLayout)
-	//reserving seat as per seatreserved string
-	type DeckOneLayoutstr struct {
-		DeckLayout [][]bool `json:"deckOneLayout"`
-	}
-	type DeckTwoLayoutstr struct {
-		DeckLayout [][]bool `json:"deckTwoLayout"`
-	}
-	var unmarshaledLayoutOne DeckOneLayoutstr
-	var unmarshaledLayoutTwo DeckTwoLayoutstr
-	// var copyLayoutOne [][]bool
-	// var copyLayoutTwo [][]bool
-	// flag := true
-	// flag1 := true
-	json.Unmarshal(chart.DeckOneSeatLayout, &unmarshaledLayoutOne)
-	copyLayoutOne := deepcopy.Copy(unmarshaledLayoutOne).(DeckOneLayoutstr)
-
-	// copyLayoutOne = unmarshaledLayoutOne.DeckLayout
-	// copyLayoutOne = append(copyLayoutOne, unmarshaledLayoutOne.DeckLayout...)
-	// fmt.Print(copyLayoutOne)
-	json.Unmarshal(chart.DeckTwoSeatLayout, &unmarshaledLayoutTwo)
-	// copyLayoutTwo = unmarshaledLayoutTwo.DeckLayout
-	copyLayoutTwo := deepcopy.Copy(unmarshaledLayoutTwo).(DeckTwoLayoutstr)
-
-	// fmt.Print(copyLayoutOne.DeckLayout)
-	// copyLayoutOne = unmarshaledLayoutOne
-	// copyLayoutOne := make([][]bool, len(unmarshaledLayoutOne.DeckLayout))
-	// for i := range copyLayoutOne {
-	// 	copyLayoutOne[i] = make([]int, len(original[i]))
-	// 	copy(copySlice[i], original[i])
-	// }
-	// json.Unmarshal(chart.DeckTwoSeatLayout, &unmarshaledLayoutTwo)
-	// fmt.Print(unmarshaledLayoutTwo)
-	// copy(te, unmarshaledLayoutTwo.DeckLayout)
-	// fmt.Print(te)
-	for i := 0; i < len(bookreq.SeatsReserved); i++ {
-		k := bookreq.SeatsReserved[i][2]
-		num, _ := strconv.Atoi(bookreq.SeatsReserved[i][:2])
-		if k == 'A' || k == 'B' || k == 'C' {
-			// if flag {
-			// 	flag = false
-			// }
-			json.Unmarshal(chart.DeckOneSeatLayout, &unmarshaledLayoutOne)
-			// copyLayoutOne = unmarshaledLayoutOne
-			if num > len(unmarshaledLayoutOne.DeckLayout) {
-				log.Println("you are trying to book an invalid seat, in userServiceImpl file")
-				return nil, errors.New("invalid seat entered")
+	buses := map[uint]*entities.BusScheduleCombo{}
+	fares := map[uint]float64{}
+	var connections []routeplanner.Connection
+	for _, run := range runs {
+		fare, ok := fares[run.Buses.ScheduleID]
+		if !ok {
+			baseFare, err := usi.repo.GetBaseFare(int(run.Buses.ScheduleID))
+			if err != nil {
+				continue
 			}
-			// fmt.Print(unmarshaledLayout)
-			// if err != nil {
-			// 	log.Println("Error unmarshalling seat layout, in userServiceImpl file")
-			// 	return nil, err
-			// }
-			if k == 'A' && !unmarshaledLayoutOne.DeckLayout[num-1][0] {
-				unmarshaledLayoutOne.DeckLayout[num-1][0] = true
-			} else if k == 'B' && !unmarshaledLayoutOne.DeckLayout[num-1][1] {
-				unmarshaledLayoutOne.DeckLayout[num-1][1] = true
-			} else if k == 'C' && !unmarshaledLayoutOne.DeckLayout[num-1][2] {
-				unmarshaledLayoutOne.DeckLayout[num-1][2] = true
-			} else {
-				log.Println("Seat you are trying to book is already reserved or invalid seat entered, in userServiceImpl file")
-				unmarshaledLayoutOne = copyLayoutOne
-				Layout, _ := json.Marshal(&unmarshaledLayoutOne)
-				chart.DeckOneSeatLayout = Layout
-				_, err := usi.repo.UpdateChart(chart)
-				if err != nil {
-					log.Println("Could not update the chart, in userService file")
-					return nil, err
-				}
-				return nil, errors.New("seat already reserved")
-			}
-			// fmt.Println(unmarshaledLayoutOne.DeckLayout)
-			Layout, _ := json.Marshal(&unmarshaledLayoutOne)
-			chart.DeckOneSeatLayout = Layout
-		} else if k == 'D' || k == 'E' || k == 'F' {
-			// fmt.Println(chart.DeckTwoSeatLayout)
-			// if flag1 {
-			// 	flag1 = false
-			// 	// fmt.Println("Flag set to false")
-			// }
-			json.Unmarshal(chart.DeckTwoSeatLayout, &unmarshaledLayoutTwo)
-			// copyLayoutTwo = unmarshaledLayoutTwo
-			// fmt.Println(unmarshaledLayoutTwo)
-			if num > len(unmarshaledLayoutTwo.DeckLayout) {
-				log.Println("Seat you are trying to book an invalid seat, in userServiceImpl file")
-				return nil, errors.New("invalid seat entered")
+			fare = float64(baseFare.BaseFare)
+			fares[run.Buses.ScheduleID] = fare
+		}
+		class, _ := seatmap.DeckClasses(run.BusTypeCode)
+		buses[run.BusID] = run
+		connections = append(connections, routeplanner.Connection{
+			BusID:     run.BusID,
+			From:      run.DepartureStation,
+			To:        run.ArrivalStation,
+			Departure: run.DepartureTime,
+			Arrival:   run.ArrivalTime,
+			Fare:      seatFare(fare, run.BusTypeCode, class),
+		})
+	}
+	planned := routeplanner.Plan(connections, depart, arrival, parsedDate, routeplanner.Options{
+		MaxLegs:    request.MaxLegs,
+		MinLayover: time.Duration(request.MinLayover) * time.Minute,
+		Limit:      5 * routeplanner.DefaultLimit,
+	})
+	seatsLeft := map[string]int{}
+	itineraries := []*dto.Itinerary{}
+	for _, plan := range planned {
+		itinerary := &dto.Itinerary{
+			TotalDurationMinutes: int(plan.Duration.Minutes()),
+			LayoverMinutes:       int(plan.Layover.Minutes()),
+			TotalFare:            plan.Fare * float64(request.Passengers),
+		}
+		for _, leg := range plan.Legs {
+			run := buses[leg.BusID]
+			bookingDate := leg.Depart.Format("02 01 2006")
+			key := fmt.Sprintf("%d %s", leg.BusID, bookingDate)
+			left, ok := seatsLeft[key]
+			if !ok {
+				left = usi.seatsLeft(&run.Buses, leg.Depart)
+				seatsLeft[key] = left
 			}
-			// if err != nil {
-			// 	log.Println("Error unmarshalling seat layout, in userServiceImpl file")
-			// 	return nil, err
-			// }
-			if k == 'D' && !unmarshaledLayoutTwo.DeckLayout[num-1][0] {
-				unmarshaledLayoutTwo.DeckLayout[num-1][0] = true
-			} else if k == 'E' && !unmarshaledLayoutTwo.DeckLayout[num-1][1] {
-				unmarshaledLayoutTwo.DeckLayout[num-1][1] = true
-			} else if k == 'F' && !unmarshaledLayoutTwo.DeckLayout[num-1][2] {
-				unmarshaledLayoutTwo.DeckLayout[num-1][2] = true
-			} else {
-				log.Println("Seat you are trying to book is already reserved, in userServiceImpl file")
-				unmarshaledLayoutTwo = copyLayoutTwo
-				Layout, _ := json.Marshal(&unmarshaledLayoutTwo)
-				chart.DeckTwoSeatLayout = Layout
-				_, err := usi.repo.UpdateChart(chart)
-				if err != nil {
-					log.Println("Could not update the chart, in userService file")
-					return nil, err
-				}
-				return nil, errors.New("seat already reserved")
+			if left < request.Passengers {
+				itinerary = nil
+				break
 			}
-			// fmt.Println(unmarshaledLayoutTwo.DeckLayout)
-			Layout, _ := json.Marshal(&unmarshaledLayoutTwo)
-			chart.DeckTwoSeatLayout = Layout
-		} else {
-			log.Println("Seat you are trying to book an invalid seat, in userServiceImpl file")
-			return nil, errors.New("invalid seat entered")
+			itinerary.Legs = append(itinerary.Legs, &dto.ItineraryLeg{
+				BusID:            leg.BusID,
+				BusNumber:        run.BusNumber,
+				BusTypeCode:      run.BusTypeCode,
+				DepartureStation: leg.From,
+				ArrivalStation:   leg.To,
+				BookingDate:      bookingDate,
+				DepartureTime:    leg.Departure,
+				ArrivalTime:      leg.Arrival,
+				Fare:             leg.Fare,
+				SeatsLeft:        left,
+			})
 		}
-		_, err := usi.repo.UpdateChart(chart)
-		if err != nil {
-			log.Println("Could not update the chart, in userService file")
-			return nil, err
+		if itinerary != nil {
+			itineraries = append(itineraries, itinerary)
+		}
+		if len(itineraries) == routeplanner.DefaultLimit {
+			break
 		}
 	}
-	if _, err := usi.repo.UpdateUser(user); err != nil {
-		log.Println("Could not update the user Balance, in userService file")
-		return nil, err
-	}
-	if _, err := usi.repo.UpdateProvider(provider); err != nil {
-		log.Println("Could not update the provider Balance, in userService file")
-		return nil, err
+	return itineraries, nil
+}
+
+// seatsLeft function is used to count the free seats of the bus on the given day, a bus without an active chart for the day has none.
+func (usi *UserServiceImpl) seatsLeft(bus *entities.Buses, departure time.Time) int {
+	day, _ := time.Parse("02 01 2006", departure.Format("02 01 2006"))
+	chart, err := usi.repo.GetChart(int(bus.BusID), day)
+	if err != nil || chart.Status != "Active" {
+		return 0
 	}
-	booked, err := usi.repo.MakeBooking(booking)
+	inventory, err := loadInventory(usi.repo, bus, chart)
 	if err != nil {
-		log.Println("Unable to make the booking, in userServiceImpl file")
-		return nil, err
+		return 0
 	}
-	message := fmt.Sprintf("The seats %s of the bus %d has been booked for the day %s.", booked.SeatReserved[:], booked.BusID, booked.BookingDate)
-	WhatsappNotifier(message, user.PhoneNumber)
-	return booked, nil
+	return len(inventory.chart.Available(inventory.seatMap))
 }
 
 // ViewAllPassengers implements interfaces.UserService.
@@ -608,48 +562,180 @@ func (usi *UserServiceImpl) AddPassenger(passenger *entities.PassengerInfo, emai
 }
 
 // FindBus implements interfaces.UserService.
-func (usi *UserServiceImpl) FindBus(request *dto.BusRequest) ([]*entities.BusesResp, error) {
-	depart := request.DepartureStation
-	arrival := request.ArrivalStation
-	buses, err := usi.repo.FindBus(depart, arrival)
+func (usi *UserServiceImpl) FindBus(request *dto.BusRequest) ([]*dto.BusSearchResult, error) {
+	if request.Date == "" {
+		request.Date = time.Now().Format("02 01 2006")
+	}
+	parsedDate, err := time.Parse("02 01 2006", request.Date)
 	if err != nil {
+		log.Println("Error parsing the date, in userServiceImpl file")
+		return nil, errors.New("invalid travel date")
+	}
+	candidates := usi.findRouteBuses(request.DepartureStation, request.ArrivalStation)
+	if len(candidates) == 0 {
 		log.Println("No Buses EXISTS for this route, in userService file")
 		return nil, errors.New("no Bus exists")
 	}
-	var outbuses []*entities.BusesResp
-	if request.Duration != 0 {
-		for _, bus := range buses {
-			depart, _ := time.Parse("15:04:05", bus.DepartureTime)
-			arrive, _ := time.Parse("15:04:05", bus.ArrivalTime)
-			if depart.After(arrive) {
-				arrive = arrive.Add(24 * time.Hour)
-			}
-			if arrive.Sub(depart) <= time.Duration(request.Duration)*time.Hour {
-				b := &entities.BusesResp{}
-				b.BusID = bus.BusID
-				b.BusNumber = bus.BusNumber
-				b.BusTypeCode = bus.BusTypeCode
-				b.TotalPushBackSeats = bus.TotalPushBackSeats
-				b.TotalSleeperSeats = bus.TotalSleeperSeats
-				outbuses = append(outbuses, b)
+	// The chart of a bus belongs to the day it leaves its origin, which is earlier than the travel day when the stop is reached after midnight.
+	busesByDay := map[string][]uint{}
+	for _, candidate := range candidates {
+		day := parsedDate.AddDate(0, 0, -candidate.stops[candidate.from].DayOffset)
+		candidate.day = day
+		busesByDay[day.Format("02 01 2006")] = append(busesByDay[day.Format("02 01 2006")], candidate.bus.BusID)
+	}
+	chartByBus := map[string]*entities.BusSchedule{}
+	for dayKey, busIDs := range busesByDay {
+		day, _ := time.Parse("02 01 2006", dayKey)
+		charts, err := usi.repo.GetChartsForDay(busIDs, day)
+		if err != nil {
+			log.Println("Error fetching the charts, in userServiceImpl file")
+			return nil, err
+		}
+		for _, chart := range charts {
+			chartByBus[fmt.Sprint(chart.BusID, dayKey)] = chart
+		}
+	}
+	outbuses := []*dto.BusSearchResult{}
+	for _, candidate := range candidates {
+		bus := candidate.bus
+		chart, ok := chartByBus[fmt.Sprint(bus.BusID, candidate.day.Format("02 01 2006"))]
+		if !ok {
+			continue
+		}
+		if !matchesBusType(bus.BusTypeCode, request) {
+			continue
+		}
+		from, to := candidate.stops[candidate.from], candidate.stops[candidate.to]
+		result := &dto.BusSearchResult{}
+		result.BusID = bus.BusID
+		result.BusNumber = bus.BusNumber
+		result.BusTypeCode = bus.BusTypeCode
+		result.Date = request.Date
+		result.BookingDate = candidate.day.Format("02 01 2006")
+		result.FromStation = from.StationName
+		result.ToStation = to.StationName
+		result.DepartureTime = from.DepartureTime
+		result.ArrivalTime = to.ArrivalTime
+		result.DurationMinutes = stopMinutes(to.ArrivalTime, to.DayOffset) - stopMinutes(from.DepartureTime, from.DayOffset)
+		result.ChartStatus = chart.Status
+		if request.Duration != 0 && result.DurationMinutes > request.Duration*60 {
+			continue
+		}
+		inventory, err := newInventory(usi.repo, &bus.Buses, chart, candidate.stops)
+		if err != nil {
+			continue
+		}
+		segment := seatmap.Segment{From: candidate.from, To: candidate.to}
+		baseFare := inventory.segmentFare(segment)
+		counts := inventory.seatMap.CountByClass()
+		if counts[seatmap.Sleeper] > 0 {
+			result.SleeperFare = seatFare(baseFare, bus.BusTypeCode, seatmap.Sleeper)
+			result.Fare = result.SleeperFare
+		}
+		if counts[seatmap.Seater] > 0 {
+			result.SeaterFare = seatFare(baseFare, bus.BusTypeCode, seatmap.Seater)
+			result.Fare = result.SeaterFare
+		}
+		if chart.Status == "Active" {
+			for _, seat := range inventory.chart.AvailableFor(inventory.seatMap, segment) {
+				if seat.Class == seatmap.Sleeper {
+					result.SleeperSeatsLeft++
+				} else {
+					result.SeaterSeatsLeft++
+				}
 			}
 		}
-		// buses = outbuses
-		// return outbuses, nil
+		if request.Price != 0 && result.Fare > float64(request.Price) {
+			continue
+		}
+		outbuses = append(outbuses, result)
 	}
-	// if request.Price != 0 {
-	// 	var outpricebuses []*entities.BusScheduleCombo
-	// 	for _, bus := range buses {
-	// 		baseFare, _ := usi.repo.GetBaseFare(int(bus.ScheduleID))
-	// 		if request.Price <= int(baseFare.BaseFare) {
-	// 			outpricebuses = append(outpricebuses, bus)
-	// 		}
-
-	// 	}
-	// }
+	sortBusResults(outbuses, request.SortBy)
 	return outbuses, nil
 }
 
+// routeBus struct is a bus found by the search together with the stops it runs and the part of them the user travels.
+type routeBus struct {
+	bus   *entities.BusScheduleCombo
+	stops []*entities.ScheduleStop
+	from  int
+	to    int
+	day   time.Time
+}
+
+// findRouteBuses function is used to collect the buses whose schedule runs between the stations and the buses that stop at both of them on the way.
+func (usi *UserServiceImpl) findRouteBuses(depart string, arrival string) []*routeBus {
+	var combos []*entities.BusScheduleCombo
+	if buses, err := usi.repo.FindBus(depart, arrival); err == nil {
+		combos = append(combos, buses...)
+	}
+	if buses, err := usi.repo.FindSegmentBuses(depart, arrival); err == nil {
+		combos = append(combos, buses...)
+	}
+	departNames := []string{depart}
+	if station, err := usi.repo.GetParentLocation(depart); err == nil {
+		departNames = append(departNames, station.ParentLocation)
+	}
+	arrivalNames := []string{arrival}
+	if station, err := usi.repo.GetParentLocation(arrival); err == nil {
+		arrivalNames = append(arrivalNames, station.ParentLocation)
+	}
+	var candidates []*routeBus
+	seen := map[uint]bool{}
+	for _, bus := range combos {
+		if seen[bus.BusID] {
+			continue
+		}
+		stops, err := routeStops(usi.repo, bus.Buses.ScheduleID, &bus.Schedule)
+		if err != nil {
+			continue
+		}
+		from, to := findStop(stops, departNames), findStop(stops, arrivalNames)
+		if from < 0 || to <= from {
+			continue
+		}
+		seen[bus.BusID] = true
+		candidates = append(candidates, &routeBus{bus: bus, stops: stops, from: from, to: to})
+	}
+	return candidates
+}
+
+// findStop function returns the position of the first of the names the route stops at, -1 when it stops at none of them.
+func findStop(stops []*entities.ScheduleStop, names []string) int {
+	for _, name := range names {
+		if index, err := stopIndex(stops, name, -1); err == nil && index >= 0 {
+			return index
+		}
+	}
+	return -1
+}
+
+// matchesBusType function is used to apply the bus type and AC filters of the search, the bus type filter matches with or without the AC_ prefix.
+func matchesBusType(busTypeCode string, request *dto.BusRequest) bool {
+	isAC := strings.HasPrefix(busTypeCode, "AC")
+	if request.AC != nil && *request.AC != isAC {
+		return false
+	}
+	if request.BusType != "" && request.BusType != busTypeCode && request.BusType != strings.TrimPrefix(busTypeCode, "AC_") {
+		return false
+	}
+	return true
+}
+
+// sortBusResults function is used to sort the search results by price, duration or departure, departure being the default.
+func sortBusResults(results []*dto.BusSearchResult, sortBy string) {
+	sort.SliceStable(results, func(i, j int) bool {
+		switch sortBy {
+		case "price":
+			return results[i].Fare < results[j].Fare
+		case "duration":
+			return results[i].DurationMinutes < results[j].DurationMinutes
+		default:
+			return results[i].DepartureTime < results[j].DepartureTime
+		}
+	})
+}
+
 // Login function is used to login the user into the application
 func (usi *UserServiceImpl) Login(login *dto.LoginRequest) (map[string]string, error) {
 	user, err := usi.repo.FindUserByEmail(login.Email)
@@ -706,9 +792,11 @@ func (usi *UserServiceImpl) RegisterUser(user *entities.User) (*entities.User, e
 }
 
 // NewUserService function returns UserServiceImpl of type UserService Interface
-func NewUserService(repo repository.UserRepository, jwt *middleware.JwtUtil) UserService {
+func NewUserService(repo repository.UserRepository, jwt *middleware.JwtUtil, hold seathold.SeatHold, gateway payment.PaymentGateway) UserService {
 	return &UserServiceImpl{
-		repo: repo,
-		jwt:  jwt,
+		repo:    repo,
+		jwt:     jwt,
+		hold:    hold,
+		gateway: gateway,
 	}
 }
@@ -1,17 +1,83 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"gobus/dto"
 	"gobus/entities"
+	"gobus/ledger"
 	"gobus/middleware"
 	"gobus/repository"
+	"gobus/repository/interfaces"
+	"os"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 )
 
+func TestMain(m *testing.M) {
+	// no e-ticket leaves the tests
+	ticketMailer = func(recipientEmail string, subject string, body string, filename string, pdf []byte) error {
+		return nil
+	}
+	os.Exit(m.Run())
+}
+
+// expectTx makes the transactions of the mocked user repository run on the mock itself.
+func expectTx(userRepo *repository.MockUserRepository) {
+	userRepo.EXPECT().WithTx(gomock.Any()).DoAndReturn(func(fn func(tx interfaces.UserRepository) error) error {
+		return fn(userRepo)
+	}).AnyTimes()
+}
+
+// expectLedger makes the transactions of the mocked ledger run on the mock itself, every account it opens was opened before.
+func expectLedger(ledgerRepo *repository.MockLedgerRepository) {
+	ledgerRepo.EXPECT().WithTx(gomock.Any()).DoAndReturn(func(fn func(tx interfaces.LedgerRepository) error) error {
+		return fn(ledgerRepo)
+	}).AnyTimes()
+	ledgerRepo.EXPECT().OpenAccount(gomock.Any()).DoAndReturn(func(account *entities.LedgerAccount) (*entities.LedgerAccount, bool, error) {
+		return account, false, nil
+	}).AnyTimes()
+	ledgerRepo.EXPECT().SaveAccountBalance(gomock.Any()).Return(nil).AnyTimes()
+}
+
+// expectPost expects the entry with the key moving the amount to be posted to the mocked ledger once.
+func expectPost(ledgerRepo *repository.MockLedgerRepository, key string, amount int64) {
+	ledgerRepo.EXPECT().FindEntryByKey(key).Return(nil, nil)
+	ledgerRepo.EXPECT().AddEntry(postedEntry{key, amount}).Return(nil)
+}
+
+// postedEntry matches the ledger entry with the key that moves the amount.
+type postedEntry struct {
+	key    string
+	amount int64
+}
+
+func (p postedEntry) Matches(x interface{}) bool {
+	entry, ok := x.(*entities.LedgerEntry)
+	if !ok || entry.Key != p.key {
+		return false
+	}
+	for _, line := range entry.Lines {
+		if line.Amount == p.amount {
+			return true
+		}
+	}
+	return false
+}
+
+func (p postedEntry) String() string {
+	return fmt.Sprintf("is the ledger entry %s moving %d", p.key, p.amount)
+}
+
 func Test_register_user(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -165,10 +231,50 @@ func Test_ViewBookings(t *testing.T) {
 				userRepo.EXPECT().ViewBookings("abc@gmail.com").Return([]*entities.Booking{{UserID: 1}},
 					nil,
 				)
+				userRepo.EXPECT().FindBookingItems([]uint{0}).Return(nil, nil)
 			},
 			want:    []*entities.Booking{{UserID: 1}},
 			wantErr: false,
 		},
+		{
+			name: "booking items",
+			args: args{
+				input: "abc@gmail.com",
+			},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().ViewBookings("abc@gmail.com").Return([]*entities.Booking{{BookingID: 4, Status: "Success"}},
+					nil,
+				)
+				userRepo.EXPECT().FindBookingItems([]uint{4}).Return([]*entities.BookingItem{
+					{ID: 1, BookingID: 4, SeatID: "01A", Status: ItemBooked},
+					{ID: 2, BookingID: 4, SeatID: "01B", Status: ItemCancelled},
+				}, nil)
+			},
+			want: []*entities.Booking{{BookingID: 4, Status: "Success", Items: []*entities.BookingItem{
+				{ID: 1, BookingID: 4, SeatID: "01A", Status: ItemBooked},
+				{ID: 2, BookingID: 4, SeatID: "01B", Status: ItemCancelled},
+			}}},
+			wantErr: false,
+		},
+		{
+			name: "waitlist position",
+			args: args{
+				input: "abc@gmail.com",
+			},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().ViewBookings("abc@gmail.com").Return([]*entities.Booking{{BookingID: 7, BusID: 1, BookingDate: "01 01 2030", Status: "Waitlisted", SeatClass: "seater"}},
+					nil,
+				)
+				userRepo.EXPECT().FindWaitlisted(uint(1), "01 01 2030").Return([]*entities.Booking{
+					{BookingID: 3, SeatClass: "seater"},
+					{BookingID: 5, SeatClass: "sleeper"},
+					{BookingID: 7, SeatClass: "seater"},
+				}, nil)
+				userRepo.EXPECT().FindBookingItems([]uint{7}).Return(nil, nil)
+			},
+			want:    []*entities.Booking{{BookingID: 7, BusID: 1, BookingDate: "01 01 2030", Status: "Waitlisted", SeatClass: "seater", WaitlistPosition: 2}},
+			wantErr: false,
+		},
 		{
 			name: "fail",
 			args: args{
@@ -339,3 +445,1521 @@ func Test_Login(t *testing.T) {
 		})
 	}
 }
+
+func Test_FindBus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	day, _ := time.Parse("02 01 2006", "01 01 2030")
+	seaterChart, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{true, false, false}}})
+	sleeperChart, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{false, false, false}}})
+	searchRoute := func(userRepo *repository.MockUserRepository) {
+		userRepo.EXPECT().FindBus("Kannur", "Bangalore").Return([]*entities.BusScheduleCombo{
+			{Schedule: entities.Schedule{DepartureStation: "Kannur", ArrivalStation: "Bangalore", DepartureTime: "22:00:00", ArrivalTime: "06:00:00"}, Buses: entities.Buses{BusID: 1, BusNumber: "KL01", BusTypeCode: "SE", ScheduleID: 1}},
+			{Schedule: entities.Schedule{DepartureStation: "Kannur", ArrivalStation: "Bangalore", DepartureTime: "08:00:00", ArrivalTime: "12:00:00"}, Buses: entities.Buses{BusID: 2, BusNumber: "KL02", BusTypeCode: "AC_SL", ScheduleID: 1}},
+			{Schedule: entities.Schedule{DepartureStation: "Kannur", ArrivalStation: "Bangalore", DepartureTime: "09:00:00", ArrivalTime: "13:00:00"}, Buses: entities.Buses{BusID: 3, BusNumber: "KL03", BusTypeCode: "SE", ScheduleID: 1}},
+		}, nil)
+		userRepo.EXPECT().FindSegmentBuses("Kannur", "Bangalore").Return(nil, nil)
+		userRepo.EXPECT().GetParentLocation(gomock.Any()).Return(nil, errors.New("station not added to db")).AnyTimes()
+		userRepo.EXPECT().GetScheduleStops(uint(1)).Return(nil, nil).AnyTimes()
+		userRepo.EXPECT().GetChartsForDay([]uint{1, 2, 3}, day).Return([]*entities.BusSchedule{
+			{BusID: 1, Status: "Active", DeckOneSeatLayout: seaterChart},
+			{BusID: 2, Status: "Active", DeckOneSeatLayout: sleeperChart},
+		}, nil)
+		userRepo.EXPECT().GetBaseFare(1).Return(&entities.BaseFare{BaseFare: 500}, nil).AnyTimes()
+		userRepo.EXPECT().GetBusTypeForProvider(gomock.Any(), gomock.Any()).Return(nil, errors.New("record not found")).AnyTimes()
+	}
+	seaterBus := &dto.BusSearchResult{BusID: 1, BusNumber: "KL01", BusTypeCode: "SE", Date: "01 01 2030", BookingDate: "01 01 2030", FromStation: "Kannur", ToStation: "Bangalore", DepartureTime: "22:00:00", ArrivalTime: "06:00:00", DurationMinutes: 480, SeaterSeatsLeft: 2, SeaterFare: 500, Fare: 500, ChartStatus: "Active"}
+	sleeperBus := &dto.BusSearchResult{BusID: 2, BusNumber: "KL02", BusTypeCode: "AC_SL", Date: "01 01 2030", BookingDate: "01 01 2030", FromStation: "Kannur", ToStation: "Bangalore", DepartureTime: "08:00:00", ArrivalTime: "12:00:00", DurationMinutes: 240, SleeperSeatsLeft: 3, SleeperFare: 780, Fare: 780, ChartStatus: "Active"}
+	ac := true
+	tests := []struct {
+		name       string
+		args       *dto.BusRequest
+		beforeTest func(userRepo *repository.MockUserRepository)
+		want       []*dto.BusSearchResult
+		wantErr    bool
+	}{
+		{
+			name:       "sorted by departure",
+			args:       &dto.BusRequest{DepartureStation: "Kannur", ArrivalStation: "Bangalore", Date: "01 01 2030"},
+			beforeTest: searchRoute,
+			want:       []*dto.BusSearchResult{sleeperBus, seaterBus},
+		},
+		{
+			name:       "sorted by price",
+			args:       &dto.BusRequest{DepartureStation: "Kannur", ArrivalStation: "Bangalore", Date: "01 01 2030", SortBy: "price"},
+			beforeTest: searchRoute,
+			want:       []*dto.BusSearchResult{seaterBus, sleeperBus},
+		},
+		{
+			name:       "max price",
+			args:       &dto.BusRequest{DepartureStation: "Kannur", ArrivalStation: "Bangalore", Date: "01 01 2030", Price: 600},
+			beforeTest: searchRoute,
+			want:       []*dto.BusSearchResult{seaterBus},
+		},
+		{
+			name:       "AC only",
+			args:       &dto.BusRequest{DepartureStation: "Kannur", ArrivalStation: "Bangalore", Date: "01 01 2030", AC: &ac},
+			beforeTest: searchRoute,
+			want:       []*dto.BusSearchResult{sleeperBus},
+		},
+		{
+			name:       "max duration",
+			args:       &dto.BusRequest{DepartureStation: "Kannur", ArrivalStation: "Bangalore", Date: "01 01 2030", Duration: 5},
+			beforeTest: searchRoute,
+			want:       []*dto.BusSearchResult{sleeperBus},
+		},
+		{
+			name: "stop on the way reached after midnight",
+			args: &dto.BusRequest{DepartureStation: "Mysore", ArrivalStation: "Bangalore", Date: "02 01 2030"},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBus("Mysore", "Bangalore").Return(nil, errors.New("schedule not Found in DB"))
+				userRepo.EXPECT().FindSegmentBuses("Mysore", "Bangalore").Return([]*entities.BusScheduleCombo{
+					{Schedule: entities.Schedule{ScheduleID: 4, DepartureStation: "Kochi", ArrivalStation: "Bangalore"}, Buses: entities.Buses{BusID: 4, BusNumber: "KL04", BusTypeCode: "SE", ScheduleID: 4}},
+				}, nil)
+				userRepo.EXPECT().GetParentLocation(gomock.Any()).Return(nil, errors.New("station not added to db")).AnyTimes()
+				userRepo.EXPECT().GetScheduleStops(uint(4)).Return([]*entities.ScheduleStop{
+					{Sequence: 0, StationName: "Kochi", DepartureTime: "20:00:00"},
+					{Sequence: 1, StationName: "Kannur", ArrivalTime: "23:15:00", DepartureTime: "23:30:00", SegmentFare: 300},
+					{Sequence: 2, StationName: "Mysore", ArrivalTime: "03:45:00", DepartureTime: "04:00:00", DayOffset: 1, SegmentFare: 200},
+					{Sequence: 3, StationName: "Bangalore", ArrivalTime: "07:00:00", DayOffset: 1, SegmentFare: 150},
+				}, nil)
+				// Seat 01A is sold from Kochi to Mysore only, so it is free again for the last hop.
+				userRepo.EXPECT().GetChartsForDay([]uint{4}, day).Return([]*entities.BusSchedule{
+					{BusID: 4, Status: "Active", DeckOneSeatLayout: seaterChart, SegmentOccupancy: []byte(`{"01A":3}`)},
+				}, nil)
+				userRepo.EXPECT().GetBusTypeForProvider(gomock.Any(), gomock.Any()).Return(nil, errors.New("record not found")).AnyTimes()
+			},
+			want: []*dto.BusSearchResult{{BusID: 4, BusNumber: "KL04", BusTypeCode: "SE", Date: "02 01 2030", BookingDate: "01 01 2030", FromStation: "Mysore", ToStation: "Bangalore", DepartureTime: "04:00:00", ArrivalTime: "07:00:00", DurationMinutes: 180, SeaterSeatsLeft: 3, SeaterFare: 150, Fare: 150, ChartStatus: "Active"}},
+		},
+		{
+			name: "no route",
+			args: &dto.BusRequest{DepartureStation: "Kannur", ArrivalStation: "Bangalore", Date: "01 01 2030"},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBus("Kannur", "Bangalore").Return(nil, errors.New("Oops"))
+				userRepo.EXPECT().FindSegmentBuses("Kannur", "Bangalore").Return(nil, nil)
+				userRepo.EXPECT().GetParentLocation(gomock.Any()).Return(nil, errors.New("station not added to db")).AnyTimes()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := repository.NewMockUserRepository(ctrl)
+
+			w := &UserServiceImpl{
+				repo: mockUserRepo,
+				jwt:  middleware.NewJwtUtil(),
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserRepo)
+			}
+
+			got, err := w.FindBus(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.FindBus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.FindBus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// lockingUserRepo is an in-memory user repository whose transactions are serialised the same way the chart row lock serialises them in postgres.
+type lockingUserRepo struct {
+	interfaces.UserRepository
+	mu       sync.Mutex
+	chart    *entities.BusSchedule
+	user     *entities.User
+	provider *entities.ServiceProvider
+	bookings []*entities.Booking
+	stops    []*entities.ScheduleStop
+	points   []*entities.BoardingPoint
+	items    []*entities.BookingItem
+	discount int
+	expired  bool
+	fare     uint
+	policy   *entities.CancellationPolicy
+	accounts []*entities.LedgerAccount
+	entries  []*entities.LedgerEntry
+}
+
+func (r *lockingUserRepo) WithTx(fn func(tx interfaces.UserRepository) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	chart, user, provider, bookings, items, entries := *r.chart, *r.user, *r.provider, r.bookings, r.items, r.entries
+	accounts := make([]entities.LedgerAccount, len(r.accounts))
+	for i, account := range r.accounts {
+		accounts[i] = *account
+	}
+	if err := fn(r); err != nil {
+		*r.chart, *r.user, *r.provider, r.bookings, r.items, r.entries = chart, user, provider, bookings, items, entries
+		r.accounts = r.accounts[:len(accounts)]
+		for i := range accounts {
+			*r.accounts[i] = accounts[i]
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *lockingUserRepo) Ledger() interfaces.LedgerRepository {
+	return &repoLedger{r}
+}
+
+func (r *lockingUserRepo) Refunds() interfaces.RefundRepository {
+	return &repoRefunds{r: r}
+}
+
+// repoRefunds is the refund repository of a lockingUserRepo, its bookings are paid from the wallet so every refund goes back there.
+type repoRefunds struct {
+	interfaces.RefundRepository
+	r *lockingUserRepo
+}
+
+func (rr *repoRefunds) Ledger() interfaces.LedgerRepository {
+	return &repoLedger{rr.r}
+}
+
+func (rr *repoRefunds) FindBookingPayment(bookingID uint) (*entities.RazorPay, error) {
+	return nil, nil
+}
+
+// repoLedger is the ledger of a lockingUserRepo, the wallets of its one user and one provider hold what they had before the ledger.
+type repoLedger struct {
+	r *lockingUserRepo
+}
+
+func (l *repoLedger) WithTx(fn func(tx interfaces.LedgerRepository) error) error {
+	return fn(l)
+}
+
+func (l *repoLedger) OpenAccount(account *entities.LedgerAccount) (*entities.LedgerAccount, bool, error) {
+	for _, existing := range l.r.accounts {
+		if existing.Code == account.Code {
+			copied := *existing
+			return &copied, false, nil
+		}
+	}
+	account.ID = uint(len(l.r.accounts) + 1)
+	l.r.accounts = append(l.r.accounts, account)
+	copied := *account
+	return &copied, true, nil
+}
+
+func (l *repoLedger) SaveAccountBalance(account *entities.LedgerAccount) error {
+	*l.r.accounts[account.ID-1] = *account
+	return nil
+}
+
+func (l *repoLedger) WalletBalance(ownerType string, ownerID uint) (int, error) {
+	if ownerType == ledger.OwnerUser {
+		return l.r.user.UserWallet, nil
+	}
+	return l.r.provider.ProviderWallet, nil
+}
+
+func (l *repoLedger) FindEntryByKey(key string) (*entities.LedgerEntry, error) {
+	for _, entry := range l.r.entries {
+		if entry.Key == key {
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+func (l *repoLedger) AddEntry(entry *entities.LedgerEntry) error {
+	entry.ID = uint(len(l.r.entries) + 1)
+	l.r.entries = append(l.r.entries, entry)
+	return nil
+}
+
+func (l *repoLedger) FindAccounts() ([]*entities.LedgerAccount, error) {
+	return l.r.accounts, nil
+}
+
+func (l *repoLedger) AccountTotals() (map[uint]int64, error) {
+	totals := map[uint]int64{}
+	for _, entry := range l.r.entries {
+		for _, line := range entry.Lines {
+			totals[line.AccountID] += line.Amount
+		}
+	}
+	return totals, nil
+}
+
+func (l *repoLedger) UnbalancedEntries() ([]uint, error) {
+	return nil, nil
+}
+
+func (l *repoLedger) FindStatement(accountID uint, offset int, limit int) ([]*entities.LedgerEntry, int64, error) {
+	var touching []*entities.LedgerEntry
+	for i := len(l.r.entries) - 1; i >= 0; i-- {
+		entry := *l.r.entries[i]
+		entry.Lines = nil
+		for _, line := range l.r.entries[i].Lines {
+			if line.AccountID == accountID {
+				entry.Lines = append(entry.Lines, line)
+			}
+		}
+		if len(entry.Lines) > 0 {
+			touching = append(touching, &entry)
+		}
+	}
+	total := int64(len(touching))
+	if offset >= len(touching) {
+		return nil, total, nil
+	}
+	touching = touching[offset:]
+	if len(touching) > limit {
+		touching = touching[:limit]
+	}
+	return touching, total, nil
+}
+
+func (l *repoLedger) FindOpenAccounts(ownerType string) ([]*entities.LedgerAccount, error) {
+	var accounts []*entities.LedgerAccount
+	for _, account := range l.r.accounts {
+		if account.OwnerType == ownerType && account.Balance != 0 {
+			copied := *account
+			accounts = append(accounts, &copied)
+		}
+	}
+	return accounts, nil
+}
+
+func (l *repoLedger) BookingTotals(accountID uint) (map[uint]int64, error) {
+	totals := map[uint]int64{}
+	for _, entry := range l.r.entries {
+		for _, line := range entry.Lines {
+			if line.AccountID == accountID {
+				totals[entry.BookingID] += line.Amount
+			}
+		}
+	}
+	return totals, nil
+}
+
+func (l *repoLedger) HeldFor(code string, bookingID uint) (int64, error) {
+	for _, account := range l.r.accounts {
+		if account.Code == code {
+			totals, _ := l.BookingTotals(account.ID)
+			return totals[bookingID], nil
+		}
+	}
+	return 0, nil
+}
+
+func (l *repoLedger) BalanceThrough(accountID uint, entryID uint) (int64, error) {
+	var balance int64
+	for _, entry := range l.r.entries {
+		for _, line := range entry.Lines {
+			if entry.ID <= entryID && line.AccountID == accountID {
+				balance += line.Amount
+			}
+		}
+	}
+	return balance, nil
+}
+
+// walletOf returns what the wallet of the user holds in paisa.
+func walletOf(t *testing.T, repo *lockingUserRepo) int64 {
+	t.Helper()
+	balance, err := ledger.Balance(repo.Ledger(), ledger.UserAccount(repo.user.ID))
+	if err != nil {
+		t.Fatalf("ledger.Balance() error = %v", err)
+	}
+	return balance
+}
+
+// escrowed returns what the escrow accounts hold in paisa.
+func escrowed(repo *lockingUserRepo) int64 {
+	var held int64
+	for _, account := range repo.accounts {
+		if account.OwnerType == ledger.OwnerEscrow {
+			held += account.Balance
+		}
+	}
+	return held
+}
+
+// balancedBooks checks that every wallet change of the test went through the ledger.
+func balancedBooks(t *testing.T, repo *lockingUserRepo) {
+	t.Helper()
+	report, err := ledger.Reconcile(repo.Ledger())
+	if err != nil {
+		t.Fatalf("ledger.Reconcile() error = %v", err)
+	}
+	if !report.Balanced {
+		t.Errorf("ledger.Reconcile() = %+v, want balanced books", report)
+	}
+}
+
+func (r *lockingUserRepo) FindUserByEmail(email string) (*entities.User, error) {
+	return &entities.User{ID: 1, Email: email}, nil
+}
+
+func (r *lockingUserRepo) ViewAllPassengers(email string) ([]*entities.PassengerInfo, error) {
+	return []*entities.PassengerInfo{{PassengerID: 1}, {PassengerID: 2}, {PassengerID: 3}}, nil
+}
+
+func (r *lockingUserRepo) GetBusInfo(id int) (*entities.Buses, error) {
+	return &entities.Buses{BusID: uint(id), BusTypeCode: "SE", ProviderID: 1, ScheduleID: 1}, nil
+}
+
+func (r *lockingUserRepo) GetBusTypeForProvider(code string, providerID uint) (*entities.BusType, error) {
+	return nil, errors.New("record not found")
+}
+
+func (r *lockingUserRepo) GetBaseFare(scheduleID int) (*entities.BaseFare, error) {
+	if r.fare > 0 {
+		return &entities.BaseFare{BaseFare: r.fare}, nil
+	}
+	return &entities.BaseFare{BaseFare: 500}, nil
+}
+
+func (r *lockingUserRepo) GetSchedule(id int) (*entities.Schedule, error) {
+	return &entities.Schedule{ScheduleID: uint(id), DepartureStation: "Kannur", ArrivalStation: "Bangalore", DepartureTime: "22:00:00", ArrivalTime: "06:00:00"}, nil
+}
+
+func (r *lockingUserRepo) GetScheduleStops(scheduleID uint) ([]*entities.ScheduleStop, error) {
+	return r.stops, nil
+}
+
+func (r *lockingUserRepo) GetUserInfo(userID int) (*entities.User, error) {
+	user := *r.user
+	return &user, nil
+}
+
+func (r *lockingUserRepo) FindBookingByID(bookID int) (*entities.Booking, error) {
+	for _, booking := range r.bookings {
+		if booking.BookingID == uint(bookID) {
+			copied := *booking
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *lockingUserRepo) UpdateBooking(booking *entities.Booking) (*entities.Booking, error) {
+	*r.bookings[booking.BookingID-1] = *booking
+	return booking, nil
+}
+
+func (r *lockingUserRepo) CancelBooking(booking *entities.Booking) (*entities.Booking, error) {
+	return r.UpdateBooking(booking)
+}
+
+func (r *lockingUserRepo) FindWaitlisted(busID uint, day string) ([]*entities.Booking, error) {
+	var waiting []*entities.Booking
+	for _, booking := range r.bookings {
+		if booking.BusID == busID && booking.BookingDate == day && booking.Status == "Waitlisted" {
+			copied := *booking
+			waiting = append(waiting, &copied)
+		}
+	}
+	return waiting, nil
+}
+
+func (r *lockingUserRepo) GetBoardingPoint(id int) (*entities.BoardingPoint, error) {
+	for _, point := range r.points {
+		if point.ID == uint(id) {
+			return point, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *lockingUserRepo) FindCouponByID(id int) (*entities.Coupons, error) {
+	return &entities.Coupons{CouponID: uint(id), IsActive: !r.expired, Discount: r.discount}, nil
+}
+
+func (r *lockingUserRepo) GetChartForUpdate(busid int, day time.Time) (*entities.BusSchedule, error) {
+	chart := *r.chart
+	return &chart, nil
+}
+
+func (r *lockingUserRepo) UpdateChart(chart *entities.BusSchedule) (*entities.BusSchedule, error) {
+	*r.chart = *chart
+	return chart, nil
+}
+
+func (r *lockingUserRepo) GetUserInfoForUpdate(userID int) (*entities.User, error) {
+	user := *r.user
+	return &user, nil
+}
+
+func (r *lockingUserRepo) UpdateUser(user *entities.User) (*entities.User, error) {
+	*r.user = *user
+	return user, nil
+}
+
+func (r *lockingUserRepo) GetProviderInfoForUpdate(providerID int) (*entities.ServiceProvider, error) {
+	provider := *r.provider
+	return &provider, nil
+}
+
+func (r *lockingUserRepo) UpdateProvider(provider *entities.ServiceProvider) (*entities.ServiceProvider, error) {
+	*r.provider = *provider
+	return provider, nil
+}
+
+func (r *lockingUserRepo) MakeBooking(booking *entities.Booking) (*entities.Booking, error) {
+	booking.BookingID = uint(len(r.bookings) + 1)
+	r.bookings = append(r.bookings, booking)
+	return booking, nil
+}
+
+func (r *lockingUserRepo) GetCancellationPolicy(providerID uint, busID uint) (*entities.CancellationPolicy, error) {
+	if r.policy == nil {
+		return nil, errors.New("record not found")
+	}
+	return r.policy, nil
+}
+
+func (r *lockingUserRepo) AddBookingItems(items []*entities.BookingItem) error {
+	for _, item := range items {
+		item.ID = uint(len(r.items) + 1)
+		copied := *item
+		r.items = append(r.items, &copied)
+	}
+	return nil
+}
+
+func (r *lockingUserRepo) FindBookingItems(bookingIDs []uint) ([]*entities.BookingItem, error) {
+	var items []*entities.BookingItem
+	for _, item := range r.items {
+		for _, id := range bookingIDs {
+			if item.BookingID == id {
+				copied := *item
+				items = append(items, &copied)
+			}
+		}
+	}
+	return items, nil
+}
+
+func (r *lockingUserRepo) UpdateBookingItem(item *entities.BookingItem) error {
+	copied := *item
+	r.items[item.ID-1] = &copied
+	return nil
+}
+
+// noHold is a seat hold that keeps nothing, for tests that do not exercise the hold window.
+type noHold struct{}
+
+func (noHold) Hold(bookingID uint, ttl time.Duration) error { return nil }
+func (noHold) IsHeld(bookingID uint) (bool, error)          { return false, nil }
+func (noHold) Release(bookingID uint) error                 { return nil }
+
+func Test_BookSeat_Concurrent(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+
+	deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{false, false, false}}})
+	deckTwo, _ := json.Marshal(map[string][][]bool{"deckTwoLayout": {}})
+	repo := &lockingUserRepo{
+		chart:    &entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne, DeckTwoSeatLayout: deckTwo},
+		user:     &entities.User{ID: 1, UserWallet: 100000},
+		provider: &entities.ServiceProvider{ProviderID: 1},
+	}
+	w := &UserServiceImpl{
+		repo: repo,
+		jwt:  middleware.NewJwtUtil(),
+	}
+
+	const attempts = 10
+	var wg sync.WaitGroup
+	var succeeded int32
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := w.BookSeat(&dto.BookingRequest{
+				UsedCouponID:         1,
+				BusID:                1,
+				PassengerID:          pq.Int64Array{1},
+				SeatsReserved:        []string{"01A"},
+				BookingDate:          "01 01 2024",
+				PreferredPaymentType: "Wallet",
+			}, "abc@gmail.com")
+			if err == nil {
+				atomic.AddInt32(&succeeded, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Fatalf("services.BookSeat() succeeded %d times for the same seat, want 1", succeeded)
+	}
+	if len(repo.bookings) != 1 {
+		t.Errorf("services.BookSeat() stored %d bookings, want 1", len(repo.bookings))
+	}
+	fare := ledger.Paisa(repo.bookings[0].FarePostDiscount)
+	if walletOf(t, repo) != 10000000-fare || escrowed(repo) != fare {
+		t.Errorf("services.BookSeat() wallets = user %d escrow %d, want %d and the fare held", walletOf(t, repo), escrowed(repo), 10000000-fare)
+	}
+	balancedBooks(t, repo)
+}
+
+func Test_BookItinerary_AllOrNothing(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+
+	deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{false, false, false}}})
+	repo := &lockingUserRepo{
+		chart:    &entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne},
+		user:     &entities.User{ID: 1, UserWallet: 100000},
+		provider: &entities.ServiceProvider{ProviderID: 1},
+	}
+	w := &UserServiceImpl{
+		repo: repo,
+		jwt:  middleware.NewJwtUtil(),
+	}
+	leg := func(busID uint, seat string) *dto.BookingRequest {
+		return &dto.BookingRequest{UsedCouponID: 1, BusID: busID, PassengerID: pq.Int64Array{1}, SeatsReserved: []string{seat}, BookingDate: "01 01 2024"}
+	}
+
+	_, err := w.BookItinerary(&dto.ItineraryBookingRequest{Legs: []*dto.BookingRequest{leg(1, "01A"), leg(2, "09Z")}, PreferredPaymentType: "Wallet"}, "abc@gmail.com")
+	if err == nil {
+		t.Fatalf("services.BookItinerary() booked an itinerary with an invalid seat")
+	}
+	if len(repo.bookings) != 0 || walletOf(t, repo) != 10000000 || !bytes.Equal(repo.chart.DeckOneSeatLayout, deckOne) {
+		t.Fatalf("services.BookItinerary() left a partial booking behind")
+	}
+
+	booked, err := w.BookItinerary(&dto.ItineraryBookingRequest{Legs: []*dto.BookingRequest{leg(2, "01B"), leg(1, "01A")}, PreferredPaymentType: "Wallet"}, "abc@gmail.com")
+	if err != nil {
+		t.Fatalf("services.BookItinerary() error = %v", err)
+	}
+	if len(booked) != 2 || booked[0].BusID != 2 || booked[0].ItineraryRef == "" || booked[0].ItineraryRef != booked[1].ItineraryRef {
+		t.Errorf("services.BookItinerary() = %+v, want both legs under one itinerary", booked)
+	}
+	if booked[0].Status != "Success" || booked[1].Status != "Success" || walletOf(t, repo) != 9900000 || escrowed(repo) != 100000 {
+		t.Errorf("services.BookItinerary() wallet = %d, escrow = %d, want 9900000 and 100000 paisa", walletOf(t, repo), escrowed(repo))
+	}
+}
+
+func Test_BookSeat_Segments(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+
+	deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{false, false, false}}})
+	repo := &lockingUserRepo{
+		chart:    &entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne},
+		user:     &entities.User{ID: 1, UserWallet: 100000},
+		provider: &entities.ServiceProvider{ProviderID: 1},
+		stops: []*entities.ScheduleStop{
+			{Sequence: 0, StationName: "Kochi", DepartureTime: "20:00:00"},
+			{Sequence: 1, StationName: "Coimbatore", ArrivalTime: "00:30:00", DepartureTime: "00:45:00", DayOffset: 1, SegmentFare: 300},
+			{Sequence: 2, StationName: "Bangalore", ArrivalTime: "06:00:00", DayOffset: 1, SegmentFare: 400},
+		},
+	}
+	w := &UserServiceImpl{
+		repo: repo,
+		jwt:  middleware.NewJwtUtil(),
+	}
+	book := func(from string, to string) error {
+		_, err := w.BookSeat(&dto.BookingRequest{
+			UsedCouponID:         1,
+			BusID:                1,
+			PassengerID:          pq.Int64Array{1},
+			SeatsReserved:        []string{"01A"},
+			BookingDate:          "01 01 2024",
+			FromStation:          from,
+			ToStation:            to,
+			PreferredPaymentType: "Wallet",
+		}, "abc@gmail.com")
+		return err
+	}
+
+	if err := book("Kochi", "Coimbatore"); err != nil {
+		t.Fatalf("services.BookSeat() Kochi to Coimbatore error = %v", err)
+	}
+	if err := book("Kochi", "Bangalore"); err == nil {
+		t.Errorf("services.BookSeat() sold seat 01A over a hop it is already sold on")
+	}
+	if err := book("Coimbatore", "Bangalore"); err != nil {
+		t.Fatalf("services.BookSeat() Coimbatore to Bangalore error = %v", err)
+	}
+	if len(repo.bookings) != 2 || repo.bookings[0].ActualFare != 300 || repo.bookings[1].ActualFare != 400 {
+		t.Errorf("services.BookSeat() bookings = %+v, want the segment fares 300 and 400", repo.bookings)
+	}
+	if repo.bookings[1].FromStation != "Coimbatore" || repo.bookings[1].ToStation != "Bangalore" {
+		t.Errorf("services.BookSeat() stored the segment %s to %s", repo.bookings[1].FromStation, repo.bookings[1].ToStation)
+	}
+}
+
+func Test_BookSeat_BoardingPoints(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+
+	deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{false, false, false}}})
+	point := func(id uint, stop string, pointType string, offset int) *entities.BoardingPoint {
+		p := &entities.BoardingPoint{BusID: 1, StopName: stop, Name: stop + " Bypass", Address: "NH 544", PointType: pointType, TimeOffset: offset}
+		p.ID = id
+		return p
+	}
+	repo := &lockingUserRepo{
+		chart:    &entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne},
+		user:     &entities.User{ID: 1, UserWallet: 100000},
+		provider: &entities.ServiceProvider{ProviderID: 1},
+		stops: []*entities.ScheduleStop{
+			{Sequence: 0, StationName: "Kochi", DepartureTime: "20:00:00"},
+			{Sequence: 1, StationName: "Coimbatore", ArrivalTime: "00:30:00", DepartureTime: "00:45:00", DayOffset: 1, SegmentFare: 300},
+			{Sequence: 2, StationName: "Bangalore", ArrivalTime: "06:00:00", DayOffset: 1, SegmentFare: 400},
+		},
+		points: []*entities.BoardingPoint{
+			point(1, "Kochi", BoardingPointType, -15),
+			point(2, "Coimbatore", BoardingPointType, 10),
+			point(3, "Bangalore", DroppingPointType, 30),
+		},
+	}
+	w := &UserServiceImpl{
+		repo: repo,
+		jwt:  middleware.NewJwtUtil(),
+	}
+	book := func(seat string, from string, boarding uint, dropping uint) error {
+		_, err := w.BookSeat(&dto.BookingRequest{
+			UsedCouponID:         1,
+			BusID:                1,
+			PassengerID:          pq.Int64Array{1},
+			SeatsReserved:        []string{seat},
+			BookingDate:          "01 01 2024",
+			FromStation:          from,
+			BoardingPointID:      boarding,
+			DroppingPointID:      dropping,
+			PreferredPaymentType: "Wallet",
+		}, "abc@gmail.com")
+		return err
+	}
+
+	if err := book("01A", "Coimbatore", 1, 3); err == nil {
+		t.Errorf("services.BookSeat() accepted a boarding point at another stop")
+	}
+	if err := book("01A", "Coimbatore", 3, 0); err == nil {
+		t.Errorf("services.BookSeat() accepted a dropping point for boarding")
+	}
+	if err := book("01A", "Coimbatore", 2, 3); err != nil {
+		t.Fatalf("services.BookSeat() error = %v", err)
+	}
+	booking := repo.bookings[0]
+	if booking.BoardingPoint != "Coimbatore Bypass, NH 544" || booking.BoardingTime != "00:55:00" || booking.DroppingTime != "06:30:00" {
+		t.Errorf("services.BookSeat() points = %q at %s, drop at %s", booking.BoardingPoint, booking.BoardingTime, booking.DroppingTime)
+	}
+	if err := book("01B", "", 1, 0); err != nil || repo.bookings[1].BoardingTime != "19:45:00" {
+		t.Errorf("services.BookSeat() boarding at the origin error = %v", err)
+	}
+}
+
+func Test_Waitlist(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+	day := time.Now().AddDate(0, 0, 10).Format("02 01 2006")
+
+	deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{false, false}}})
+	repo := &lockingUserRepo{
+		chart:    &entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne},
+		user:     &entities.User{ID: 1, UserWallet: 100000},
+		provider: &entities.ServiceProvider{ProviderID: 1},
+	}
+	w := &UserServiceImpl{
+		repo: repo,
+		jwt:  middleware.NewJwtUtil(),
+		hold: noHold{},
+	}
+	book := func(seats []string, waitlist bool) (*entities.Booking, error) {
+		return w.BookSeat(&dto.BookingRequest{
+			UsedCouponID:         1,
+			BusID:                1,
+			PassengerID:          pq.Int64Array{1},
+			SeatsReserved:        seats,
+			BookingDate:          day,
+			PreferredPaymentType: "Wallet",
+			Waitlist:             waitlist,
+		}, "abc@gmail.com")
+	}
+
+	if _, err := book(nil, true); err == nil {
+		t.Fatalf("services.BookSeat() waitlisted while seats are free")
+	}
+	first, _ := book([]string{"01A"}, false)
+	if _, err := book([]string{"01B"}, false); err != nil {
+		t.Fatalf("services.BookSeat() error = %v", err)
+	}
+	if _, err := book([]string{"01A"}, false); err == nil || err.Error() != "not enough seats left, join the waitlist instead" {
+		t.Errorf("services.BookSeat() on a sold out chart error = %v", err)
+	}
+	waiting, err := book(nil, true)
+	if err != nil || waiting.Status != "Waitlisted" || waiting.SeatClass != "seater" {
+		t.Fatalf("services.BookSeat() waitlist = %+v, error = %v", waiting, err)
+	}
+	second, _ := book(nil, true)
+	setWaitlistPositions(repo, repo.bookings)
+	if repo.bookings[waiting.BookingID-1].WaitlistPosition != 1 || repo.bookings[second.BookingID-1].WaitlistPosition != 2 {
+		t.Errorf("waitlist positions = %d and %d, want 1 and 2", repo.bookings[waiting.BookingID-1].WaitlistPosition, repo.bookings[second.BookingID-1].WaitlistPosition)
+	}
+
+	wallet := walletOf(t, repo)
+	if _, err := w.CancelBooking(int(first.BookingID), "", "abc@gmail.com"); err != nil {
+		t.Fatalf("services.CancelBooking() error = %v", err)
+	}
+	promoted := repo.bookings[waiting.BookingID-1]
+	if promoted.Status != "Success" || len(promoted.SeatReserved) != 1 || promoted.SeatReserved[0] != "01A" {
+		t.Errorf("services.CancelBooking() left the first waitlisted booking as %+v", promoted)
+	}
+	refund := ledger.Paisa(first.FarePostDiscount * 0.9)
+	if walletOf(t, repo) != wallet+refund-ledger.Paisa(promoted.FarePostDiscount) {
+		t.Errorf("services.CancelBooking() wallet = %d, want the refund less the promoted fare", walletOf(t, repo))
+	}
+	if repo.bookings[second.BookingID-1].Status != "Waitlisted" {
+		t.Errorf("services.CancelBooking() promoted more bookings than seats freed")
+	}
+	balancedBooks(t, repo)
+}
+
+func Test_CancelSeats(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+	day := time.Now().AddDate(0, 0, 10).Format("02 01 2006")
+
+	deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{false, false, false}}})
+	repo := &lockingUserRepo{
+		chart:    &entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne},
+		user:     &entities.User{ID: 1, Email: "abc@gmail.com", UserWallet: 100000},
+		provider: &entities.ServiceProvider{ProviderID: 1},
+		discount: 20,
+	}
+	w := &UserServiceImpl{
+		repo: repo,
+		jwt:  middleware.NewJwtUtil(),
+		hold: noHold{},
+	}
+	booking, err := w.BookSeat(&dto.BookingRequest{
+		UsedCouponID:         1,
+		BusID:                1,
+		PassengerID:          pq.Int64Array{1, 2},
+		SeatsReserved:        []string{"01A", "01B"},
+		BookingDate:          day,
+		PreferredPaymentType: "Wallet",
+	}, "abc@gmail.com")
+	if err != nil || booking.Status != "Success" {
+		t.Fatalf("services.BookSeat() = %+v, error = %v", booking, err)
+	}
+
+	wallet, paid := walletOf(t, repo), booking.FarePostDiscount
+	if _, err := w.CancelSeats(int(booking.BookingID), &dto.SeatCancelRequest{Seats: []string{"01C"}}, "abc@gmail.com"); err == nil {
+		t.Errorf("services.CancelSeats() cancelled a seat that is not on the booking")
+	}
+	cancelled, err := w.CancelSeats(int(booking.BookingID), &dto.SeatCancelRequest{PassengerIDs: []uint{2}}, "abc@gmail.com")
+	if err != nil {
+		t.Fatalf("services.CancelSeats() error = %v", err)
+	}
+	if cancelled.Status != "Success" || cancelled.FarePostDiscount != paid/2 {
+		t.Errorf("services.CancelSeats() left the booking as %+v", cancelled)
+	}
+	if want := wallet + ledger.Paisa(paid/2*0.9); walletOf(t, repo) != want {
+		t.Errorf("services.CancelSeats() wallet = %d, want %d", walletOf(t, repo), want)
+	}
+	if len(repo.items) != 2 || repo.items[0].Status != ItemBooked || repo.items[1].Status != ItemCancelled || repo.items[1].SeatID != "01B" || repo.items[1].CancelledAt == nil {
+		t.Errorf("services.CancelSeats() items = %+v and %+v", repo.items[0], repo.items[1])
+	}
+	if len(repo.bookings[0].SeatReserved) != 2 {
+		t.Errorf("services.CancelSeats() rewrote the seats of the booking to %v", repo.bookings[0].SeatReserved)
+	}
+
+	if _, err := w.BookSeat(&dto.BookingRequest{
+		UsedCouponID:         1,
+		BusID:                1,
+		PassengerID:          pq.Int64Array{3},
+		SeatsReserved:        []string{"01B"},
+		BookingDate:          day,
+		PreferredPaymentType: "Wallet",
+	}, "abc@gmail.com"); err != nil {
+		t.Fatalf("services.BookSeat() on the freed seat error = %v", err)
+	}
+	if _, err := w.CancelBooking(int(booking.BookingID), "", "abc@gmail.com"); err != nil {
+		t.Fatalf("services.CancelBooking() error = %v", err)
+	}
+	if repo.bookings[0].Status != "Cancelled by User" || repo.items[0].Status != ItemCancelled {
+		t.Errorf("services.CancelBooking() left the booking as %+v", repo.bookings[0])
+	}
+	if _, err := w.BookSeat(&dto.BookingRequest{
+		UsedCouponID:         1,
+		BusID:                1,
+		PassengerID:          pq.Int64Array{1},
+		SeatsReserved:        []string{"01A"},
+		BookingDate:          day,
+		PreferredPaymentType: "Wallet",
+	}, "abc@gmail.com"); err != nil {
+		t.Errorf("services.BookSeat() after the cancel error = %v", err)
+	}
+	balancedBooks(t, repo)
+}
+
+// expectRoute expects the lookups of bus 1 of provider 1, leaving Kochi at 22:00 under a policy refunding all of the fare up to 72h before and 75% up to 24h before.
+func expectRoute(userRepo *repository.MockUserRepository) {
+	tiers, _ := json.Marshal([]map[string]int{
+		{"hours_before": 72, "refund_percent": 100},
+		{"hours_before": 24, "refund_percent": 75},
+	})
+	userRepo.EXPECT().GetBusInfo(1).Return(&entities.Buses{BusID: 1, ProviderID: 1, ScheduleID: 1, BusTypeCode: "NAC"}, nil).AnyTimes()
+	userRepo.EXPECT().GetScheduleStops(uint(1)).Return([]*entities.ScheduleStop{
+		{ScheduleID: 1, Sequence: 0, StationName: "Kochi", DepartureTime: "22:00:00"},
+		{ScheduleID: 1, Sequence: 1, StationName: "Bangalore", ArrivalTime: "06:00:00", DayOffset: 1, SegmentFare: 500},
+	}, nil).AnyTimes()
+	userRepo.EXPECT().GetCancellationPolicy(uint(1), uint(1)).Return(&entities.CancellationPolicy{ProviderID: 1, Tiers: tiers}, nil).AnyTimes()
+	userRepo.EXPECT().GetBusTypeForProvider("NAC", uint(1)).Return(nil, errors.New("record not found")).AnyTimes()
+}
+
+func Test_CancelBooking(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	soon := time.Now().AddDate(0, 0, 2).Format("02 01 2006")
+	left := time.Now().AddDate(0, 0, -1).Format("02 01 2006")
+	booked := func(day string, status string) func(int) (*entities.Booking, error) {
+		return func(int) (*entities.Booking, error) {
+			return &entities.Booking{BookingID: 1, UserID: 1, BusID: 1, PNR: "GB7K2M9Q", BookingDate: day, SeatReserved: []string{"01A"}, ActualFare: 500, FarePostDiscount: 500, Status: status}, nil
+		}
+	}
+	// cancelled expects the booked seat of the trip on the day to be freed and the booking cancelled
+	cancelled := func(userRepo *repository.MockUserRepository, day string) {
+		deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{true, false}}})
+		parsed, _ := time.Parse("02 01 2006", day)
+		userRepo.EXPECT().GetChartForUpdate(1, parsed).Return(&entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne}, nil)
+		userRepo.EXPECT().FindBookingItems([]uint{1}).Return([]*entities.BookingItem{{ID: 1, BookingID: 1, PassengerID: 1, SeatID: "01A", Fare: 500, FarePostDiscount: 500, Status: ItemBooked}}, nil)
+		userRepo.EXPECT().UpdateChart(gomock.Any()).DoAndReturn(func(chart *entities.BusSchedule) (*entities.BusSchedule, error) {
+			return chart, nil
+		})
+		userRepo.EXPECT().UpdateBookingItem(gomock.Any()).Return(nil)
+		userRepo.EXPECT().CancelBooking(gomock.Any()).DoAndReturn(func(booking *entities.Booking) (*entities.Booking, error) {
+			return booking, nil
+		})
+		userRepo.EXPECT().FindWaitlisted(uint(1), day).Return(nil, nil)
+	}
+	tests := []struct {
+		name       string
+		refundTo   string
+		email      string
+		beforeTest func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository)
+		want       *entities.Booking
+		wantErr    bool
+	}{
+		{
+			name:     "success refund two days ahead goes to the wallet",
+			refundTo: "",
+			email:    "abc@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1, Email: "abc@gmail.com"}, nil)
+				userRepo.EXPECT().FindBookingByID(1).DoAndReturn(booked(soon, "Success")).Times(2)
+				cancelled(userRepo, soon)
+				ledgerRepo.EXPECT().HeldFor(gomock.Any(), uint(1)).Return(int64(50000), nil)
+				userRepo.EXPECT().GetUserInfo(1).Return(&entities.User{ID: 1, PhoneNumber: "1234567890"}, nil)
+				expectPost(ledgerRepo, "booking:1:cancel:1", 37500)
+			},
+			want:    &entities.Booking{Status: "Cancelled by User", RefundAmount: 375, CancellationCharge: 125, RefundPercent: 75, RefundRule: "75% refund from 24h before departure"},
+			wantErr: false,
+		},
+		{
+			name:     "success nothing refunded after departure",
+			refundTo: RefundToWallet,
+			email:    "abc@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1, Email: "abc@gmail.com"}, nil)
+				userRepo.EXPECT().FindBookingByID(1).DoAndReturn(booked(left, "Success")).Times(2)
+				cancelled(userRepo, left)
+			},
+			want:    &entities.Booking{Status: "Cancelled by User", RefundAmount: 0, CancellationCharge: 500, RefundPercent: 0, RefundRule: "no refund after departure"},
+			wantErr: false,
+		},
+		{
+			name:     "unknown refund destination",
+			refundTo: "bank",
+			email:    "abc@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1, Email: "abc@gmail.com"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "booking of another user",
+			refundTo: RefundToSource,
+			email:    "xyz@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("xyz@gmail.com").Return(&entities.User{ID: 2, Email: "xyz@gmail.com"}, nil)
+				userRepo.EXPECT().FindBookingByID(1).DoAndReturn(booked(soon, "Success"))
+			},
+			wantErr: true,
+		},
+		{
+			name:     "no user",
+			refundTo: "",
+			email:    "abc@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+		{
+			name:     "booking cancelled before",
+			refundTo: "",
+			email:    "abc@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1, Email: "abc@gmail.com"}, nil)
+				userRepo.EXPECT().FindBookingByID(1).DoAndReturn(booked(soon, "Cancelled by User")).Times(2)
+				parsed, _ := time.Parse("02 01 2006", soon)
+				userRepo.EXPECT().GetChartForUpdate(1, parsed).Return(&entities.BusSchedule{BusID: 1}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "no booking",
+			refundTo: "",
+			email:    "abc@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1, Email: "abc@gmail.com"}, nil)
+				userRepo.EXPECT().FindBookingByID(1).Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			mockLedger := repository.NewMockLedgerRepository(ctrl)
+			mockRefunds := repository.NewMockRefundRepository(ctrl)
+			expectTx(mockRepo)
+			expectLedger(mockLedger)
+			expectRoute(mockRepo)
+			mockRepo.EXPECT().Ledger().Return(mockLedger).AnyTimes()
+			mockRepo.EXPECT().Refunds().Return(mockRefunds).AnyTimes()
+			mockRefunds.EXPECT().Ledger().Return(mockLedger).AnyTimes()
+			tt.beforeTest(mockRepo, mockLedger)
+			u := &UserServiceImpl{repo: mockRepo, hold: noHold{}}
+			got, err := u.CancelBooking(1, tt.refundTo, tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.CancelBooking() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Status != tt.want.Status || got.RefundAmount != tt.want.RefundAmount || got.CancellationCharge != tt.want.CancellationCharge || got.RefundPercent != tt.want.RefundPercent || got.RefundRule != tt.want.RefundRule {
+				t.Errorf("services.CancelBooking() = %s refunding %v, charging %v at %d%% (%q), want %+v", got.Status, got.RefundAmount, got.CancellationCharge, got.RefundPercent, got.RefundRule, tt.want)
+			}
+		})
+	}
+}
+
+// heldSeats is a seat hold whose live holds are the bookings listed.
+type heldSeats map[uint]bool
+
+func (h heldSeats) Hold(bookingID uint, ttl time.Duration) error { h[bookingID] = true; return nil }
+func (h heldSeats) IsHeld(bookingID uint) (bool, error)          { return h[bookingID], nil }
+func (h heldSeats) Release(bookingID uint) error                 { delete(h, bookingID); return nil }
+
+func Test_ReleaseExpiredHolds(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	day := time.Now().AddDate(0, 0, 2).Format("02 01 2006")
+	parsed, _ := time.Parse("02 01 2006", day)
+	past := time.Now().Add(-time.Minute)
+	awaiting := func() *entities.Booking {
+		return &entities.Booking{BookingID: 1, UserID: 1, BusID: 1, BookingDate: day, FromStation: "Kochi", ToStation: "Bangalore", PassengerID: pq.Int64Array{1}, SeatReserved: []string{"01A"}, SeatClass: "seater", ActualFare: 500, FarePostDiscount: 500, Status: "Awaiting Payment", HoldExpiresAt: &past}
+	}
+	// swept expects the expired booking to give its seat back under the chart lock
+	swept := func(userRepo *repository.MockUserRepository, status string) {
+		deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{true}}})
+		userRepo.EXPECT().GetChartForUpdate(1, parsed).Return(&entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne}, nil)
+		booking := awaiting()
+		booking.Status = status
+		userRepo.EXPECT().FindBookingByID(1).Return(booking, nil)
+	}
+	tests := []struct {
+		name       string
+		held       heldSeats
+		beforeTest func(userRepo *repository.MockUserRepository)
+		want       int
+		wantHeld   uint
+		wantErr    bool
+	}{
+		{
+			name: "success hold expired",
+			held: heldSeats{},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindExpiredHolds(gomock.Any()).Return([]*entities.Booking{awaiting()}, nil)
+				swept(userRepo, "Awaiting Payment")
+				userRepo.EXPECT().FindBookingItems([]uint{1}).Return([]*entities.BookingItem{{ID: 1, BookingID: 1, PassengerID: 1, SeatID: "01A", Fare: 500, FarePostDiscount: 500, Status: ItemBooked}}, nil)
+				userRepo.EXPECT().UpdateChart(gomock.Any()).DoAndReturn(func(chart *entities.BusSchedule) (*entities.BusSchedule, error) {
+					if strings.Contains(string(chart.DeckOneSeatLayout), "true") {
+						t.Errorf("services.ReleaseExpiredHolds() kept the seat in %s", chart.DeckOneSeatLayout)
+					}
+					return chart, nil
+				})
+				userRepo.EXPECT().UpdateBooking(gomock.Any()).DoAndReturn(func(booking *entities.Booking) (*entities.Booking, error) {
+					if booking.Status != "Expired" {
+						t.Errorf("services.ReleaseExpiredHolds() stored the booking as %s", booking.Status)
+					}
+					return booking, nil
+				})
+				userRepo.EXPECT().FindWaitlisted(uint(1), day).Return(nil, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "hold still live",
+			held: heldSeats{1: true},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindExpiredHolds(gomock.Any()).Return([]*entities.Booking{awaiting()}, nil)
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "payment arrived during the sweep",
+			held: heldSeats{},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindExpiredHolds(gomock.Any()).Return([]*entities.Booking{awaiting()}, nil)
+				swept(userRepo, "Success")
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "success seat goes to the waitlist",
+			held: heldSeats{},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindExpiredHolds(gomock.Any()).Return([]*entities.Booking{awaiting()}, nil)
+				swept(userRepo, "Awaiting Payment")
+				userRepo.EXPECT().FindBookingItems([]uint{1}).Return([]*entities.BookingItem{{ID: 1, BookingID: 1, PassengerID: 1, SeatID: "01A", Fare: 500, FarePostDiscount: 500, Status: ItemBooked}}, nil)
+				userRepo.EXPECT().UpdateChart(gomock.Any()).DoAndReturn(func(chart *entities.BusSchedule) (*entities.BusSchedule, error) {
+					return chart, nil
+				}).Times(2)
+				userRepo.EXPECT().UpdateBooking(gomock.Any()).DoAndReturn(func(booking *entities.Booking) (*entities.Booking, error) {
+					return booking, nil
+				}).Times(2)
+				userRepo.EXPECT().FindWaitlisted(uint(1), day).Return([]*entities.Booking{
+					{BookingID: 2, UserID: 2, BusID: 1, BookingDate: day, FromStation: "Kochi", ToStation: "Bangalore", PassengerID: pq.Int64Array{2}, SeatReserved: []string{}, SeatClass: "seater", ActualFare: 500, FarePostDiscount: 500, Status: "Waitlisted"},
+				}, nil)
+				userRepo.EXPECT().FindBookingItems([]uint{2}).Return(nil, nil)
+				userRepo.EXPECT().AddBookingItems(gomock.Any()).DoAndReturn(func(items []*entities.BookingItem) error {
+					if len(items) != 1 || items[0].BookingID != 2 || items[0].SeatID != "01A" {
+						t.Errorf("services.ReleaseExpiredHolds() gave the waitlist the items %+v", items)
+					}
+					return nil
+				})
+				userRepo.EXPECT().GetUserInfo(2).Return(&entities.User{ID: 2, PhoneNumber: "1234567890"}, nil)
+			},
+			want:     1,
+			wantHeld: 2,
+			wantErr:  false,
+		},
+		{
+			name: "chart could not be locked",
+			held: heldSeats{},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindExpiredHolds(gomock.Any()).Return([]*entities.Booking{awaiting()}, nil)
+				userRepo.EXPECT().GetChartForUpdate(1, parsed).Return(nil, errors.New("Oops"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "one booking failed the rest released",
+			held: heldSeats{},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				broken := awaiting()
+				broken.BookingID, broken.BookingDate = 3, "not a date"
+				userRepo.EXPECT().FindExpiredHolds(gomock.Any()).Return([]*entities.Booking{broken, awaiting()}, nil)
+				swept(userRepo, "Awaiting Payment")
+				userRepo.EXPECT().FindBookingItems([]uint{1}).Return([]*entities.BookingItem{{ID: 1, BookingID: 1, PassengerID: 1, SeatID: "01A", Fare: 500, FarePostDiscount: 500, Status: ItemBooked}}, nil)
+				userRepo.EXPECT().UpdateChart(gomock.Any()).DoAndReturn(func(chart *entities.BusSchedule) (*entities.BusSchedule, error) {
+					return chart, nil
+				})
+				userRepo.EXPECT().UpdateBooking(gomock.Any()).DoAndReturn(func(booking *entities.Booking) (*entities.Booking, error) {
+					return booking, nil
+				})
+				userRepo.EXPECT().FindWaitlisted(uint(1), day).Return(nil, nil)
+			},
+			want:    1,
+			wantErr: true,
+		},
+		{
+			name: "expired holds not found",
+			held: heldSeats{},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindExpiredHolds(gomock.Any()).Return(nil, errors.New("Oops"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			expectTx(mockRepo)
+			expectRoute(mockRepo)
+			tt.beforeTest(mockRepo)
+			u := &UserServiceImpl{repo: mockRepo, hold: tt.held}
+			got, err := u.ReleaseExpiredHolds()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.ReleaseExpiredHolds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("services.ReleaseExpiredHolds() = %v, want %v", got, tt.want)
+			}
+			if tt.wantHeld != 0 && !tt.held[tt.wantHeld] {
+				t.Errorf("services.ReleaseExpiredHolds() placed no hold for booking %d", tt.wantHeld)
+			}
+		})
+	}
+}
+
+func Test_RescheduleBooking(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+	day := time.Now().AddDate(0, 0, 10).Format("02 01 2006")
+	later := time.Now().AddDate(0, 0, 12).Format("02 01 2006")
+
+	deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{false, false, false}}})
+	repo := &lockingUserRepo{
+		chart:    &entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne},
+		user:     &entities.User{ID: 1, Email: "abc@gmail.com", UserWallet: 100000},
+		provider: &entities.ServiceProvider{ProviderID: 1},
+		discount: 50,
+	}
+	w := &UserServiceImpl{
+		repo: repo,
+		jwt:  middleware.NewJwtUtil(),
+		hold: noHold{},
+	}
+	original, err := w.BookSeat(&dto.BookingRequest{
+		UsedCouponID:         1,
+		BusID:                1,
+		PassengerID:          pq.Int64Array{1},
+		SeatsReserved:        []string{"01A"},
+		BookingDate:          day,
+		PreferredPaymentType: "Wallet",
+	}, "abc@gmail.com")
+	if err != nil {
+		t.Fatalf("services.BookSeat() error = %v", err)
+	}
+
+	if _, err := w.RescheduleBooking(int(original.BookingID), &dto.RescheduleRequest{BookingDate: day, SeatsReserved: []string{"01B"}}, "abc@gmail.com"); err == nil {
+		t.Errorf("services.RescheduleBooking() moved the booking onto itself")
+	}
+	if _, err := w.RescheduleBooking(int(original.BookingID), &dto.RescheduleRequest{BookingDate: later, SeatsReserved: []string{"01B", "01C"}}, "abc@gmail.com"); err == nil {
+		t.Errorf("services.RescheduleBooking() took more seats than passengers")
+	}
+
+	// the coupon has expired since, the new booking keeps its discount and the higher fare is taken from the wallet
+	repo.expired = true
+	repo.fare = 1000
+	wallet, held := walletOf(t, repo), escrowed(repo)
+	moved, err := w.RescheduleBooking(int(original.BookingID), &dto.RescheduleRequest{BookingDate: later, SeatsReserved: []string{"01B"}, PreferredPaymentType: "Wallet"}, "abc@gmail.com")
+	if err != nil {
+		t.Fatalf("services.RescheduleBooking() error = %v", err)
+	}
+	if moved.Status != "Success" || moved.RescheduledFrom != original.BookingID || repo.bookings[0].Status != "Rescheduled" || repo.bookings[0].RescheduledTo != moved.BookingID {
+		t.Errorf("services.RescheduleBooking() = %+v, original %+v", moved, repo.bookings[0])
+	}
+	if moved.UsedCouponID != original.UsedCouponID || moved.FarePostDiscount != moved.ActualFare/2 {
+		t.Errorf("services.RescheduleBooking() fare = %v of %v, want the coupon discount kept", moved.FarePostDiscount, moved.ActualFare)
+	}
+	difference := ledger.Paisa(moved.FarePostDiscount) - ledger.Paisa(original.FarePostDiscount)
+	if difference <= 0 || walletOf(t, repo) != wallet-difference || escrowed(repo) != held+difference {
+		t.Errorf("services.RescheduleBooking() wallet = %d and escrow = %d, want the difference %d moved", walletOf(t, repo), escrowed(repo), difference)
+	}
+	if len(repo.items) != 2 || repo.items[0].Status != ItemRescheduled || repo.items[1].SeatID != "01B" || repo.items[1].BookingID != moved.BookingID {
+		t.Errorf("services.RescheduleBooking() items = %+v and %+v", repo.items[0], repo.items[len(repo.items)-1])
+	}
+	if _, err := w.CancelBooking(int(original.BookingID), "", "abc@gmail.com"); err == nil {
+		t.Errorf("services.CancelBooking() cancelled a rescheduled booking")
+	}
+
+	// paying the rest through Razorpay leaves the new booking awaiting payment with the paid fare as credit
+	repo.fare = 500
+	wallet = walletOf(t, repo)
+	cheaper, err := w.RescheduleBooking(int(moved.BookingID), &dto.RescheduleRequest{BookingDate: day, SeatsReserved: []string{"01A"}}, "abc@gmail.com")
+	if err != nil {
+		t.Fatalf("services.RescheduleBooking() error = %v", err)
+	}
+	if cheaper.Status != "Success" || walletOf(t, repo) != wallet+ledger.Paisa(moved.FarePostDiscount)-ledger.Paisa(cheaper.FarePostDiscount) {
+		t.Errorf("services.RescheduleBooking() to a cheaper fare = %+v, wallet %d", cheaper, walletOf(t, repo))
+	}
+	repo.fare = 1000
+	pending, err := w.RescheduleBooking(int(cheaper.BookingID), &dto.RescheduleRequest{BookingDate: later, SeatsReserved: []string{"01C"}, PreferredPaymentType: "Razorpay"}, "abc@gmail.com")
+	if err != nil {
+		t.Fatalf("services.RescheduleBooking() error = %v", err)
+	}
+	if pending.Status != "Awaiting Payment" || pending.RescheduleCredit != cheaper.FarePostDiscount {
+		t.Errorf("services.RescheduleBooking() through Razorpay = %+v", pending)
+	}
+	wallet = walletOf(t, repo)
+	if _, err := w.CancelBooking(int(pending.BookingID), "", "abc@gmail.com"); err != nil {
+		t.Fatalf("services.CancelBooking() error = %v", err)
+	}
+	if walletOf(t, repo) != wallet+ledger.Paisa(cheaper.FarePostDiscount*0.9) {
+		t.Errorf("services.CancelBooking() wallet = %d, want the credit refunded", walletOf(t, repo))
+	}
+	balancedBooks(t, repo)
+}
+
+func Test_moveRescheduledFare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	original := &entities.Booking{BookingID: 1, UserID: 1, BusID: 1, BookingDate: "01 01 2024", FarePostDiscount: 500, Status: "Success"}
+	rescheduled := &entities.Booking{BookingID: 2, UserID: 1, BusID: 1, BookingDate: "03 01 2024"}
+	tests := []struct {
+		name       string
+		credited   float64
+		refundTo   string
+		beforeTest func(userRepo *repository.MockUserRepository, refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository)
+		want       *entities.Refund
+		wantErr    bool
+	}{
+		{
+			name:     "success whole fare credited",
+			credited: 500,
+			beforeTest: func(userRepo *repository.MockUserRepository, refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				expectPost(ledgerRepo, "booking:1:reschedule", 50000)
+				expectPost(ledgerRepo, "booking:2:credit", 50000)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:     "success lower fare refunded to the wallet",
+			credited: 300,
+			beforeTest: func(userRepo *repository.MockUserRepository, refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().GetUserInfo(1).Return(&entities.User{ID: 1}, nil)
+				expectPost(ledgerRepo, "booking:1:reschedule:refund", 20000)
+				expectPost(ledgerRepo, "booking:1:reschedule", 30000)
+				expectPost(ledgerRepo, "booking:2:credit", 30000)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:     "success lower fare refunded to the payment the user prefers",
+			credited: 300,
+			beforeTest: func(userRepo *repository.MockUserRepository, refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().GetUserInfo(1).Return(&entities.User{ID: 1, RefundTo: RefundToSource}, nil)
+				refundRepo.EXPECT().FindBookingPayment(uint(1)).Return(&entities.RazorPay{BookID: 1, RazorPaymentID: "pay_1", AmountPaid: 500, Status: PaymentCaptured}, nil)
+				refundRepo.EXPECT().RefundedFrom("pay_1").Return(int64(0), nil)
+				expectPost(ledgerRepo, "booking:1:reschedule:refund", 20000)
+				refundRepo.EXPECT().AddRefund(gomock.Any()).Return(nil)
+				expectPost(ledgerRepo, "booking:1:reschedule", 30000)
+				expectPost(ledgerRepo, "booking:2:credit", 30000)
+			},
+			want:    &entities.Refund{BookingID: 1, UserID: 1, PaymentID: "pay_1", Key: "booking:1:reschedule:refund", Amount: 20000, Status: RefundPending},
+			wantErr: false,
+		},
+		{
+			name:     "asked for the wallet over the preference",
+			credited: 300,
+			refundTo: RefundToWallet,
+			beforeTest: func(userRepo *repository.MockUserRepository, refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().GetUserInfo(1).Return(&entities.User{ID: 1, RefundTo: RefundToSource}, nil)
+				expectPost(ledgerRepo, "booking:1:reschedule:refund", 20000)
+				expectPost(ledgerRepo, "booking:1:reschedule", 30000)
+				expectPost(ledgerRepo, "booking:2:credit", 30000)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:     "user lookup failed",
+			credited: 300,
+			beforeTest: func(userRepo *repository.MockUserRepository, refundRepo *repository.MockRefundRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().GetUserInfo(1).Return(nil, errors.New("oops"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := repository.NewMockUserRepository(ctrl)
+			mockRefunds := repository.NewMockRefundRepository(ctrl)
+			mockLedger := repository.NewMockLedgerRepository(ctrl)
+			expectLedger(mockLedger)
+			mockUserRepo.EXPECT().Ledger().Return(mockLedger).AnyTimes()
+			mockUserRepo.EXPECT().Refunds().Return(mockRefunds).AnyTimes()
+			mockRefunds.EXPECT().Ledger().Return(mockLedger).AnyTimes()
+			mockUserRepo.EXPECT().GetBusInfo(1).Return(&entities.Buses{BusID: 1, ProviderID: 1}, nil).AnyTimes()
+			mockLedger.EXPECT().HeldFor(gomock.Any(), uint(1)).Return(int64(50000), nil).AnyTimes()
+			tt.beforeTest(mockUserRepo, mockRefunds, mockLedger)
+			got, err := moveRescheduledFare(mockUserRepo, original, rescheduled, tt.credited, tt.refundTo)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.moveRescheduledFare() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.moveRescheduledFare() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_LookupBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	booking := &entities.Booking{BookingID: 3, PNR: "ABCD234XYZ", UserID: 1}
+	user := &entities.User{ID: 1, Email: "abc@gmail.com", PhoneNumber: "9876543210"}
+	tests := []struct {
+		name       string
+		pnr        string
+		contact    string
+		beforeTest func(userRepo *repository.MockUserRepository)
+		wantErr    bool
+	}{
+		{
+			name:    "by email",
+			pnr:     "abcd 234xyz",
+			contact: "ABC@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("ABCD234XYZ").Return(booking, nil)
+				userRepo.EXPECT().GetUserInfo(1).Return(user, nil)
+				userRepo.EXPECT().FindBookingItems([]uint{3}).Return(nil, nil)
+			},
+		},
+		{
+			name:    "by phone",
+			pnr:     "ABCD234XYZ",
+			contact: "9876543210",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("ABCD234XYZ").Return(booking, nil)
+				userRepo.EXPECT().GetUserInfo(1).Return(user, nil)
+				userRepo.EXPECT().FindBookingItems([]uint{3}).Return(nil, nil)
+			},
+		},
+		{
+			name:    "wrong contact",
+			pnr:     "ABCD234XYZ",
+			contact: "other@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("ABCD234XYZ").Return(booking, nil)
+				userRepo.EXPECT().GetUserInfo(1).Return(user, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown pnr",
+			pnr:     "ZZZZ234XYZ",
+			contact: "abc@gmail.com",
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindBookingByPNR("ZZZZ234XYZ").Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "booking id instead of pnr",
+			pnr:     "3",
+			contact: "abc@gmail.com",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := repository.NewMockUserRepository(ctrl)
+			w := &UserServiceImpl{
+				repo: mockUserRepo,
+				jwt:  middleware.NewJwtUtil(),
+			}
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserRepo)
+			}
+			got, err := w.LookupBooking(tt.pnr, tt.contact)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("services.LookupBooking() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.BookingID != booking.BookingID {
+				t.Errorf("services.LookupBooking() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_newPNR(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		pnr := newPNR()
+		if len(pnr) != pnrLength || strings.Trim(pnr, pnrAlphabet) != "" {
+			t.Fatalf("newPNR() = %q", pnr)
+		}
+		if seen[pnr] {
+			t.Fatalf("newPNR() repeated %q", pnr)
+		}
+		seen[pnr] = true
+	}
+}
+
+func Test_BookingTicket(t *testing.T) {
+	t.Setenv("TICKET_SIGNING_KEY", "boarding-secret")
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+	var mailed []byte
+	var mailedTo string
+	sent := make(chan bool, 1)
+	ticketMailer = func(recipientEmail string, subject string, body string, filename string, pdf []byte) error {
+		mailedTo, mailed = recipientEmail, pdf
+		sent <- true
+		return nil
+	}
+	defer func() { ticketMailer = func(string, string, string, string, []byte) error { return nil } }()
+
+	deckOne, _ := json.Marshal(map[string][][]bool{"deckOneLayout": {{false, false}}})
+	repo := &lockingUserRepo{
+		chart:    &entities.BusSchedule{BusID: 1, Status: "Active", DeckOneSeatLayout: deckOne},
+		user:     &entities.User{ID: 1, Email: "abc@gmail.com", UserWallet: 100000},
+		provider: &entities.ServiceProvider{ProviderID: 1},
+	}
+	w := &UserServiceImpl{
+		repo: repo,
+		jwt:  middleware.NewJwtUtil(),
+		hold: noHold{},
+	}
+	booking, err := w.BookSeat(&dto.BookingRequest{
+		UsedCouponID:         1,
+		BusID:                1,
+		PassengerID:          pq.Int64Array{1, 2},
+		SeatsReserved:        []string{"01A", "01B"},
+		BookingDate:          "01 01 2030",
+		PreferredPaymentType: "Wallet",
+	}, "abc@gmail.com")
+	if err != nil {
+		t.Fatalf("services.BookSeat() error = %v", err)
+	}
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatalf("services.BookSeat() did not email the ticket")
+	}
+	if mailedTo != "abc@gmail.com" || !bytes.HasPrefix(mailed, []byte("%PDF")) {
+		t.Errorf("services.BookSeat() mailed %d bytes to %q", len(mailed), mailedTo)
+	}
+
+	pnr, pdf, err := w.BookingTicket(int(booking.BookingID), "abc@gmail.com")
+	if err != nil {
+		t.Fatalf("services.BookingTicket() error = %v", err)
+	}
+	for _, want := range []string{"(PNR: " + booking.PNR + ")", "(01A)", "(01B)", "(Kannur to Bangalore)", "(01 01 2030 22:00)", "(02 01 2030 06:00)"} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("services.BookingTicket() is missing %s", want)
+		}
+	}
+	if pnr != booking.PNR {
+		t.Errorf("services.BookingTicket() pnr = %q, want %q", pnr, booking.PNR)
+	}
+	if !bytes.Contains(pdf, []byte(" re\n")) {
+		t.Errorf("services.BookingTicket() has no boarding QR code")
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gobus/entities"
+	"gobus/ledger"
+	repository "gobus/repository/interfaces"
+	"gobus/seathold"
+	"gobus/seatmap"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// waitlistDraft function is used to put the draft on the waitlist of its seat class, only allowed once the class has too few free seats on the segment.
+func waitlistDraft(draft *bookingDraft, inventory *seatInventory, segment seatmap.Segment) error {
+	class := strings.ToLower(draft.request.SeatClass)
+	counts := inventory.seatMap.CountByClass()
+	if class == "" && len(counts) == 1 {
+		for only := range counts {
+			class = only
+		}
+	}
+	if counts[class] == 0 {
+		log.Println("Invalid seat class for the waitlist, in waitlist file")
+		return errors.New("pick the seat class to wait for, the bus has no " + class + " seats")
+	}
+	free := seatsOfClass(inventory.chart.AvailableFor(inventory.seatMap, segment), class)
+	if len(free) >= len(draft.request.PassengerID) {
+		log.Println("Seats still available for the waitlist, in waitlist file")
+		return errors.New("seats are still available in this class, book them instead")
+	}
+	fare := seatFare(inventory.segmentFare(segment), draft.bus.BusTypeCode, class) * float64(len(draft.request.PassengerID))
+	draft.booking.SeatClass = class
+	draft.booking.SeatReserved = []string{}
+	draft.booking.ActualFare = fare
+	draft.booking.FarePostDiscount = fare * float64((100-float64(draft.discount))/100)
+	draft.booking.Status = "Waitlisted"
+	return nil
+}
+
+// seatsOfClass function returns the IDs of the seats of the given class.
+func seatsOfClass(seats []seatmap.Seat, class string) []string {
+	var ids []string
+	for _, seat := range seats {
+		if seat.Class == class {
+			ids = append(ids, seat.ID)
+		}
+	}
+	return ids
+}
+
+// promoteWaitlist function is used to give the seats freed on a chart to the waitlisted bookings in the order they joined, a class stops moving as soon as its first booking does not fit.
+func promoteWaitlist(tx repository.UserRepository, chart *entities.BusSchedule, bookingDate string) ([]*entities.Booking, error) {
+	waiting, err := tx.FindWaitlisted(chart.BusID, bookingDate)
+	if err != nil || len(waiting) == 0 {
+		return nil, err
+	}
+	bus, err := tx.GetBusInfo(int(chart.BusID))
+	if err != nil {
+		log.Println("Error fetching bus details, in waitlist file")
+		return nil, err
+	}
+	inventory, err := loadInventory(tx, bus, chart)
+	if err != nil {
+		return nil, err
+	}
+	var promoted []*entities.Booking
+	stuck := map[string]bool{}
+	for _, booking := range waiting {
+		if stuck[booking.SeatClass] {
+			continue
+		}
+		segment, err := inventory.segment(booking.FromStation, booking.ToStation)
+		if err != nil {
+			continue
+		}
+		free := seatsOfClass(inventory.chart.AvailableFor(inventory.seatMap, segment), booking.SeatClass)
+		if len(free) < len(booking.PassengerID) {
+			stuck[booking.SeatClass] = true
+			continue
+		}
+		seats := free[:len(booking.PassengerID)]
+		if _, err := inventory.chart.ReserveSegment(inventory.seatMap, seats, segment); err != nil {
+			return nil, err
+		}
+		booking.SeatReserved = seats
+		if err := payWaitlisted(tx, bus, booking); err != nil {
+			return nil, err
+		}
+		if _, err := tx.UpdateBooking(booking); err != nil {
+			log.Println("Could not confirm the waitlisted booking, in waitlist file")
+			return nil, err
+		}
+		if _, err := bookingItems(tx, booking); err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, booking)
+	}
+	if len(promoted) == 0 {
+		return nil, nil
+	}
+	if err := inventory.store(chart); err != nil {
+		return nil, err
+	}
+	if _, err := tx.UpdateChart(chart); err != nil {
+		log.Println("Could not update the chart, in waitlist file")
+		return nil, err
+	}
+	return promoted, nil
+}
+
+// payWaitlisted function is used to settle a promoted booking from the wallet when the user chose it and can cover the fare, otherwise the seats are held for the payment.
+func payWaitlisted(tx repository.UserRepository, bus *entities.Buses, booking *entities.Booking) error {
+	if booking.PaymentType == "Wallet" {
+		balance, err := walletBalance(tx, booking.UserID)
+		if err != nil {
+			return err
+		}
+		if balance >= ledger.Paisa(booking.FarePostDiscount) {
+			if err := postWalletPayment(tx, booking); err != nil {
+				return err
+			}
+			booking.Status = "Success"
+			booking.HoldExpiresAt = nil
+			return nil
+		}
+	}
+	holdExpiry := time.Now().Add(seathold.HoldDuration())
+	booking.Status = "Awaiting Payment"
+	booking.HoldExpiresAt = &holdExpiry
+	return nil
+}
+
+// notifyPromoted function is used to place the payment holds of the promoted bookings and tell their users, called once the transaction has committed.
+func (usi *UserServiceImpl) notifyPromoted(promoted []*entities.Booking) {
+	for _, booking := range promoted {
+		message := fmt.Sprintf("Your waitlisted booking %s is confirmed with the seats %s.", booking.PNR, strings.Join(booking.SeatReserved, ", "))
+		if booking.Status == "Awaiting Payment" {
+			if err := usi.hold.Hold(booking.BookingID, seathold.HoldDuration()); err != nil {
+				log.Println("Unable to place the seat hold, in waitlist file")
+			}
+			message = fmt.Sprintf("The seats %s are held for your waitlisted booking %s, please complete the payment.", strings.Join(booking.SeatReserved, ", "), booking.PNR)
+		}
+		user, err := usi.repo.GetUserInfo(int(booking.UserID))
+		if err != nil {
+			continue
+		}
+		smsNotifier(message, user.PhoneNumber)
+	}
+}
+
+// setWaitlistPositions function is used to fill the queue position of the waitlisted bookings, counted within their bus, day and seat class.
+func setWaitlistPositions(repo repository.UserRepository, bookings []*entities.Booking) {
+	queues := map[string][]*entities.Booking{}
+	for _, booking := range bookings {
+		if booking.Status != "Waitlisted" {
+			continue
+		}
+		key := fmt.Sprintf("%d %s", booking.BusID, booking.BookingDate)
+		queue, ok := queues[key]
+		if !ok {
+			queue, _ = repo.FindWaitlisted(booking.BusID, booking.BookingDate)
+			sort.SliceStable(queue, func(i, j int) bool { return queue[i].BookingID < queue[j].BookingID })
+			queues[key] = queue
+		}
+		position := 0
+		for _, waiting := range queue {
+			if waiting.SeatClass != booking.SeatClass {
+				continue
+			}
+			position++
+			if waiting.BookingID == booking.BookingID {
+				booking.WaitlistPosition = position
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/ledger"
+	repository "gobus/repository/interfaces"
+	"log"
+	"math"
+	"time"
+)
+
+// Statuses of a wallet top-up.
+const (
+	TopupCreated  = "Created"
+	TopupCredited = "Credited"
+)
+
+// WalletStatement implements interfaces.UserService.
+func (usi *UserServiceImpl) WalletStatement(email string, page int, limit int) (*dto.WalletStatement, error) {
+	user, err := usi.repo.FindUserByEmail(email)
+	if err != nil {
+		log.Println("No USER EXISTS, in wallet file")
+		return nil, err
+	}
+	return walletStatement(usi.repo.Ledger(), ledger.UserAccount(user.ID), page, limit)
+}
+
+// TopupWallet implements interfaces.UserService.
+func (usi *UserServiceImpl) TopupWallet(request *dto.TopupRequest, email string) (*dto.TopupResponse, error) {
+	amount := math.Round(request.Amount*100) / 100
+	if amount <= 0 {
+		return nil, errors.New("top-up amount should be more than zero")
+	}
+	user, err := usi.repo.FindUserByEmail(email)
+	if err != nil {
+		log.Println("No USER EXISTS, in wallet file")
+		return nil, err
+	}
+	order, err := usi.gateway.CreateOrder(ledger.Paisa(amount), fmt.Sprintf("topup-%d-%d", user.ID, time.Now().Unix()))
+	if err != nil {
+		log.Println("Unable to raise the order of the top-up, in wallet file")
+		return nil, err
+	}
+	topup := &entities.WalletTopup{UserID: user.ID, Amount: amount, OrderID: order.ID, Status: TopupCreated}
+	if err := usi.repo.AddTopup(topup); err != nil {
+		log.Println("Unable to store the wallet top-up, in wallet file")
+		return nil, err
+	}
+	return &dto.TopupResponse{
+		TopupID:     topup.ID,
+		OrderID:     order.ID,
+		Amount:      amount,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+	}, nil
+}
+
+// TopupSuccess implements interfaces.UserService, a top-up already credited is left as it is.
+func (usi *UserServiceImpl) TopupSuccess(razor *entities.RazorPay) error {
+	topup, err := usi.repo.FindTopup(razor.RazorPayOrderID)
+	if err != nil {
+		log.Println("Error fetching the wallet top-up, in wallet file")
+		return errors.New("top-up not found")
+	}
+	if topup.Status == TopupCredited {
+		return nil
+	}
+	// the order is the top-up's own, only the signature and the amount are left to check
+	if reason, err := usi.verifyPayment(razor, "", ledger.Paisa(topup.Amount)); err != nil {
+		if reason != "" {
+			usi.flagPayment(razor, 0, topup.ID, reason)
+		}
+		return err
+	}
+	credited := false
+	err = usi.repo.WithTx(func(tx repository.UserRepository) error {
+		topup, err = tx.FindTopupForUpdate(razor.RazorPayOrderID)
+		if err != nil {
+			log.Println("Error fetching the wallet top-up, in wallet file")
+			return errors.New("top-up not found")
+		}
+		// the webhook of the payment may have credited it while the payment was verified
+		if topup.Status == TopupCredited {
+			return nil
+		}
+		if err := creditTopup(tx, topup, razor.RazorPaymentID); err != nil {
+			return err
+		}
+		credited = true
+		return nil
+	})
+	if err != nil || !credited {
+		return err
+	}
+	usi.notifyTopup(topup)
+	return nil
+}
+
+// creditTopup function is used to credit the wallet with the top-up paid by the payment within the transaction.
+func creditTopup(tx repository.UserRepository, topup *entities.WalletTopup, paymentID string) error {
+	entry := ledger.Transfer(fmt.Sprintf("topup:%d", topup.ID), ledger.KindTopup, 0, "Wallet top-up through Razorpay", ledger.Razorpay, ledger.UserAccount(topup.UserID), ledger.Paisa(topup.Amount))
+	if err := postEntry(tx.Ledger(), entry); err != nil {
+		return err
+	}
+	now := time.Now()
+	topup.Status = TopupCredited
+	topup.PaymentID = paymentID
+	topup.CreditedAt = &now
+	return tx.UpdateTopup(topup)
+}
+
+// notifyTopup function is used to tell the user by SMS that the top-up was added to the wallet.
+func (usi *UserServiceImpl) notifyTopup(topup *entities.WalletTopup) {
+	if user, err := usi.repo.GetUserInfo(int(topup.UserID)); err == nil {
+		smsNotifier(fmt.Sprintf("Rs %.2f has been added to your wallet.", topup.Amount), user.PhoneNumber)
+	}
+}
+
+// WalletStatement implements interfaces.ProviderService.
+func (ps *ProviderServiceImpl) WalletStatement(email string, page int, limit int) (*dto.WalletStatement, error) {
+	provider, err := ps.repo.FindProviderByEmail(email)
+	if err != nil {
+		log.Println("Provider not found, in wallet file")
+		return nil, err
+	}
+	return walletStatement(ps.repo.Ledger(), ledger.ProviderAccount(provider.ProviderID), page, limit)
+}
+
+// walletStatement function is used to list a page of the movements of the wallet in rupees, the page starts at one and holds twenty movements unless asked otherwise.
+func walletStatement(repo repository.LedgerRepository, account string, page int, limit int) (*dto.WalletStatement, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	statement, err := ledger.AccountStatement(repo, account, (page-1)*limit, limit)
+	if err != nil {
+		log.Println("Error fetching the wallet statement, in wallet file")
+		return nil, err
+	}
+	result := &dto.WalletStatement{Balance: rupees(statement.Balance), Page: page, Limit: limit, Total: statement.Total, Transactions: []*dto.WalletTransaction{}}
+	for _, line := range statement.Lines {
+		result.Transactions = append(result.Transactions, &dto.WalletTransaction{
+			ID:        line.EntryID,
+			Kind:      line.Kind,
+			BookingID: line.BookingID,
+			Memo:      line.Memo,
+			Amount:    rupees(line.Amount),
+			Balance:   rupees(line.Balance),
+			At:        line.At,
+		})
+	}
+	return result, nil
+}
+
+// rupees function converts an amount in paisa to rupees.
+func rupees(paisa int64) float64 {
+	return float64(paisa) / 100
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"errors"
+	"gobus/dto"
+	"gobus/entities"
+	"gobus/payment"
+	"gobus/repository"
+	"gobus/repository/interfaces"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_TopupWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	type args struct {
+		request *dto.TopupRequest
+		email   string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(userRepo *repository.MockUserRepository)
+		want       *dto.TopupResponse
+		wantErr    bool
+	}{
+		{
+			name: "success top-up rounded to paisa",
+			args: args{request: &dto.TopupRequest{Amount: 250.505}, email: "abc@gmail.com"},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1, Email: "abc@gmail.com", PhoneNumber: "1234567890"}, nil)
+				userRepo.EXPECT().AddTopup(&entities.WalletTopup{UserID: 1, Amount: 250.51, OrderID: "order_fake1", Status: TopupCreated}).DoAndReturn(func(topup *entities.WalletTopup) error {
+					topup.ID = 1
+					return nil
+				})
+			},
+			want:    &dto.TopupResponse{TopupID: 1, OrderID: "order_fake1", Amount: 250.51, Email: "abc@gmail.com", PhoneNumber: "1234567890"},
+			wantErr: false,
+		},
+		{
+			name:       "top-up of nothing",
+			args:       args{request: &dto.TopupRequest{Amount: 0.001}, email: "abc@gmail.com"},
+			beforeTest: func(userRepo *repository.MockUserRepository) {},
+			wantErr:    true,
+		},
+		{
+			name: "no user",
+			args: args{request: &dto.TopupRequest{Amount: 100}, email: "abc@gmail.com"},
+			beforeTest: func(userRepo *repository.MockUserRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			tt.beforeTest(mockRepo)
+			u := &UserServiceImpl{repo: mockRepo, gateway: payment.NewFakeGateway("")}
+			got, err := u.TopupWallet(tt.args.request, tt.args.email)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.TopupWallet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.TopupWallet() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TopupSuccess(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	created := func(orderID string) *entities.WalletTopup {
+		return &entities.WalletTopup{ID: 1, UserID: 1, Amount: 250.51, OrderID: orderID, Status: TopupCreated}
+	}
+	tests := []struct {
+		name       string
+		forged     bool
+		beforeTest func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, orderID string)
+		wantErr    bool
+	}{
+		{
+			name: "success wallet credited",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, orderID string) {
+				userRepo.EXPECT().FindTopup(orderID).Return(created(orderID), nil)
+				userRepo.EXPECT().FindTopupForUpdate(orderID).Return(created(orderID), nil)
+				expectPost(ledgerRepo, "topup:1", 25051)
+				userRepo.EXPECT().UpdateTopup(gomock.Any()).DoAndReturn(func(topup *entities.WalletTopup) error {
+					if topup.Status != TopupCredited || topup.PaymentID == "" || topup.CreditedAt == nil {
+						t.Errorf("services.TopupSuccess() stored the top-up %+v", topup)
+					}
+					return nil
+				})
+				userRepo.EXPECT().GetUserInfo(1).Return(&entities.User{ID: 1, PhoneNumber: "1234567890"}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "callback for a credited top-up",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, orderID string) {
+				credited := created(orderID)
+				credited.Status = TopupCredited
+				userRepo.EXPECT().FindTopup(orderID).Return(credited, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "webhook credited the top-up while it was verified",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, orderID string) {
+				credited := created(orderID)
+				credited.Status = TopupCredited
+				userRepo.EXPECT().FindTopup(orderID).Return(created(orderID), nil)
+				userRepo.EXPECT().FindTopupForUpdate(orderID).Return(credited, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:   "forged signature",
+			forged: true,
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, orderID string) {
+				userRepo.EXPECT().FindTopup(orderID).Return(created(orderID), nil)
+				userRepo.EXPECT().AddSuspiciousPayment(gomock.Any()).DoAndReturn(func(attempt *entities.SuspiciousPayment) error {
+					if attempt.TopupID != 1 || attempt.Reason != reasonSignature {
+						t.Errorf("services.TopupSuccess() recorded %+v", attempt)
+					}
+					return nil
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown order",
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository, orderID string) {
+				userRepo.EXPECT().FindTopup(orderID).Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := payment.NewFakeGateway("")
+			order, _ := gateway.CreateOrder(25051, "topup-1-1")
+			paid, signature, err := gateway.Pay(order.ID)
+			if err != nil {
+				t.Fatalf("payment.FakeGateway.Pay() error = %v", err)
+			}
+			if tt.forged {
+				signature = payment.Sign("guessed", order.ID, paid.ID)
+			}
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			mockLedger := repository.NewMockLedgerRepository(ctrl)
+			expectTx(mockRepo)
+			expectLedger(mockLedger)
+			mockRepo.EXPECT().Ledger().Return(mockLedger).AnyTimes()
+			tt.beforeTest(mockRepo, mockLedger, order.ID)
+			u := &UserServiceImpl{repo: mockRepo, gateway: gateway}
+			err = u.TopupSuccess(&entities.RazorPay{RazorPaymentID: paid.ID, RazorPayOrderID: order.ID, Signature: signature})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.TopupSuccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_WalletStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	at := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		page       int
+		limit      int
+		beforeTest func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository)
+		want       *dto.WalletStatement
+		wantErr    bool
+	}{
+		{
+			name:  "success newest first with running balance",
+			page:  1,
+			limit: 2,
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1}, nil)
+				ledgerRepo.EXPECT().OpenAccount(gomock.Any()).Return(&entities.LedgerAccount{ID: 7, Code: "user:1", Balance: 35051}, false, nil)
+				ledgerRepo.EXPECT().FindStatement(uint(7), 0, 2).Return([]*entities.LedgerEntry{
+					{ID: 2, Kind: "topup", Memo: "Wallet top-up through Razorpay", Lines: []*entities.LedgerLine{{AccountID: 7, Amount: 25051}}, CreatedAt: at},
+					{ID: 1, Kind: "opening", Memo: "Wallet balance before the ledger", Lines: []*entities.LedgerLine{{AccountID: 7, Amount: 10000}}, CreatedAt: at},
+				}, int64(2), nil)
+				ledgerRepo.EXPECT().BalanceThrough(uint(7), uint(2)).Return(int64(35051), nil)
+			},
+			want: &dto.WalletStatement{Balance: 350.51, Page: 1, Limit: 2, Total: 2, Transactions: []*dto.WalletTransaction{
+				{ID: 2, Kind: "topup", Memo: "Wallet top-up through Razorpay", Amount: 250.51, Balance: 350.51, At: at},
+				{ID: 1, Kind: "opening", Memo: "Wallet balance before the ledger", Amount: 100, Balance: 100, At: at},
+			}},
+			wantErr: false,
+		},
+		{
+			name:  "page past the end with the default limit",
+			page:  0,
+			limit: 500,
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(&entities.User{ID: 1}, nil)
+				ledgerRepo.EXPECT().OpenAccount(gomock.Any()).Return(&entities.LedgerAccount{ID: 7, Code: "user:1"}, false, nil)
+				ledgerRepo.EXPECT().FindStatement(uint(7), 0, 20).Return(nil, int64(0), nil)
+			},
+			want:    &dto.WalletStatement{Page: 1, Limit: 20, Transactions: []*dto.WalletTransaction{}},
+			wantErr: false,
+		},
+		{
+			name:  "no user",
+			page:  1,
+			limit: 20,
+			beforeTest: func(userRepo *repository.MockUserRepository, ledgerRepo *repository.MockLedgerRepository) {
+				userRepo.EXPECT().FindUserByEmail("abc@gmail.com").Return(nil, errors.New("record not found"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockUserRepository(ctrl)
+			mockLedger := repository.NewMockLedgerRepository(ctrl)
+			mockRepo.EXPECT().Ledger().Return(mockLedger).AnyTimes()
+			mockLedger.EXPECT().WithTx(gomock.Any()).DoAndReturn(func(fn func(tx interfaces.LedgerRepository) error) error {
+				return fn(mockLedger)
+			}).AnyTimes()
+			tt.beforeTest(mockRepo, mockLedger)
+			u := &UserServiceImpl{repo: mockRepo}
+			got, err := u.WalletStatement("abc@gmail.com", tt.page, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("services.WalletStatement() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("services.WalletStatement() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"gobus/entities"
+	"gobus/ledger"
+	"gobus/payment"
+	repository "gobus/repository/interfaces"
+	"log"
+	"strings"
+	"time"
+)
+
+// Statuses of a payment taken through the gateway.
+const (
+	PaymentCaptured          = "Captured"
+	PaymentFailed            = "Failed"
+	PaymentCredited          = "Credited to Wallet"
+	PaymentPartiallyRefunded = "Partially Refunded"
+	PaymentRefunded          = "Refunded"
+)
+
+// Outcomes of a webhook event.
+const (
+	EventApplied  = "Applied"
+	EventIgnored  = "Ignored"
+	EventRejected = "Rejected"
+)
+
+// paymentMoves lists the statuses a payment can move to from each status, a payment not stored yet has none.
+var paymentMoves = map[string][]string{
+	"":                       {PaymentCaptured, PaymentFailed, PaymentCredited},
+	PaymentCaptured:          {PaymentPartiallyRefunded, PaymentRefunded},
+	PaymentCredited:          {PaymentPartiallyRefunded, PaymentRefunded},
+	PaymentPartiallyRefunded: {PaymentPartiallyRefunded, PaymentRefunded},
+}
+
+// paymentStatus function returns the status of the stored payment, payments stored before statuses were kept were captured.
+func paymentStatus(razor *entities.RazorPay) string {
+	if razor == nil {
+		return ""
+	}
+	if razor.Status == "" {
+		return PaymentCaptured
+	}
+	return razor.Status
+}
+
+// canMove function reports whether a payment in the status can move to the next one.
+func canMove(from string, to string) bool {
+	for _, next := range paymentMoves[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmBooking function is used to confirm the booking awaiting the payment within the transaction, the fare is held in the escrow of its trip. A booking that can no longer take the payment has it credited to the wallet of the user instead. It returns the status the payment was stored with, none when the payment confirmed the booking before.
+func confirmBooking(tx repository.UserRepository, book *entities.Booking, razor *entities.RazorPay, amount int64) (string, error) {
+	used, err := tx.FindPayment(razor.RazorPaymentID)
+	if err != nil {
+		return "", err
+	}
+	if used != nil {
+		if used.BookID == book.BookingID && paymentStatus(used) != PaymentFailed {
+			return "", nil
+		}
+		return "", errPaymentReplayed
+	}
+	razor.AmountPaid = rupees(amount)
+	if book.Status != "Awaiting Payment" {
+		// the booking expired, was cancelled or was paid another way while the payment went through
+		refund := ledger.Transfer(fmt.Sprintf("payment:%s:unapplied", razor.RazorPaymentID), ledger.KindRefund, book.BookingID, "Payment the booking could not take", ledger.Razorpay, ledger.UserAccount(book.UserID), amount)
+		if err := postEntry(tx.Ledger(), refund); err != nil {
+			return "", err
+		}
+		razor.Status = PaymentCredited
+		if err := tx.PaymentSuccess(razor); err != nil {
+			log.Println("Error updating Payment Info, in webhook file")
+			return "", err
+		}
+		return PaymentCredited, nil
+	}
+	escrow, err := tripEscrow(book)
+	if err != nil {
+		return "", err
+	}
+	// what came in through Razorpay is held for the trip
+	if err := postEntry(tx.Ledger(), ledger.Transfer(paymentKey(book.BookingID), ledger.KindPayment, book.BookingID, "Fare paid through Razorpay", ledger.Razorpay, escrow, amount)); err != nil {
+		return "", err
+	}
+	book.Status = "Success"
+	book.HoldExpiresAt = nil
+	if _, err := tx.UpdateBooking(book); err != nil {
+		log.Println("Error updating the booking, in webhook file")
+		return "", err
+	}
+	razor.Status = PaymentCaptured
+	if err := tx.PaymentSuccess(razor); err != nil {
+		log.Println("Error updating Payment Info, in webhook file")
+		return "", err
+	}
+	return PaymentCaptured, nil
+}
+
+// PaymentWebhook implements interfaces.UserService.
+func (usi *UserServiceImpl) PaymentWebhook(eventID string, body []byte, signature string) error {
+	if eventID == "" {
+		return errors.New("webhook has no event id")
+	}
+	event, err := usi.gateway.ParseWebhook(body, signature)
+	if err != nil {
+		log.Println("Unable to read the webhook, in webhook file")
+		return err
+	}
+	record := &entities.PaymentEvent{
+		ID:         eventID,
+		Type:       event.Type,
+		OrderID:    event.OrderID,
+		PaymentID:  event.PaymentID,
+		RefundID:   event.RefundID,
+		Amount:     event.Amount,
+		ReceivedAt: time.Now(),
+	}
+	switch event.Type {
+	case payment.EventPaymentCaptured, payment.EventPaymentFailed:
+		return usi.paymentEvent(record, event)
+	case payment.EventRefundProcessed:
+		return usi.refundEvent(record, event)
+	}
+	record.Outcome, record.Detail = EventIgnored, "event is not handled"
+	_, err = usi.repo.AddPaymentEvent(record)
+	return err
+}
+
+// paymentEvent function is used to apply a captured or failed payment to the booking or the wallet top-up its order was raised for.
+func (usi *UserServiceImpl) paymentEvent(record *entities.PaymentEvent, event *payment.Event) error {
+	order, err := usi.gateway.FetchStatus(event.OrderID)
+	if err != nil {
+		log.Println("Unable to fetch the order from the gateway, in webhook file")
+		return err
+	}
+	if strings.HasPrefix(order.Receipt, "topup-") {
+		return usi.topupEvent(record, event)
+	}
+	book, err := usi.repo.FindBookingByPNR(order.Receipt)
+	if err != nil {
+		record.Outcome, record.Detail = EventIgnored, "no booking found for the order"
+		_, err = usi.repo.AddPaymentEvent(record)
+		return err
+	}
+	parsedDate, err := time.Parse("02 01 2006", book.BookingDate)
+	if err != nil {
+		log.Println("Error parsing the date, in webhook file")
+		return err
+	}
+	razor := &entities.RazorPay{BookID: book.BookingID, RazorPaymentID: event.PaymentID, RazorPayOrderID: event.OrderID, AmountPaid: rupees(event.Amount)}
+	applied, flagged := false, false
+	status := ""
+	err = usi.repo.WithTx(func(tx repository.UserRepository) error {
+		if _, err := tx.GetChartForUpdate(int(book.BusID), parsedDate); err != nil {
+			log.Println("Error fetching bus schedule, in webhook file")
+			return err
+		}
+		added, err := tx.AddPaymentEvent(record)
+		if err != nil || !added {
+			return err
+		}
+		applied = true
+		book, err = tx.FindBookingByID(int(book.BookingID))
+		if err != nil {
+			return err
+		}
+		used, err := tx.FindPayment(event.PaymentID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case event.Type == payment.EventPaymentFailed:
+			if !canMove(paymentStatus(used), PaymentFailed) {
+				record.Outcome, record.Detail = EventIgnored, "payment is already "+paymentStatus(used)
+				break
+			}
+			razor.Status = PaymentFailed
+			if err := tx.PaymentSuccess(razor); err != nil {
+				return err
+			}
+			record.Outcome, record.Detail = EventApplied, "payment failed, the booking is left as it is"
+		case used != nil:
+			record.Outcome, record.Detail = EventIgnored, "payment is already "+paymentStatus(used)
+		case event.Currency != "INR" || event.Amount != ledger.Paisa(book.FarePostDiscount-book.RescheduleCredit):
+			record.Outcome, record.Detail = EventRejected, reasonAmount
+			flagged = true
+		default:
+			if status, err = confirmBooking(tx, book, razor, event.Amount); err != nil {
+				return err
+			}
+			record.Outcome, record.Detail = EventApplied, "payment "+strings.ToLower(status)
+		}
+		return tx.UpdatePaymentEvent(record)
+	})
+	if err != nil || !applied {
+		return err
+	}
+	if flagged {
+		usi.flagPayment(razor, book.BookingID, 0, reasonAmount)
+	}
+	if status == PaymentCaptured {
+		if err := usi.hold.Release(book.BookingID); err != nil {
+			log.Println("Error releasing the seat hold, in webhook file")
+		}
+		usi.mailTicket(book)
+	}
+	return nil
+}
+
+// topupEvent function is used to credit the wallet top-up the captured payment was taken for, a failed payment leaves it as it is.
+func (usi *UserServiceImpl) topupEvent(record *entities.PaymentEvent, event *payment.Event) error {
+	var topup *entities.WalletTopup
+	credited := false
+	err := usi.repo.WithTx(func(tx repository.UserRepository) error {
+		added, err := tx.AddPaymentEvent(record)
+		if err != nil || !added {
+			return err
+		}
+		topup, err = tx.FindTopupForUpdate(event.OrderID)
+		if err != nil {
+			log.Println("Error fetching the wallet top-up, in webhook file")
+			return errors.New("top-up not found")
+		}
+		switch {
+		case event.Type == payment.EventPaymentFailed:
+			record.Outcome, record.Detail = EventApplied, "payment failed, the top-up is left as it is"
+		case topup.Status == TopupCredited:
+			record.Outcome, record.Detail = EventIgnored, "top-up is already credited"
+		case event.Currency != "INR" || event.Amount != ledger.Paisa(topup.Amount):
+			record.Outcome, record.Detail = EventRejected, reasonAmount
+		default:
+			if err := creditTopup(tx, topup, event.PaymentID); err != nil {
+				return err
+			}
+			credited = true
+			record.Outcome, record.Detail = EventApplied, "top-up credited"
+		}
+		return tx.UpdatePaymentEvent(record)
+	})
+	if err != nil {
+		return err
+	}
+	if record.Outcome == EventRejected {
+		usi.flagPayment(&entities.RazorPay{RazorPaymentID: event.PaymentID, RazorPayOrderID: event.OrderID}, 0, topup.ID, reasonAmount)
+	}
+	if credited {
+		usi.notifyTopup(topup)
+	}
+	return nil
+}
+
+// refundEvent function is used to add the processed refund to the payment it was taken from and mark the refund issued for it processed.
+func (usi *UserServiceImpl) refundEvent(record *entities.PaymentEvent, event *payment.Event) error {
+	return usi.repo.WithTx(func(tx repository.UserRepository) error {
+		added, err := tx.AddPaymentEvent(record)
+		if err != nil || !added {
+			return err
+		}
+		sent, err := tx.Refunds().FindRefundByGatewayID(event.RefundID)
+		if err != nil {
+			return err
+		}
+		if sent != nil && sent.Status != RefundProcessed {
+			now := time.Now()
+			sent.Status = RefundProcessed
+			sent.ProcessedAt = &now
+			if err := tx.Refunds().UpdateRefund(sent); err != nil {
+				return err
+			}
+		}
+		razor, err := tx.FindPayment(event.PaymentID)
+		if err != nil {
+			return err
+		}
+		if razor == nil {
+			record.Outcome, record.Detail = EventIgnored, "payment is not stored"
+			return tx.UpdatePaymentEvent(record)
+		}
+		refunded := ledger.Paisa(razor.AmountRefunded) + event.Amount
+		next := PaymentPartiallyRefunded
+		if refunded >= ledger.Paisa(razor.AmountPaid) {
+			next = PaymentRefunded
+		}
+		if !canMove(paymentStatus(razor), next) {
+			record.Outcome, record.Detail = EventIgnored, "payment is "+paymentStatus(razor)
+			return tx.UpdatePaymentEvent(record)
+		}
+		razor.Status = next
+		razor.AmountRefunded = rupees(refunded)
+		if err := tx.SavePayment(razor); err != nil {
+			log.Println("Error updating Payment Info, in webhook file")
+			return err
+		}
+		record.Outcome, record.Detail = EventApplied, "payment "+strings.ToLower(next)
+		return tx.UpdatePaymentEvent(record)
+	})
+}
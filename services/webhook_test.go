@@ -0,0 +1,280 @@
+package services
+
+import (
+	"errors"
+	"gobus/entities"
+	"gobus/payment"
+	"gobus/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_PaymentWebhook(t *testing.T) {
+	smsNotifier = func(messageText string, toNumber string) {}
+	defer func() { smsNotifier = WhatsappNotifier }()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	day, _ := time.Parse("02 01 2006", "01 01 2024")
+	booking := func(status string) *entities.Booking {
+		return &entities.Booking{BookingID: 1, UserID: 1, BusID: 1, PNR: "GB7K2M9Q", BookingDate: "01 01 2024", FarePostDiscount: 500, Status: status}
+	}
+	// outcome expects the event to be stored with the outcome
+	outcome := func(t *testing.T, userRepo *repository.MockUserRepository, want string) {
+		userRepo.EXPECT().UpdatePaymentEvent(gomock.Any()).DoAndReturn(func(event *entities.PaymentEvent) error {
+			if event.Outcome != want {
+				t.Errorf("services.PaymentWebhook() stored the event as %s (%s), want %s", event.Outcome, event.Detail, want)
+			}
+			return nil
+		})
+	}
+	// locked expects the chart of the booking locked and the event to be new
+	locked := func(userRepo *repository.MockUserRepository, found *entities.Booking) {
+		userRepo.EXPECT().FindBookingByPNR("GB7K2M9Q").Return(booking("Awaiting Payment"), nil)
+		userRepo.EXPECT().GetChartForUpdate(1, day).Return(&entities.BusSchedule{BusID: 1}, nil)
+		userRepo.EXPECT().AddPaymentEvent(gomock.Any()).Return(true, nil)
+		userRepo.EXPECT().FindBookingByID(1).Return(found, nil)
+	}
+	type mocks struct {
+		user   *repository.MockUserRepository
+		ledger *repository.MockLedgerRepository
+		refund *repository.MockRefundRepository
+	}
+	tests := []struct {
+		name       string
+		eventID    string
+		eventType  string
+		receipt    string
+		decline    bool
+		refunded   []int64
+		forged     bool
+		beforeTest func(t *testing.T, m mocks, paymentID string)
+		wantErr    bool
+	}{
+		{
+			name:      "success captured payment confirms the booking",
+			eventID:   "evt_1",
+			eventType: payment.EventPaymentCaptured,
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				locked(m.user, booking("Awaiting Payment"))
+				m.user.EXPECT().FindPayment(paymentID).Return(nil, nil).Times(2)
+				expectPost(m.ledger, "booking:1:payment", 50000)
+				m.user.EXPECT().UpdateBooking(gomock.Any()).DoAndReturn(func(booking *entities.Booking) (*entities.Booking, error) {
+					if booking.Status != "Success" {
+						t.Errorf("services.PaymentWebhook() left the booking %s", booking.Status)
+					}
+					return booking, nil
+				})
+				m.user.EXPECT().PaymentSuccess(gomock.Any()).Return(nil)
+				outcome(t, m.user, EventApplied)
+				// the e-ticket has tests of its own
+				m.user.EXPECT().GetUserInfo(1).Return(nil, errors.New("record not found"))
+			},
+			wantErr: false,
+		},
+		{
+			name:      "event sent again",
+			eventID:   "evt_1",
+			eventType: payment.EventPaymentCaptured,
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				m.user.EXPECT().FindBookingByPNR("GB7K2M9Q").Return(booking("Success"), nil)
+				m.user.EXPECT().GetChartForUpdate(1, day).Return(&entities.BusSchedule{BusID: 1}, nil)
+				m.user.EXPECT().AddPaymentEvent(gomock.Any()).Return(false, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "payment stored before by the callback",
+			eventID:   "evt_1",
+			eventType: payment.EventPaymentCaptured,
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				locked(m.user, booking("Success"))
+				m.user.EXPECT().FindPayment(paymentID).Return(&entities.RazorPay{BookID: 1, RazorPaymentID: paymentID, Status: PaymentCaptured}, nil)
+				outcome(t, m.user, EventIgnored)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "amount the booking does not charge",
+			eventID:   "evt_1",
+			eventType: payment.EventPaymentCaptured,
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				cheaper := booking("Awaiting Payment")
+				cheaper.FarePostDiscount = 400
+				locked(m.user, cheaper)
+				m.user.EXPECT().FindPayment(paymentID).Return(nil, nil)
+				outcome(t, m.user, EventRejected)
+				m.user.EXPECT().AddSuspiciousPayment(gomock.Any()).DoAndReturn(func(attempt *entities.SuspiciousPayment) error {
+					if attempt.Reason != reasonAmount || attempt.BookingID != 1 {
+						t.Errorf("services.PaymentWebhook() recorded %+v", attempt)
+					}
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name:      "failed payment leaves the booking waiting",
+			eventID:   "evt_1",
+			eventType: payment.EventPaymentFailed,
+			decline:   true,
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				locked(m.user, booking("Awaiting Payment"))
+				m.user.EXPECT().FindPayment(paymentID).Return(nil, nil)
+				m.user.EXPECT().PaymentSuccess(gomock.Any()).DoAndReturn(func(razor *entities.RazorPay) error {
+					if razor.Status != PaymentFailed {
+						t.Errorf("services.PaymentWebhook() stored the payment %+v", razor)
+					}
+					return nil
+				})
+				outcome(t, m.user, EventApplied)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "payment captured after the seat hold expired goes to the wallet",
+			eventID:   "evt_1",
+			eventType: payment.EventPaymentCaptured,
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				locked(m.user, booking("Expired"))
+				m.user.EXPECT().FindPayment(paymentID).Return(nil, nil).Times(2)
+				expectPost(m.ledger, "payment:"+paymentID+":unapplied", 50000)
+				m.user.EXPECT().PaymentSuccess(gomock.Any()).DoAndReturn(func(razor *entities.RazorPay) error {
+					if razor.Status != PaymentCredited {
+						t.Errorf("services.PaymentWebhook() stored the payment %+v", razor)
+					}
+					return nil
+				})
+				outcome(t, m.user, EventApplied)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "refund moves the payment to partially refunded",
+			eventID:   "evt_2",
+			eventType: payment.EventRefundProcessed,
+			refunded:  []int64{25000},
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				m.user.EXPECT().AddPaymentEvent(gomock.Any()).Return(true, nil)
+				m.refund.EXPECT().FindRefundByGatewayID(gomock.Any()).Return(nil, nil)
+				m.user.EXPECT().FindPayment(paymentID).Return(&entities.RazorPay{BookID: 1, RazorPaymentID: paymentID, AmountPaid: 500, Status: PaymentCaptured}, nil)
+				m.user.EXPECT().SavePayment(gomock.Any()).DoAndReturn(func(razor *entities.RazorPay) error {
+					if razor.Status != PaymentPartiallyRefunded || razor.AmountRefunded != 250 {
+						t.Errorf("services.PaymentWebhook() stored the payment %+v", razor)
+					}
+					return nil
+				})
+				outcome(t, m.user, EventApplied)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "refund issued by a cancellation is processed",
+			eventID:   "evt_3",
+			eventType: payment.EventRefundProcessed,
+			refunded:  []int64{25000, 25000},
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				m.user.EXPECT().AddPaymentEvent(gomock.Any()).Return(true, nil)
+				m.refund.EXPECT().FindRefundByGatewayID(gomock.Any()).Return(&entities.Refund{ID: 1, Status: RefundIssued}, nil)
+				m.refund.EXPECT().UpdateRefund(gomock.Any()).DoAndReturn(func(refund *entities.Refund) error {
+					if refund.Status != RefundProcessed || refund.ProcessedAt == nil {
+						t.Errorf("services.PaymentWebhook() stored the refund %+v", refund)
+					}
+					return nil
+				})
+				m.user.EXPECT().FindPayment(paymentID).Return(&entities.RazorPay{BookID: 1, RazorPaymentID: paymentID, AmountPaid: 500, AmountRefunded: 250, Status: PaymentPartiallyRefunded}, nil)
+				m.user.EXPECT().SavePayment(gomock.Any()).DoAndReturn(func(razor *entities.RazorPay) error {
+					if razor.Status != PaymentRefunded || razor.AmountRefunded != 500 {
+						t.Errorf("services.PaymentWebhook() stored the payment %+v", razor)
+					}
+					return nil
+				})
+				outcome(t, m.user, EventApplied)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "captured payment credits the wallet top-up",
+			eventID:   "evt_4",
+			eventType: payment.EventPaymentCaptured,
+			receipt:   "topup-1-1",
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				m.user.EXPECT().AddPaymentEvent(gomock.Any()).Return(true, nil)
+				m.user.EXPECT().FindTopupForUpdate(gomock.Any()).Return(&entities.WalletTopup{ID: 1, UserID: 1, Amount: 500, Status: TopupCreated}, nil)
+				expectPost(m.ledger, "topup:1", 50000)
+				m.user.EXPECT().UpdateTopup(gomock.Any()).Return(nil)
+				outcome(t, m.user, EventApplied)
+				m.user.EXPECT().GetUserInfo(1).Return(&entities.User{ID: 1, PhoneNumber: "1234567890"}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "event that is not handled",
+			eventID:   "evt_5",
+			eventType: "order.paid",
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {
+				m.user.EXPECT().AddPaymentEvent(gomock.Any()).DoAndReturn(func(event *entities.PaymentEvent) (bool, error) {
+					if event.Outcome != EventIgnored {
+						t.Errorf("services.PaymentWebhook() stored the event as %s", event.Outcome)
+					}
+					return true, nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name:       "forged signature",
+			eventID:    "evt_1",
+			eventType:  payment.EventPaymentCaptured,
+			forged:     true,
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {},
+			wantErr:    true,
+		},
+		{
+			name:       "event without an id",
+			eventID:    "",
+			eventType:  payment.EventPaymentCaptured,
+			beforeTest: func(t *testing.T, m mocks, paymentID string) {},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := payment.NewFakeGateway("")
+			gateway.Decline = tt.decline
+			receipt := tt.receipt
+			if receipt == "" {
+				receipt = "GB7K2M9Q"
+			}
+			order, _ := gateway.CreateOrder(50000, receipt)
+			paid, _, _ := gateway.Pay(order.ID)
+			for _, amount := range tt.refunded {
+				if _, err := gateway.Refund(paid.ID, amount, receipt); err != nil {
+					t.Fatalf("payment.FakeGateway.Refund() error = %v", err)
+				}
+			}
+			body, signature, err := gateway.Webhook(tt.eventType, paid.ID)
+			if err != nil {
+				t.Fatalf("payment.FakeGateway.Webhook() error = %v", err)
+			}
+			if tt.forged {
+				signature = payment.SignWebhook("guessed", body)
+			}
+			m := mocks{
+				user:   repository.NewMockUserRepository(ctrl),
+				ledger: repository.NewMockLedgerRepository(ctrl),
+				refund: repository.NewMockRefundRepository(ctrl),
+			}
+			expectTx(m.user)
+			expectLedger(m.ledger)
+			m.user.EXPECT().Ledger().Return(m.ledger).AnyTimes()
+			m.user.EXPECT().Refunds().Return(m.refund).AnyTimes()
+			tt.beforeTest(t, m, paid.ID)
+			u := &UserServiceImpl{repo: m.user, hold: noHold{}, gateway: gateway}
+			if err := u.PaymentWebhook(tt.eventID, body, signature); (err != nil) != tt.wantErr {
+				t.Errorf("services.PaymentWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package ticket
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ManifestRow struct is a seat of the trip with the passenger travelling on it.
+type ManifestRow struct {
+	Seat          string
+	PNR           string
+	Name          string
+	Age           uint
+	Gender        string
+	From          string
+	To            string
+	BoardingPoint string
+	Boarded       bool
+}
+
+// Manifest struct holds the passenger list of a trip handed to the crew.
+type Manifest struct {
+	BusNumber string
+	Route     string
+	Date      string
+	Departure string
+	Rows      []ManifestRow
+}
+
+// RenderManifest function is used to lay out the manifest as an A4 PDF, the column titles are repeated on every page.
+func RenderManifest(m *Manifest) ([]byte, error) {
+	if m == nil || m.BusNumber == "" {
+		return nil, errors.New("manifest has no bus")
+	}
+	d := newDocument()
+	d.space(4)
+	d.text(margin, bold, 20, "Passenger Manifest")
+	d.space(24)
+	d.text(margin, regular, 10, fmt.Sprintf("Bus %s, %s", m.BusNumber, m.Route))
+	d.space(14)
+	d.text(margin, regular, 10, fmt.Sprintf("Date %s, departure %s, %d passengers", m.Date, m.Departure, len(m.Rows)))
+	d.rule()
+	d.space(8)
+
+	columns := []float64{margin, 85, 150, 265, 290, 315, 385, 455, 525}
+	widths := []int{7, 12, 22, 3, 3, 13, 13, 14, 3}
+	row := func(font string, cells ...string) {
+		d.space(14)
+		for i, cell := range cells {
+			d.text(columns[i], font, 8, fit(cell, widths[i]))
+		}
+	}
+	header := func() {
+		row(bold, "Seat", "PNR", "Name", "Age", "Sex", "From", "To", "Boarding point", "In")
+	}
+	header()
+	for _, r := range m.Rows {
+		if !d.fits(14) {
+			d.newPage()
+			header()
+		}
+		boarded := ""
+		if r.Boarded {
+			boarded = "Yes"
+		}
+		row(regular, r.Seat, r.PNR, r.Name, fmt.Sprint(r.Age), r.Gender, r.From, r.To, r.BoardingPoint, boarded)
+	}
+	return d.bytes(), nil
+}
+
+// fit function is used to cut the text down to the given number of characters so it stays in its column.
+func fit(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "."
+}
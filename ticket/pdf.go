@@ -0,0 +1,129 @@
+package ticket
+
+import (
+	"bytes"
+	"fmt"
+	"gobus/qrcode"
+	"strings"
+)
+
+// Page size of an A4 sheet and the margin kept free around it, in PDF points.
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 50.0
+)
+
+// Fonts of the document, both are standard PDF fonts so nothing has to be embedded.
+const (
+	regular = "F1"
+	bold    = "F2"
+)
+
+// document struct is a minimal PDF writer that lays out lines of text from the top of the page down.
+type document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func newDocument() *document {
+	d := &document{}
+	d.newPage()
+	return d
+}
+
+func (d *document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+func (d *document) current() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// fits function reports whether a line of the given height still fits on the current page.
+func (d *document) fits(height float64) bool {
+	return d.y-height >= margin
+}
+
+// space function is used to make room for the given height, starting a new page when the current one is full.
+func (d *document) space(height float64) {
+	if !d.fits(height) {
+		d.newPage()
+	}
+	d.y -= height
+}
+
+// text function is used to write the text at the given position from the left edge and the current line.
+func (d *document) text(x float64, font string, size float64, s string) {
+	fmt.Fprintf(d.current(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, escape(s))
+}
+
+// rule function is used to draw a horizontal line across the page just under the current line.
+func (d *document) rule() {
+	fmt.Fprintf(d.current(), "0.6 w %.2f %.2f m %.2f %.2f l S\n", margin, d.y-6, pageWidth-margin, d.y-6)
+}
+
+// square function is used to draw a QR code with its top left corner at the given position, side is the width of the code without its quiet zone.
+func (d *document) square(code *qrcode.Code, x float64, top float64, side float64) {
+	module := side / float64(code.Size)
+	page := d.current()
+	for row := 0; row < code.Size; row++ {
+		for col := 0; col < code.Size; col++ {
+			if code.Dark(col, row) {
+				fmt.Fprintf(page, "%.2f %.2f %.2f %.2f re\n", x+float64(col)*module, top-float64(row+1)*module, module, module)
+			}
+		}
+	}
+	page.WriteString("f\n")
+}
+
+// escape function is used to encode the text as a PDF string, characters outside of the standard fonts are replaced.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '₹':
+			b.WriteString("Rs.")
+		case r >= 32 && r <= 126:
+			b.WriteRune(r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// bytes function is used to write out the whole document with its cross reference table.
+func (d *document) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	out.WriteString("%PDF-1.4\n")
+	// catalog, page tree and fonts come first, then a page and its content for every page
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, regular, bold, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", page.Len(), page.String()))
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
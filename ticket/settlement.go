@@ -0,0 +1,67 @@
+package ticket
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SettlementRow struct is a booking whose fare was released to the provider once its trip completed, the amounts are in rupees.
+type SettlementRow struct {
+	BookingID  uint
+	PNR        string
+	BusID      uint
+	TripDate   string
+	Gross      float64
+	Percent    float64
+	Commission float64
+	Net        float64
+}
+
+// Settlement struct holds the settlement statement of a payout sent to the provider.
+type Settlement struct {
+	PayoutID   uint
+	Provider   string
+	Status     string
+	Created    string
+	Gross      float64
+	Commission float64
+	Net        float64
+	Rows       []SettlementRow
+}
+
+// RenderSettlement function is used to lay out the settlement statement as an A4 PDF, the column titles are repeated on every page.
+func RenderSettlement(s *Settlement) ([]byte, error) {
+	if s == nil || s.PayoutID == 0 {
+		return nil, errors.New("settlement has no payout")
+	}
+	d := newDocument()
+	d.space(4)
+	d.text(margin, bold, 20, "Settlement Statement")
+	d.space(24)
+	d.text(margin, regular, 10, fmt.Sprintf("Payout %d to %s, %s on %s", s.PayoutID, s.Provider, s.Status, s.Created))
+	d.space(14)
+	d.text(margin, regular, 10, fmt.Sprintf("%d bookings, fares Rs %.2f, commission Rs %.2f, paid out Rs %.2f", len(s.Rows), s.Gross, s.Commission, s.Net))
+	d.rule()
+	d.space(8)
+
+	columns := []float64{margin, 100, 175, 215, 285, 355, 400, 470}
+	widths := []int{9, 12, 6, 10, 11, 6, 11, 11}
+	row := func(font string, cells ...string) {
+		d.space(14)
+		for i, cell := range cells {
+			d.text(columns[i], font, 8, fit(cell, widths[i]))
+		}
+	}
+	header := func() {
+		row(bold, "Booking", "PNR", "Bus", "Trip", "Fare", "Rate", "Commission", "Net")
+	}
+	header()
+	for _, r := range s.Rows {
+		if !d.fits(14) {
+			d.newPage()
+			header()
+		}
+		row(regular, fmt.Sprint(r.BookingID), r.PNR, fmt.Sprint(r.BusID), r.TripDate, fmt.Sprintf("%.2f", r.Gross), fmt.Sprintf("%g%%", r.Percent), fmt.Sprintf("%.2f", r.Commission), fmt.Sprintf("%.2f", r.Net))
+	}
+	return d.bytes(), nil
+}
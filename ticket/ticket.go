@@ -0,0 +1,144 @@
+package ticket
+
+import (
+	"errors"
+	"fmt"
+	"gobus/qrcode"
+)
+
+// Passenger struct is a line of the ticket, a cancelled seat is still listed with its status.
+type Passenger struct {
+	Name   string
+	Age    uint
+	Gender string
+	Seat   string
+	Fare   float64
+	Status string
+}
+
+// Fare struct is the fare breakdown of the booking.
+type Fare struct {
+	BaseFare         float64
+	Discount         float64
+	Payable          float64
+	RescheduleCredit float64
+	Refunded         float64
+}
+
+// Ticket struct holds everything printed on the e-ticket of a booking.
+type Ticket struct {
+	PNR           string
+	Status        string
+	BusNumber     string
+	BusType       string
+	Route         string
+	From          string
+	To            string
+	Date          string
+	Departure     string
+	Arrival       string
+	BoardingPoint string
+	BoardingTime  string
+	DroppingPoint string
+	DroppingTime  string
+	Passengers    []Passenger
+	Fare          Fare
+	// Pass is the signed boarding pass printed as a QR code, the crew scans it while boarding
+	Pass string
+}
+
+// qrSide is the width of the boarding QR code, it sits to the right of the journey details.
+const qrSide = 110.0
+
+// Render function is used to lay out the ticket as a single A4 PDF, more pages are added when the passengers do not fit.
+func Render(t *Ticket) ([]byte, error) {
+	if t == nil || t.PNR == "" {
+		return nil, errors.New("ticket has no PNR")
+	}
+	d := newDocument()
+	d.space(4)
+	d.text(margin, bold, 22, "GoBus e-Ticket")
+	d.text(360, bold, 14, "PNR: "+t.PNR)
+	d.space(18)
+	d.text(360, regular, 10, "Status: "+t.Status)
+	d.rule()
+
+	section(d, "Journey")
+	if t.Pass != "" {
+		code, err := qrcode.Encode([]byte(t.Pass))
+		if err != nil {
+			return nil, err
+		}
+		d.square(code, pageWidth-margin-qrSide, d.y, qrSide)
+	}
+	field(d, "Bus", join(t.BusNumber, t.BusType))
+	field(d, "Route", t.Route)
+	field(d, "From", t.From)
+	field(d, "To", t.To)
+	field(d, "Date", t.Date)
+	field(d, "Departure", t.Departure)
+	field(d, "Arrival", t.Arrival)
+	if t.BoardingPoint != "" {
+		field(d, "Boarding point", join(t.BoardingPoint, t.BoardingTime))
+	}
+	if t.DroppingPoint != "" {
+		field(d, "Dropping point", join(t.DroppingPoint, t.DroppingTime))
+	}
+
+	section(d, "Passengers")
+	columns := []float64{margin, 250, 300, 370, 420, 490}
+	row := func(font string, cells ...string) {
+		d.space(16)
+		for i, cell := range cells {
+			d.text(columns[i], font, 10, cell)
+		}
+	}
+	row(bold, "Name", "Age", "Gender", "Seat", "Fare", "Status")
+	for _, p := range t.Passengers {
+		row(regular, p.Name, fmt.Sprint(p.Age), p.Gender, p.Seat, amount(p.Fare), p.Status)
+	}
+
+	section(d, "Fare")
+	field(d, "Base fare", amount(t.Fare.BaseFare))
+	if t.Fare.Discount > 0 {
+		field(d, "Coupon discount", "- "+amount(t.Fare.Discount))
+	}
+	field(d, "Total", amount(t.Fare.Payable))
+	if t.Fare.RescheduleCredit > 0 {
+		field(d, "Paid on the original booking", amount(t.Fare.RescheduleCredit))
+	}
+	if t.Fare.Refunded > 0 {
+		field(d, "Refunded", amount(t.Fare.Refunded))
+	}
+
+	d.space(36)
+	d.text(margin, regular, 9, "Please carry a photo ID and show the QR code of this ticket while boarding.")
+	return d.bytes(), nil
+}
+
+func section(d *document, title string) {
+	d.space(30)
+	d.text(margin, bold, 13, title)
+	d.rule()
+	d.space(6)
+}
+
+func field(d *document, label string, value string) {
+	if value == "" {
+		return
+	}
+	d.space(16)
+	d.text(margin, bold, 10, label)
+	d.text(180, regular, 10, value)
+}
+
+func join(value string, detail string) string {
+	if detail == "" {
+		return value
+	}
+	return value + " (" + detail + ")"
+}
+
+func amount(value float64) string {
+	return fmt.Sprintf("Rs. %.2f", value)
+}
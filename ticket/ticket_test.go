@@ -0,0 +1,123 @@
+package ticket
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func Test_Render(t *testing.T) {
+	ticket := &Ticket{
+		PNR:           "ABCD234XYZ",
+		Status:        "Success",
+		BusNumber:     "KL-13-1234",
+		Route:         "Kannur to Bangalore",
+		From:          "Kannur",
+		To:            "Bangalore",
+		Date:          "01 01 2030",
+		BoardingPoint: "Thavakkara (Bus stand)",
+		Passengers:    []Passenger{{Name: "Asha", Age: 30, Gender: "F", Seat: "01A", Fare: 450, Status: "Booked"}},
+		Fare:          Fare{BaseFare: 500, Discount: 50, Payable: 450},
+		Pass:          "GOBUS1|42|20300101|01A|signature",
+	}
+	pdf, err := Render(ticket)
+	if err != nil {
+		t.Fatalf("ticket.Render() error = %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatalf("ticket.Render() is not a PDF")
+	}
+	for _, want := range []string{"(PNR: ABCD234XYZ)", "(Asha)", "(01A)", "(Rs. 450.00)", `(Thavakkara \(Bus stand\))`} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("ticket.Render() is missing %s", want)
+		}
+	}
+
+	if squares := bytes.Count(pdf, []byte(" re\n")); squares < 100 {
+		t.Errorf("ticket.Render() drew %d QR modules", squares)
+	}
+
+	// every object has to start at the offset the cross reference table gives for it
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(pdf[xref:], -1)
+	if len(entries) != 6 {
+		t.Fatalf("xref has %d objects, want 6", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(pdf[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))) {
+			t.Errorf("xref entry %d points at %q", i+1, pdf[offset:offset+10])
+		}
+	}
+}
+
+func Test_Render_Pages(t *testing.T) {
+	ticket := &Ticket{PNR: "ABCD234XYZ"}
+	for i := 0; i < 50; i++ {
+		ticket.Passengers = append(ticket.Passengers, Passenger{Name: fmt.Sprint("Passenger ", i), Seat: fmt.Sprint(i)})
+	}
+	pdf, err := Render(ticket)
+	if err != nil {
+		t.Fatalf("ticket.Render() error = %v", err)
+	}
+	if !bytes.Contains(pdf, []byte("/Count 2")) {
+		t.Errorf("ticket.Render() did not move the passengers over to a second page")
+	}
+	if _, err := Render(&Ticket{}); err == nil {
+		t.Errorf("ticket.Render() rendered a ticket without PNR")
+	}
+}
+
+func Test_escape(t *testing.T) {
+	if got := escape(`a(b)\c ₹ é`); got != `a\(b\)\\c Rs. ?` {
+		t.Errorf("escape() = %q", got)
+	}
+}
+
+func Test_RenderManifest(t *testing.T) {
+	manifest := &Manifest{BusNumber: "KL-13-1234", Route: "Kannur to Bangalore", Date: "01 01 2030", Departure: "22:00"}
+	for i := 0; i < 60; i++ {
+		manifest.Rows = append(manifest.Rows, ManifestRow{Seat: fmt.Sprintf("%02dA", i), PNR: "ABCD234XYZ", Name: "Passenger with a rather long name", Age: 30, Gender: "F", Boarded: i%2 == 0})
+	}
+	pdf, err := RenderManifest(manifest)
+	if err != nil {
+		t.Fatalf("ticket.RenderManifest() error = %v", err)
+	}
+	if !bytes.Contains(pdf, []byte("/Count 2")) {
+		t.Fatalf("ticket.RenderManifest() did not move the passengers over to a second page")
+	}
+	if headers := bytes.Count(pdf, []byte("(Boarding point)")); headers != 2 {
+		t.Errorf("ticket.RenderManifest() printed the column titles %d times, want 2", headers)
+	}
+	for _, want := range []string{"(Bus KL-13-1234, Kannur to Bangalore)", "(59A)", "(Passenger with a rath.)"} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("ticket.RenderManifest() is missing %s", want)
+		}
+	}
+	if _, err := RenderManifest(&Manifest{}); err == nil {
+		t.Errorf("ticket.RenderManifest() rendered a manifest without bus")
+	}
+}
+
+func Test_RenderSettlement(t *testing.T) {
+	settlement := &Settlement{PayoutID: 7, Provider: "Kerala Travels", Status: "Pending", Created: "01 01 2030", Gross: 1000, Commission: 100, Net: 900}
+	settlement.Rows = append(settlement.Rows, SettlementRow{BookingID: 12, PNR: "ABCD234XYZ", BusID: 3, TripDate: "31 12 2029", Gross: 1000, Percent: 10, Commission: 100, Net: 900})
+	pdf, err := RenderSettlement(settlement)
+	if err != nil {
+		t.Fatalf("ticket.RenderSettlement() error = %v", err)
+	}
+	for _, want := range []string{"(Payout 7 to Kerala Travels, Pending on 01 01 2030)", "(ABCD234XYZ)", "(10%)", "(900.00)"} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("ticket.RenderSettlement() is missing %s", want)
+		}
+	}
+	if _, err := RenderSettlement(&Settlement{}); err == nil {
+		t.Errorf("ticket.RenderSettlement() rendered a statement without payout")
+	}
+}